@@ -6,6 +6,10 @@
 // @in cookie
 // @name auth_token
 // @description JWT authentication via HTTP-only cookie. Use /auth/login to authenticate, then the cookie will be automatically included in requests.
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token in the Authorization header as "Bearer <token>". Takes precedence over the auth_token cookie. Get a token from /auth/login with "token_mode": "body".
 package main
 
 import (
@@ -39,6 +43,9 @@ func main() {
 	}
 	defer server.Close()
 
+	// Purge expired soft-deleted ideas in the background
+	server.StartBackgroundJobs()
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
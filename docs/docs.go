@@ -92,6 +92,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Get current authenticated user information",
@@ -192,6 +195,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Create a new feedback idea (authentication required)",
@@ -249,6 +255,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Update the title and content of an existing feedback idea (authentication required, creator only)",
@@ -327,6 +336,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Get all roles in the system",
@@ -365,6 +377,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Create a new role (Super User only)",
@@ -434,6 +449,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Get a role by its ID",
@@ -484,6 +502,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Update a role's name (Super User only)",
@@ -564,6 +585,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Delete a role (Super User only, cannot delete Super User role)",
@@ -622,6 +646,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Get all users in the system",
@@ -660,6 +687,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Create a new user with role assignment (authorization rules apply)",
@@ -729,6 +759,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Get a user by their ID",
@@ -779,6 +812,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Update a user's name (email is immutable)",
@@ -853,6 +889,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Delete a user (authorization rules apply)",
@@ -905,6 +944,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
                 "description": "Update a user's role (Super User only)",
@@ -1168,6 +1210,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT access token in the Authorization header as \"Bearer \u003ctoken\u003e\". Takes precedence over the auth_token cookie. Get a token from /auth/login with \"token_mode\": \"body\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
         "JWTAuth": {
             "description": "JWT authentication via HTTP-only cookie. Use /auth/login to authenticate, then the cookie will be automatically included in requests.",
             "type": "apiKey",
@@ -0,0 +1,277 @@
+package application
+
+import (
+	"context"
+	commentdomain "feedback_hub_2/internal/comment/domain"
+	"feedback_hub_2/internal/shared/auth"
+	events "feedback_hub_2/internal/shared/bus"
+	"feedback_hub_2/internal/shared/queries"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// CommentApplicationService orchestrates comment-related use cases.
+// AI-hint: Application service for idea discussions. Uses shared queries to validate
+// ideas and users so the comment domain stays independent of the idea and user domains.
+type CommentApplicationService struct {
+	commentRepo    commentdomain.Repository
+	ideaQueries    queries.IdeaQueries
+	userQueries    queries.UserQueries
+	roleQueries    queries.RoleQueries
+	authService    *auth.AuthorizationService
+	eventPublisher events.EventPublisher
+	maxReplyDepth  int
+
+	requireVerifiedEmail bool
+}
+
+// NewCommentApplicationService creates a new CommentApplicationService instance.
+// AI-hint: Factory method for comment service with dependency injection. A negative
+// maxReplyDepth falls back to commentdomain.DefaultMaxReplyDepth. With
+// requireVerifiedEmail, users must verify their email address before commenting.
+func NewCommentApplicationService(commentRepo commentdomain.Repository, ideaQueries queries.IdeaQueries, userQueries queries.UserQueries, roleQueries queries.RoleQueries, authService *auth.AuthorizationService, eventPublisher events.EventPublisher, maxReplyDepth int, requireVerifiedEmail bool) *CommentApplicationService {
+	if maxReplyDepth < 0 {
+		maxReplyDepth = commentdomain.DefaultMaxReplyDepth
+	}
+
+	return &CommentApplicationService{
+		commentRepo:    commentRepo,
+		ideaQueries:    ideaQueries,
+		userQueries:    userQueries,
+		roleQueries:    roleQueries,
+		authService:    authService,
+		eventPublisher: eventPublisher,
+		maxReplyDepth:  maxReplyDepth,
+
+		requireVerifiedEmail: requireVerifiedEmail,
+	}
+}
+
+// MaxReplyDepth returns the configured maximum reply nesting depth.
+func (s *CommentApplicationService) MaxReplyDepth() int {
+	return s.maxReplyDepth
+}
+
+// CreateComment posts a comment on an idea, optionally as a reply to another comment.
+// AI-hint: parentID is nil for top-level comments. Replies must belong to the same idea
+// and respect the configured depth limit. Publishes a comment.created event.
+// Returns ErrEmailNotVerified when email verification is required and the author
+// has not verified their address.
+func (s *CommentApplicationService) CreateComment(ctx interface{}, ideaID uuid.UUID, parentID *uuid.UUID, content string, authorUserID string) (*commentdomain.Comment, error) {
+	context := ctx.(context.Context)
+
+	// Validate that the author exists using shared queries
+	author, err := s.userQueries.GetUserByID(context, authorUserID)
+	if err != nil {
+		return nil, commentdomain.ErrAuthorNotFound
+	}
+	if s.requireVerifiedEmail && !author.EmailVerified {
+		return nil, commentdomain.ErrEmailNotVerified
+	}
+	authorUUID, err := uuid.Parse(authorUserID)
+	if err != nil {
+		return nil, commentdomain.ErrAuthorNotFound
+	}
+	userCtx, err := s.getUserContext(context, authorUserID)
+	if err != nil {
+		return nil, commentdomain.ErrAuthorNotFound
+	}
+
+	// Validate that the idea exists and the author may see it using shared queries
+	exists, err := s.ideaQueries.CanViewIdea(context, ideaID.String(), userCtx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, commentdomain.ErrIdeaNotFound
+	}
+
+	var newComment *commentdomain.Comment
+	if parentID == nil {
+		newComment, err = commentdomain.NewComment(ideaID, authorUUID, content)
+	} else {
+		parent, findErr := s.commentRepo.FindByID(context, *parentID)
+		if findErr != nil {
+			if findErr == commentdomain.ErrCommentNotFound {
+				return nil, commentdomain.ErrParentNotFound
+			}
+			return nil, findErr
+		}
+		if parent.IdeaID != ideaID {
+			return nil, commentdomain.ErrParentNotFound
+		}
+		newComment, err = commentdomain.NewReply(parent, authorUUID, content, s.maxReplyDepth)
+	}
+	if err != nil {
+		if err == commentdomain.ErrMaxDepthExceeded {
+			return nil, err
+		}
+		return nil, commentdomain.ErrInvalidCommentData
+	}
+
+	if err := s.commentRepo.Save(context, newComment); err != nil {
+		return nil, err
+	}
+
+	// Publish domain event for the new comment
+	parentIDValue := ""
+	if newComment.ParentID != nil {
+		parentIDValue = newComment.ParentID.String()
+	}
+	createdEvent := events.NewCommentCreatedEvent(newComment.ID.String(), ideaID.String(), parentIDValue, authorUserID)
+	if err := s.eventPublisher.PublishEvent(context, createdEvent); err != nil {
+		log.Printf("Warning: failed to publish comment created event: %v", err)
+		// Don't fail the operation if event publishing fails
+	}
+
+	return newComment, nil
+}
+
+// GetThreads retrieves all comments on an idea arranged as reply trees.
+// AI-hint: Returns ErrIdeaNotFound for unknown ideas and ideas the viewer may not see,
+// so callers can distinguish "no comments yet" from "no such idea".
+func (s *CommentApplicationService) GetThreads(ctx interface{}, ideaID uuid.UUID, viewerUserID string) ([]*commentdomain.Thread, error) {
+	context := ctx.(context.Context)
+
+	userCtx, err := s.getUserContext(context, viewerUserID)
+	if err != nil {
+		return nil, commentdomain.ErrAuthorNotFound
+	}
+
+	exists, err := s.ideaQueries.CanViewIdea(context, ideaID.String(), userCtx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, commentdomain.ErrIdeaNotFound
+	}
+
+	comments, err := s.commentRepo.FindByIdeaID(context, ideaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentdomain.BuildThreads(comments), nil
+}
+
+// UpdateComment edits a comment's content with authorization checks.
+// AI-hint: Only the author or a user with the comment moderation permission
+// (Product Owner) may edit, and only while they may still see the idea.
+func (s *CommentApplicationService) UpdateComment(ctx interface{}, commentID uuid.UUID, content string, updatedByUserID string) (*commentdomain.Comment, error) {
+	context := ctx.(context.Context)
+
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(context, updatedByUserID)
+	if err != nil {
+		return nil, commentdomain.ErrAuthorNotFound
+	}
+
+	existingComment, err := s.commentRepo.FindByID(context, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkIdeaVisible(context, existingComment.IdeaID, userCtx); err != nil {
+		return nil, err
+	}
+
+	// Check authorization - author or moderator only
+	if !s.canModify(userCtx, existingComment, auth.PermissionUpdateAnyComment) {
+		return nil, commentdomain.ErrUnauthorized
+	}
+
+	if err := existingComment.UpdateContent(content); err != nil {
+		return nil, commentdomain.ErrInvalidCommentData
+	}
+
+	if err := s.commentRepo.Update(context, existingComment); err != nil {
+		return nil, err
+	}
+
+	return existingComment, nil
+}
+
+// DeleteComment deletes a comment and its replies with authorization checks.
+// AI-hint: Only the author or a user with the comment moderation permission
+// (Product Owner) may delete, and only while they may still see the idea.
+func (s *CommentApplicationService) DeleteComment(ctx interface{}, commentID uuid.UUID, deletedByUserID string) error {
+	context := ctx.(context.Context)
+
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(context, deletedByUserID)
+	if err != nil {
+		return commentdomain.ErrAuthorNotFound
+	}
+
+	existingComment, err := s.commentRepo.FindByID(context, commentID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkIdeaVisible(context, existingComment.IdeaID, userCtx); err != nil {
+		return err
+	}
+
+	// Check authorization - author or moderator only
+	if !s.canModify(userCtx, existingComment, auth.PermissionDeleteAnyComment) {
+		return commentdomain.ErrUnauthorized
+	}
+
+	return s.commentRepo.Delete(context, commentID)
+}
+
+// checkIdeaVisible returns ErrIdeaNotFound unless the user may see the comment's idea.
+// AI-hint: Users who lost access to an idea, e.g. after its audience changed, can no
+// longer touch their comments on it.
+func (s *CommentApplicationService) checkIdeaVisible(ctx context.Context, ideaID uuid.UUID, userCtx *auth.UserContext) error {
+	visible, err := s.ideaQueries.CanViewIdea(ctx, ideaID.String(), userCtx)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return commentdomain.ErrIdeaNotFound
+	}
+	return nil
+}
+
+// canModify checks whether the user may modify the comment, either as its author
+// or by holding the given moderation permission.
+func (s *CommentApplicationService) canModify(userCtx *auth.UserContext, comment *commentdomain.Comment, moderationPermission auth.Permission) bool {
+	userUUID, err := uuid.Parse(userCtx.UserID)
+	if err != nil {
+		return false
+	}
+	return comment.CanBeModifiedBy(userUUID, s.authService.CanPerform(userCtx, moderationPermission))
+}
+
+// getUserContext retrieves the user context for authorization.
+// AI-hint: Helper method to build authorization context from user ID using shared queries.
+func (s *CommentApplicationService) getUserContext(ctx context.Context, userID string) (*auth.UserContext, error) {
+	if userID == "" {
+		return nil, auth.ErrInvalidContext
+	}
+
+	// Reuse the caller resolved by the auth middleware
+	if userCtx, ok := auth.UserContextFor(ctx, userID); ok {
+		return userCtx, nil
+	}
+
+	// Get the user to determine their role
+	user, err := s.userQueries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user's role to determine their permissions
+	userRole, err := s.roleQueries.GetRoleByID(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UserContext{
+		UserID:      userID,
+		RoleID:      userRole.ID,
+		RoleName:    userRole.Name,
+		Scopes:      auth.ScopesFromContext(ctx),
+		MFAVerified: auth.MFAVerifiedFromContext(ctx),
+	}, nil
+}
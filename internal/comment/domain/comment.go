@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Comment limits.
+// AI-hint: DefaultMaxReplyDepth is used when no reply depth is configured. Depth 0 is a
+// top-level comment, so a max depth of 0 disables replies entirely.
+const (
+	DefaultMaxReplyDepth = 3
+	MaxContentLength     = 5000
+)
+
+// Comment represents a single message in an idea's discussion thread.
+// AI-hint: Core domain entity for comments. ParentID is nil for top-level comments;
+// Depth is derived from the parent at creation time and never changes afterwards.
+type Comment struct {
+	ID           uuid.UUID  `json:"id"`
+	IdeaID       uuid.UUID  `json:"idea_id"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	AuthorUserID uuid.UUID  `json:"author_user_id"`
+	Content      string     `json:"content"`
+	Depth        int        `json:"depth"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// NewComment creates a new top-level comment on an idea with validation.
+// AI-hint: Factory method that enforces content rules and generates identity and timestamps.
+func NewComment(ideaID, authorUserID uuid.UUID, content string) (*Comment, error) {
+	if ideaID == uuid.Nil {
+		return nil, errors.New("idea ID cannot be empty")
+	}
+	if authorUserID == uuid.Nil {
+		return nil, errors.New("author user ID cannot be empty")
+	}
+	content, err := validateContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Comment{
+		ID:           uuid.New(),
+		IdeaID:       ideaID,
+		AuthorUserID: authorUserID,
+		Content:      content,
+		Depth:        0,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// NewReply creates a reply to an existing comment with validation.
+// AI-hint: Business rule - replies inherit the parent's idea and may not nest deeper
+// than maxReplyDepth; violations return ErrMaxDepthExceeded.
+func NewReply(parent *Comment, authorUserID uuid.UUID, content string, maxReplyDepth int) (*Comment, error) {
+	if parent == nil {
+		return nil, ErrParentNotFound
+	}
+	if parent.Depth+1 > maxReplyDepth {
+		return nil, ErrMaxDepthExceeded
+	}
+
+	reply, err := NewComment(parent.IdeaID, authorUserID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID := parent.ID
+	reply.ParentID = &parentID
+	reply.Depth = parent.Depth + 1
+	return reply, nil
+}
+
+// UpdateContent updates the comment's content with validation.
+// AI-hint: Domain method that maintains content invariants and updates the modification timestamp.
+func (c *Comment) UpdateContent(content string) error {
+	content, err := validateContent(content)
+	if err != nil {
+		return err
+	}
+	c.Content = content
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// CanBeModifiedBy reports whether the given user may edit or delete this comment.
+// AI-hint: Business rule - only the author may change a comment unless the caller
+// holds a moderation permission (e.g. Product Owners moderating discussions).
+func (c *Comment) CanBeModifiedBy(userID uuid.UUID, canModerate bool) bool {
+	if canModerate {
+		return true
+	}
+	return userID != uuid.Nil && c.AuthorUserID == userID
+}
+
+// validateContent trims comment content and enforces non-empty and length rules.
+func validateContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", errors.New("comment content cannot be empty")
+	}
+	if utf8.RuneCountInString(content) > MaxContentLength {
+		return "", errors.New("comment content is too long")
+	}
+	return content, nil
+}
+
+// Thread is a comment together with its nested replies.
+// AI-hint: Read model used to render discussions as a tree.
+type Thread struct {
+	Comment *Comment
+	Replies []*Thread
+}
+
+// BuildThreads arranges a flat list of comments into reply trees.
+// AI-hint: Input is expected in chronological order; sibling order is preserved.
+// Comments whose parent is not in the list are treated as roots so nothing is dropped.
+func BuildThreads(comments []*Comment) []*Thread {
+	nodes := make(map[uuid.UUID]*Thread, len(comments))
+	for _, comment := range comments {
+		nodes[comment.ID] = &Thread{Comment: comment, Replies: []*Thread{}}
+	}
+
+	roots := make([]*Thread, 0)
+	for _, comment := range comments {
+		node := nodes[comment.ID]
+		if comment.ParentID != nil {
+			if parent, ok := nodes[*comment.ParentID]; ok {
+				parent.Replies = append(parent.Replies, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// Repository defines the interface for comment persistence operations.
+// AI-hint: Repository pattern interface for dependency inversion.
+// Deleting a comment also deletes its replies.
+type Repository interface {
+	Save(ctx interface{}, comment *Comment) error
+	FindByID(ctx interface{}, id uuid.UUID) (*Comment, error)
+	FindByIdeaID(ctx interface{}, ideaID uuid.UUID) ([]*Comment, error)
+	Update(ctx interface{}, comment *Comment) error
+	Delete(ctx interface{}, id uuid.UUID) error
+}
+
+// Error types for the comment domain.
+// AI-hint: Domain-specific errors for clear error handling and business rules.
+var (
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrInvalidCommentData = errors.New("invalid comment data")
+	ErrUnauthorized       = errors.New("unauthorized operation")
+	ErrIdeaNotFound       = errors.New("idea not found")
+	ErrAuthorNotFound     = errors.New("author user not found")
+	ErrParentNotFound     = errors.New("parent comment not found")
+	ErrMaxDepthExceeded   = errors.New("maximum reply depth exceeded")
+	ErrEmailNotVerified   = errors.New("email address must be verified first")
+)
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewComment(t *testing.T) {
+	ideaID := uuid.New()
+	authorID := uuid.New()
+
+	comment, err := NewComment(ideaID, authorID, "  Great idea  ")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	if comment.Content != "Great idea" {
+		t.Errorf("Expected trimmed content, got %q", comment.Content)
+	}
+	if comment.ParentID != nil {
+		t.Error("Expected top-level comment to have no parent")
+	}
+	if comment.Depth != 0 {
+		t.Errorf("Expected depth 0, got %d", comment.Depth)
+	}
+
+	// Test validation
+	if _, err := NewComment(uuid.Nil, authorID, "Content"); err == nil {
+		t.Error("Expected error for empty idea ID")
+	}
+	if _, err := NewComment(ideaID, uuid.Nil, "Content"); err == nil {
+		t.Error("Expected error for empty author ID")
+	}
+	if _, err := NewComment(ideaID, authorID, "   "); err == nil {
+		t.Error("Expected error for whitespace-only content")
+	}
+	if _, err := NewComment(ideaID, authorID, strings.Repeat("a", MaxContentLength+1)); err == nil {
+		t.Error("Expected error for overly long content")
+	}
+}
+
+func TestNewReply(t *testing.T) {
+	root, err := NewComment(uuid.New(), uuid.New(), "Root")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	reply, err := NewReply(root, uuid.New(), "Reply", 2)
+	if err != nil {
+		t.Fatalf("Failed to create reply: %v", err)
+	}
+	if reply.ParentID == nil || *reply.ParentID != root.ID {
+		t.Error("Expected reply to reference its parent")
+	}
+	if reply.IdeaID != root.IdeaID {
+		t.Error("Expected reply to inherit the parent's idea")
+	}
+	if reply.Depth != 1 {
+		t.Errorf("Expected depth 1, got %d", reply.Depth)
+	}
+
+	nested, err := NewReply(reply, uuid.New(), "Nested", 2)
+	if err != nil {
+		t.Fatalf("Failed to create nested reply: %v", err)
+	}
+	if nested.Depth != 2 {
+		t.Errorf("Expected depth 2, got %d", nested.Depth)
+	}
+
+	// Test depth limit
+	if _, err := NewReply(nested, uuid.New(), "Too deep", 2); err != ErrMaxDepthExceeded {
+		t.Errorf("Expected ErrMaxDepthExceeded, got %v", err)
+	}
+	if _, err := NewReply(root, uuid.New(), "No replies", 0); err != ErrMaxDepthExceeded {
+		t.Errorf("Expected ErrMaxDepthExceeded with replies disabled, got %v", err)
+	}
+	if _, err := NewReply(nil, uuid.New(), "Orphan", 2); err != ErrParentNotFound {
+		t.Errorf("Expected ErrParentNotFound, got %v", err)
+	}
+}
+
+func TestComment_UpdateContent(t *testing.T) {
+	comment, err := NewComment(uuid.New(), uuid.New(), "Original")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	if err := comment.UpdateContent("Edited"); err != nil {
+		t.Errorf("UpdateContent failed: %v", err)
+	}
+	if comment.Content != "Edited" {
+		t.Errorf("Expected content Edited, got %s", comment.Content)
+	}
+
+	if err := comment.UpdateContent(""); err == nil {
+		t.Error("Expected error when updating with empty content")
+	}
+}
+
+func TestComment_CanBeModifiedBy(t *testing.T) {
+	authorID := uuid.New()
+	comment, err := NewComment(uuid.New(), authorID, "Content")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	if !comment.CanBeModifiedBy(authorID, false) {
+		t.Error("Expected author to be able to modify the comment")
+	}
+	if comment.CanBeModifiedBy(uuid.New(), false) {
+		t.Error("Expected other users to be denied")
+	}
+	if !comment.CanBeModifiedBy(uuid.New(), true) {
+		t.Error("Expected moderators to be able to modify the comment")
+	}
+	if comment.CanBeModifiedBy(uuid.Nil, false) {
+		t.Error("Expected nil user to be denied")
+	}
+}
+
+func TestBuildThreads(t *testing.T) {
+	ideaID := uuid.New()
+	first, _ := NewComment(ideaID, uuid.New(), "First")
+	second, _ := NewComment(ideaID, uuid.New(), "Second")
+	reply, _ := NewReply(first, uuid.New(), "Reply", DefaultMaxReplyDepth)
+	nested, _ := NewReply(reply, uuid.New(), "Nested", DefaultMaxReplyDepth)
+
+	orphanParent := uuid.New()
+	orphan, _ := NewComment(ideaID, uuid.New(), "Orphan")
+	orphan.ParentID = &orphanParent
+
+	threads := BuildThreads([]*Comment{first, second, reply, nested, orphan})
+
+	if len(threads) != 3 {
+		t.Fatalf("Expected 3 root threads, got %d", len(threads))
+	}
+	if threads[0].Comment != first || threads[1].Comment != second || threads[2].Comment != orphan {
+		t.Error("Expected roots in original order with orphans treated as roots")
+	}
+	if len(threads[0].Replies) != 1 || threads[0].Replies[0].Comment != reply {
+		t.Fatal("Expected reply nested under first comment")
+	}
+	if len(threads[0].Replies[0].Replies) != 1 || threads[0].Replies[0].Replies[0].Comment != nested {
+		t.Error("Expected nested reply under reply")
+	}
+	if threads[1].Replies == nil {
+		t.Error("Expected empty replies slice rather than nil")
+	}
+
+	if threads := BuildThreads(nil); threads == nil || len(threads) != 0 {
+		t.Error("Expected empty non-nil result for no comments")
+	}
+}
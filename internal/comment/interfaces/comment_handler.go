@@ -0,0 +1,383 @@
+package interfaces
+
+import (
+	"encoding/json"
+	commentapp "feedback_hub_2/internal/comment/application"
+	commentdomain "feedback_hub_2/internal/comment/domain"
+	"feedback_hub_2/internal/shared/web"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CommentHandler handles HTTP requests for idea discussions.
+// AI-hint: HTTP transport layer for comment operations following REST conventions.
+// Provides proper error handling, status codes, and JSON responses.
+type CommentHandler struct {
+	commentService *commentapp.CommentApplicationService
+}
+
+// NewCommentHandler creates a new CommentHandler instance.
+// AI-hint: Factory method for comment handler with dependency injection of comment service.
+func NewCommentHandler(commentService *commentapp.CommentApplicationService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// CreateCommentRequest represents the request body for posting a comment.
+// AI-hint: parent_id is omitted for top-level comments and set for replies.
+type CreateCommentRequest struct {
+	Content  string `json:"content" example:"Could this also cover the mobile dashboard?"`
+	ParentID string `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+}
+
+// UpdateCommentRequest represents the request body for editing a comment.
+type UpdateCommentRequest struct {
+	Content string `json:"content" example:"Could this also cover the mobile and tablet dashboards?"`
+}
+
+// CommentResponse represents a single comment in API responses.
+// AI-hint: DTO for comment API responses with consistent structure.
+type CommentResponse struct {
+	ID           string `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	IdeaID       string `json:"idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentID     string `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	AuthorUserID string `json:"author_user_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Content      string `json:"content" example:"Could this also cover the mobile dashboard?"`
+	Depth        int    `json:"depth" example:"1"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// CommentThreadResponse represents a comment with its nested replies.
+type CommentThreadResponse struct {
+	CommentResponse
+	Replies []CommentThreadResponse `json:"replies"`
+}
+
+// ListCommentsResponse represents the discussion of an idea.
+// AI-hint: items are top-level comments in chronological order with replies nested;
+// max_reply_depth tells clients when to hide the reply action.
+type ListCommentsResponse struct {
+	Items         []CommentThreadResponse `json:"items"`
+	MaxReplyDepth int                     `json:"max_reply_depth" example:"3"`
+}
+
+// newCommentResponse converts a domain comment into its API representation.
+// AI-hint: Single mapping point from domain entity to response DTO.
+func newCommentResponse(comment *commentdomain.Comment) CommentResponse {
+	response := CommentResponse{
+		ID:           comment.ID.String(),
+		IdeaID:       comment.IdeaID.String(),
+		AuthorUserID: comment.AuthorUserID.String(),
+		Content:      comment.Content,
+		Depth:        comment.Depth,
+		CreatedAt:    comment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    comment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if comment.ParentID != nil {
+		response.ParentID = comment.ParentID.String()
+	}
+	return response
+}
+
+// newThreadResponses converts comment threads into nested API representations.
+// AI-hint: Always returns a non-nil slice so empty reply lists encode as [].
+func newThreadResponses(threads []*commentdomain.Thread) []CommentThreadResponse {
+	responses := make([]CommentThreadResponse, 0, len(threads))
+	for _, thread := range threads {
+		responses = append(responses, CommentThreadResponse{
+			CommentResponse: newCommentResponse(thread.Comment),
+			Replies:         newThreadResponses(thread.Replies),
+		})
+	}
+	return responses
+}
+
+// ListComments handles GET /ideas/{ideaId}/comments requests.
+// AI-hint: Returns the full discussion of an idea as nested threads.
+//
+// @Summary List comments on an idea
+// @Description Get all comments on an idea as threads of nested replies (authentication required)
+// @Tags comments
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} ListCommentsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/comments [get]
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	ideaID, ok := parseIdeaCommentsPath(w, r)
+	if !ok {
+		return
+	}
+
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Call the application service
+	threads, err := h.commentService.GetThreads(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case commentdomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case commentdomain.ErrAuthorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := ListCommentsResponse{
+		Items:         newThreadResponses(threads),
+		MaxReplyDepth: h.commentService.MaxReplyDepth(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateComment handles POST /ideas/{ideaId}/comments requests.
+// AI-hint: Posts a top-level comment or, with parent_id, a reply within the depth limit.
+//
+// @Summary Comment on an idea
+// @Description Post a comment on an idea, or reply to a comment by setting parent_id (authentication required)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param comment body CreateCommentRequest true "Comment creation request"
+// @Success 201 {object} CommentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/comments [post]
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaCommentsPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var req CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if strings.TrimSpace(req.Content) == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Content is required")
+		return
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentID != "" {
+		value, err := uuid.Parse(req.ParentID)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid parent_id format")
+			return
+		}
+		parentID = &value
+	}
+
+	// Call the application service
+	newComment, err := h.commentService.CreateComment(r.Context(), ideaID, parentID, req.Content, userID)
+	if err != nil {
+		switch err {
+		case commentdomain.ErrAuthorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case commentdomain.ErrInvalidCommentData:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid comment data")
+		case commentdomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case commentdomain.ErrParentNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Parent comment not found on this idea")
+		case commentdomain.ErrMaxDepthExceeded:
+			web.WriteErrorResponse(w, http.StatusUnprocessableEntity, "Maximum reply depth exceeded")
+		case commentdomain.ErrEmailNotVerified:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Verify your email address before commenting")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCommentResponse(newComment))
+}
+
+// UpdateComment handles PUT /comments/{commentId} requests.
+// AI-hint: Comment edit endpoint restricted to the author or a Product Owner.
+//
+// @Summary Edit a comment
+// @Description Edit a comment's content (authentication required, author or Product Owner only)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param commentId path string true "Comment ID" format(uuid)
+// @Param comment body UpdateCommentRequest true "Comment update request"
+// @Success 200 {object} CommentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /comments/{commentId} [put]
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	commentID, ok := parseCommentIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var req UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if strings.TrimSpace(req.Content) == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Content is required")
+		return
+	}
+
+	// Call the application service
+	updatedComment, err := h.commentService.UpdateComment(r.Context(), commentID, req.Content, userID)
+	if err != nil {
+		switch err {
+		case commentdomain.ErrAuthorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case commentdomain.ErrInvalidCommentData:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid comment data")
+		case commentdomain.ErrCommentNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Comment not found")
+		case commentdomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case commentdomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the author or a Product Owner can edit this comment")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newCommentResponse(updatedComment))
+}
+
+// DeleteComment handles DELETE /comments/{commentId} requests.
+// AI-hint: Comment deletion restricted to the author or a Product Owner; replies are removed too.
+//
+// @Summary Delete a comment
+// @Description Delete a comment and all of its replies (authentication required, author or Product Owner only)
+// @Tags comments
+// @Param commentId path string true "Comment ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	commentID, ok := parseCommentIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Call the application service
+	err := h.commentService.DeleteComment(r.Context(), commentID, userID)
+	if err != nil {
+		switch err {
+		case commentdomain.ErrAuthorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case commentdomain.ErrCommentNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Comment not found")
+		case commentdomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case commentdomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the author or a Product Owner can delete this comment")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return 204 No Content for successful deletion
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseIdeaCommentsPath extracts the idea ID from a /ideas/{ideaId}/comments URL path.
+// AI-hint: Writes a 400 response and returns false when the path or ID is malformed.
+func parseIdeaCommentsPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 3 || pathParts[0] != "ideas" || pathParts[2] != "comments" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return uuid.Nil, false
+	}
+
+	ideaID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+		return uuid.Nil, false
+	}
+
+	return ideaID, true
+}
+
+// parseCommentIDFromPath extracts the comment ID from a /comments/{commentId} URL path.
+// AI-hint: Writes a 400 response and returns false when the path or ID is malformed.
+func parseCommentIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 2 || pathParts[0] != "comments" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return uuid.Nil, false
+	}
+
+	commentID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid comment ID format")
+		return uuid.Nil, false
+	}
+
+	return commentID, true
+}
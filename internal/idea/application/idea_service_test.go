@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+	events "feedback_hub_2/internal/shared/bus"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func (r *fakeIdeaRepository) UpdateStatus(ctx interface{}, idea *ideadomain.Idea, previous ideadomain.Status) error {
+	r.ideas[idea.ID] = idea
+	return nil
+}
+
+// fakeEventPublisher drops every event.
+type fakeEventPublisher struct{}
+
+func (fakeEventPublisher) PublishEvent(ctx context.Context, event events.DomainEvent) error {
+	return nil
+}
+
+func TestChangeIdeaStatus_PrivateIdea(t *testing.T) {
+	creatorID := uuid.New()
+	productOwner := &auth.UserContext{UserID: uuid.New().String(), RoleID: uuid.New().String(), RoleName: "Product Owner"}
+	contributor := &auth.UserContext{UserID: uuid.New().String(), RoleID: uuid.New().String(), RoleName: "Contributor"}
+
+	private, _ := ideadomain.NewIdea("Security report", "XSS in the search page", creatorID)
+	private.SetAudience(ideadomain.Audience{Visibility: ideadomain.VisibilityPrivate})
+
+	ideaRepo := &fakeIdeaRepository{ideas: map[uuid.UUID]*ideadomain.Idea{private.ID: private}}
+	service := NewIdeaApplicationService(ideaRepo, nil, nil, nil, nil, nil, nil, auth.NewAuthorizationService(), fakeEventPublisher{}, false)
+
+	changeStatus := func(userCtx *auth.UserContext) (*ideadomain.Idea, error) {
+		ctx := auth.WithUserContext(context.Background(), userCtx)
+		return service.ChangeIdeaStatus(ctx, private.ID, string(ideadomain.StatusUnderReview), "", userCtx.UserID)
+	}
+
+	if _, err := changeStatus(contributor); err != ideadomain.ErrUnauthorized {
+		t.Errorf("Expected ErrUnauthorized for a Contributor, got %v", err)
+	}
+
+	updated, err := changeStatus(productOwner)
+	if err != nil {
+		t.Fatalf("Expected a Product Owner to change the status of a private idea, got %v", err)
+	}
+	if updated.Status != ideadomain.StatusUnderReview {
+		t.Errorf("Expected status %s, got %s", ideadomain.StatusUnderReview, updated.Status)
+	}
+}
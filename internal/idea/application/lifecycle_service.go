@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RestoreIdea brings a soft-deleted idea back.
+// AI-hint: Same authorization as DeleteIdea (creator or Product Owner). Returns
+// ErrIdeaNotDeleted when the idea is live and ErrIdeaNotFound once it was purged.
+func (s *IdeaApplicationService) RestoreIdea(ctx interface{}, ideaID uuid.UUID, restoredByUserID string) (*ideadomain.Idea, error) {
+	context := ctx.(context.Context)
+
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(context, restoredByUserID)
+	if err != nil {
+		return nil, ideadomain.ErrCreatorNotFound
+	}
+	viewer := s.moderationViewer(userCtx, auth.PermissionDeleteAnyIdea)
+
+	deletedIdea, err := s.ideaRepo.FindDeletedByID(context, ideaID, viewer)
+	if err != nil {
+		if err == ideadomain.ErrIdeaNotFound {
+			// Report live ideas distinctly from unknown ones
+			if _, findErr := s.ideaRepo.FindByID(context, ideaID, viewer); findErr == nil {
+				return nil, ideadomain.ErrIdeaNotDeleted
+			}
+		}
+		return nil, err
+	}
+
+	// Check authorization - creator or moderator only
+	if !s.canModify(userCtx, deletedIdea, auth.PermissionDeleteAnyIdea) {
+		return nil, ideadomain.ErrUnauthorized
+	}
+
+	if err := s.ideaRepo.Restore(context, ideaID); err != nil {
+		return nil, err
+	}
+
+	return s.ideaRepo.FindByID(context, ideaID, viewer)
+}
+
+// ArchiveIdea hides an idea from listings and search without deleting it.
+// AI-hint: Same authorization as UpdateIdea (creator or Product Owner).
+func (s *IdeaApplicationService) ArchiveIdea(ctx interface{}, ideaID uuid.UUID, archivedByUserID string) (*ideadomain.Idea, error) {
+	return s.setArchived(ctx.(context.Context), ideaID, archivedByUserID, (*ideadomain.Idea).Archive)
+}
+
+// UnarchiveIdea returns an archived idea to listings and search.
+// AI-hint: Same authorization as UpdateIdea (creator or Product Owner).
+func (s *IdeaApplicationService) UnarchiveIdea(ctx interface{}, ideaID uuid.UUID, unarchivedByUserID string) (*ideadomain.Idea, error) {
+	return s.setArchived(ctx.(context.Context), ideaID, unarchivedByUserID, (*ideadomain.Idea).Unarchive)
+}
+
+// PurgeDeletedIdeas permanently removes ideas that were soft-deleted longer than retention ago.
+// AI-hint: System operation run by the background purge job; no user authorization.
+// Returns the number of purged ideas.
+func (s *IdeaApplicationService) PurgeDeletedIdeas(ctx interface{}, retention time.Duration) (int64, error) {
+	context := ctx.(context.Context)
+	return s.ideaRepo.PurgeDeleted(context, time.Now().Add(-retention))
+}
+
+// setArchived applies an archive state change after checking authorization.
+func (s *IdeaApplicationService) setArchived(ctx context.Context, ideaID uuid.UUID, userID string, change func(*ideadomain.Idea) error) (*ideadomain.Idea, error) {
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(ctx, userID)
+	if err != nil {
+		return nil, ideadomain.ErrCreatorNotFound
+	}
+
+	existingIdea, err := s.ideaRepo.FindByID(ctx, ideaID, s.moderationViewer(userCtx, auth.PermissionUpdateAnyIdea))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check authorization - creator or moderator only
+	if !s.canModify(userCtx, existingIdea, auth.PermissionUpdateAnyIdea) {
+		return nil, ideadomain.ErrUnauthorized
+	}
+
+	if err := change(existingIdea); err != nil {
+		return nil, err
+	}
+
+	if err := s.ideaRepo.UpdateArchived(ctx, existingIdea); err != nil {
+		return nil, err
+	}
+
+	return existingIdea, nil
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+	events "feedback_hub_2/internal/shared/bus"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// FindDuplicates suggests existing ideas that look like duplicates of the given idea.
+// AI-hint: Read access is open to every authenticated user; only ideas the viewer may
+// see are suggested. Returns an empty list when trigram similarity is unavailable.
+func (s *IdeaApplicationService) FindDuplicates(ctx interface{}, ideaID uuid.UUID, limit int, viewerUserID string) ([]*ideadomain.DuplicateCandidate, error) {
+	context := ctx.(context.Context)
+
+	viewer, err := s.viewerFor(context, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingIdea, err := s.ideaRepo.FindByID(context, ideaID, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ideaRepo.FindSimilar(context, ideadomain.DuplicateQuery{
+		Title:         existingIdea.Title,
+		Content:       existingIdea.Content,
+		ExcludeIdeaID: existingIdea.ID,
+		Limit:         limit,
+		Viewer:        viewer,
+	})
+}
+
+// MergeIdeas folds a duplicate idea into a canonical idea and returns the updated target.
+// AI-hint: Requires the idea:merge permission (Product Owners). Votes and comments move
+// to the target, the duplicate is soft-deleted, and a redirect record is kept. Both ideas
+// must have the same audience (ErrMergeAudienceMismatch).
+func (s *IdeaApplicationService) MergeIdeas(ctx interface{}, sourceIdeaID, targetIdeaID uuid.UUID, mergedByUserID string) (*ideadomain.Idea, *ideadomain.IdeaMerge, error) {
+	context := ctx.(context.Context)
+
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(context, mergedByUserID)
+	if err != nil {
+		return nil, nil, ideadomain.ErrCreatorNotFound
+	}
+
+	// Check authorization - merging is a moderation action
+	if !s.authService.CanPerform(userCtx, auth.PermissionMergeIdeas) {
+		return nil, nil, ideadomain.ErrUnauthorized
+	}
+
+	viewer := s.moderationViewer(userCtx, auth.PermissionMergeIdeas)
+	source, err := s.ideaRepo.FindByID(context, sourceIdeaID, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := s.ideaRepo.FindByID(context, targetIdeaID, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedByUUID, err := uuid.Parse(mergedByUserID)
+	if err != nil {
+		return nil, nil, ideadomain.ErrCreatorNotFound
+	}
+
+	merge, err := ideadomain.NewIdeaMerge(source, target, mergedByUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.mergeRepo.Merge(context, merge); err != nil {
+		return nil, nil, err
+	}
+
+	// Publish domain event for the merge
+	mergedEvent := events.NewIdeaMergedEvent(source.ID.String(), target.ID.String(), mergedByUserID, merge.MovedVotes, merge.MovedComments)
+	if err := s.eventPublisher.PublishEvent(context, mergedEvent); err != nil {
+		log.Printf("Warning: failed to publish idea merged event: %v", err)
+		// Don't fail the operation if event publishing fails
+	}
+
+	// Reload the target so the response reflects the moved votes
+	updatedTarget, err := s.ideaRepo.FindByID(context, target.ID, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updatedTarget, merge, nil
+}
+
+// GetMergeRedirect returns the merge record of an idea that was merged into another idea.
+// AI-hint: Used to redirect requests for a merged duplicate; returns ErrMergeNotFound
+// when the idea was never merged or the viewer may not see it. Once PurgeDeleted removed
+// the source idea, visibility is checked against the target, which had the same audience.
+func (s *IdeaApplicationService) GetMergeRedirect(ctx interface{}, ideaID uuid.UUID, viewerUserID string) (*ideadomain.IdeaMerge, error) {
+	context := ctx.(context.Context)
+
+	viewer, err := s.viewerFor(context, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.ideaRepo.FindDeletedByID(context, ideaID, viewer)
+	if err == nil {
+		return s.mergeRepo.FindBySourceID(context, ideaID)
+	}
+	if err != ideadomain.ErrIdeaNotFound {
+		return nil, err
+	}
+
+	// The source is hidden from the viewer, not deleted, or purged
+	purged, err := s.isPurged(context, ideaID)
+	if err != nil {
+		return nil, err
+	}
+	if !purged {
+		return nil, ideadomain.ErrMergeNotFound
+	}
+
+	merge, err := s.mergeRepo.FindBySourceID(context, ideaID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ideaRepo.FindByID(context, merge.TargetIdeaID, viewer); err != nil {
+		if err == ideadomain.ErrIdeaNotFound {
+			return nil, ideadomain.ErrMergeNotFound
+		}
+		return nil, err
+	}
+	return merge, nil
+}
+
+// isPurged reports whether no idea with the given ID is stored, live or soft-deleted.
+func (s *IdeaApplicationService) isPurged(ctx context.Context, ideaID uuid.UUID) (bool, error) {
+	if _, err := s.ideaRepo.FindDeletedByID(ctx, ideaID, ideadomain.SystemViewer()); err != ideadomain.ErrIdeaNotFound {
+		return false, err
+	}
+	if _, err := s.ideaRepo.FindByID(ctx, ideaID, ideadomain.SystemViewer()); err != ideadomain.ErrIdeaNotFound {
+		return false, err
+	}
+	return true, nil
+}
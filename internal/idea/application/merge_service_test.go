@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeIdeaRepository keeps ideas in memory and applies the viewer like IdeaRepository.
+type fakeIdeaRepository struct {
+	ideadomain.Repository
+	ideas map[uuid.UUID]*ideadomain.Idea
+}
+
+func (r *fakeIdeaRepository) FindByID(ctx interface{}, id uuid.UUID, viewer ideadomain.Viewer) (*ideadomain.Idea, error) {
+	idea, ok := r.ideas[id]
+	if !ok || idea.IsDeleted() || !idea.IsVisibleTo(viewer) {
+		return nil, ideadomain.ErrIdeaNotFound
+	}
+	return idea, nil
+}
+
+func (r *fakeIdeaRepository) FindDeletedByID(ctx interface{}, id uuid.UUID, viewer ideadomain.Viewer) (*ideadomain.Idea, error) {
+	idea, ok := r.ideas[id]
+	if !ok || !idea.IsDeleted() || !idea.IsVisibleTo(viewer) {
+		return nil, ideadomain.ErrIdeaNotFound
+	}
+	return idea, nil
+}
+
+// fakeMergeRepository keeps merge records in memory.
+type fakeMergeRepository struct {
+	merges map[uuid.UUID]*ideadomain.IdeaMerge
+}
+
+func (r *fakeMergeRepository) Merge(ctx interface{}, merge *ideadomain.IdeaMerge) error {
+	r.merges[merge.SourceIdeaID] = merge
+	return nil
+}
+
+func (r *fakeMergeRepository) FindBySourceID(ctx interface{}, sourceIdeaID uuid.UUID) (*ideadomain.IdeaMerge, error) {
+	merge, ok := r.merges[sourceIdeaID]
+	if !ok {
+		return nil, ideadomain.ErrMergeNotFound
+	}
+	return merge, nil
+}
+
+func TestGetMergeRedirect(t *testing.T) {
+	creator := &auth.UserContext{UserID: uuid.New().String(), RoleID: uuid.New().String(), RoleName: "Contributor"}
+	stranger := &auth.UserContext{UserID: uuid.New().String(), RoleID: uuid.New().String(), RoleName: "Contributor"}
+	creatorID, _ := uuid.Parse(creator.UserID)
+
+	source, _ := ideadomain.NewIdea("Leak", "Token in logs", creatorID)
+	target, _ := ideadomain.NewIdea("Token leak", "Tokens are logged", creatorID)
+	source.SetAudience(ideadomain.Audience{Visibility: ideadomain.VisibilityPrivate})
+	target.SetAudience(ideadomain.Audience{Visibility: ideadomain.VisibilityPrivate})
+	merge, err := ideadomain.NewIdeaMerge(source, target, creatorID)
+	if err != nil {
+		t.Fatalf("NewIdeaMerge failed: %v", err)
+	}
+	deletedAt := time.Now()
+	source.DeletedAt = &deletedAt
+
+	ideaRepo := &fakeIdeaRepository{ideas: map[uuid.UUID]*ideadomain.Idea{source.ID: source, target.ID: target}}
+	mergeRepo := &fakeMergeRepository{merges: map[uuid.UUID]*ideadomain.IdeaMerge{source.ID: merge}}
+	service := NewIdeaApplicationService(ideaRepo, nil, nil, nil, mergeRepo, nil, nil, auth.NewAuthorizationService(), nil, false)
+
+	redirect := func(userCtx *auth.UserContext, ideaID uuid.UUID) (*ideadomain.IdeaMerge, error) {
+		return service.GetMergeRedirect(auth.WithUserContext(context.Background(), userCtx), ideaID, userCtx.UserID)
+	}
+
+	t.Run("soft-deleted source", func(t *testing.T) {
+		if got, err := redirect(creator, source.ID); err != nil || got.TargetIdeaID != target.ID {
+			t.Errorf("Expected redirect to %s, got %+v, %v", target.ID, got, err)
+		}
+		if _, err := redirect(stranger, source.ID); err != ideadomain.ErrMergeNotFound {
+			t.Errorf("Expected ErrMergeNotFound for a viewer who cannot see the idea, got %v", err)
+		}
+	})
+
+	t.Run("live idea", func(t *testing.T) {
+		if _, err := redirect(creator, target.ID); err != ideadomain.ErrMergeNotFound {
+			t.Errorf("Expected ErrMergeNotFound for an idea that was not merged, got %v", err)
+		}
+	})
+
+	t.Run("purged source", func(t *testing.T) {
+		delete(ideaRepo.ideas, source.ID)
+
+		if got, err := redirect(creator, source.ID); err != nil || got.TargetIdeaID != target.ID {
+			t.Errorf("Expected redirect to %s after purge, got %+v, %v", target.ID, got, err)
+		}
+		if _, err := redirect(stranger, source.ID); err != ideadomain.ErrMergeNotFound {
+			t.Errorf("Expected ErrMergeNotFound for a viewer who cannot see the target, got %v", err)
+		}
+		if _, err := redirect(creator, uuid.New()); err != ideadomain.ErrMergeNotFound {
+			t.Errorf("Expected ErrMergeNotFound for an unknown idea, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+
+	"github.com/google/uuid"
+)
+
+// ListRevisions retrieves an idea's revision history, oldest first.
+// AI-hint: Read access is open to every user who may see the idea. Returns ErrIdeaNotFound
+// for unknown or hidden ideas rather than an empty history.
+func (s *IdeaApplicationService) ListRevisions(ctx interface{}, ideaID uuid.UUID, viewerUserID string) ([]*ideadomain.Revision, error) {
+	context := ctx.(context.Context)
+
+	viewer, err := s.viewerFor(context, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ideaRepo.FindByID(context, ideaID, viewer); err != nil {
+		return nil, err
+	}
+
+	return s.revisionRepo.FindByIdeaID(context, ideaID)
+}
+
+// DiffRevisions compares two versions of an idea.
+// AI-hint: Revision number ideadomain.CurrentRevisionNumber (0) refers to the idea's
+// current title and content.
+func (s *IdeaApplicationService) DiffRevisions(ctx interface{}, ideaID uuid.UUID, fromNumber, toNumber int, viewerUserID string) (*ideadomain.RevisionDiff, error) {
+	context := ctx.(context.Context)
+
+	viewer, err := s.viewerFor(context, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingIdea, err := s.ideaRepo.FindByID(context, ideaID, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.findRevision(context, existingIdea, fromNumber)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findRevision(context, existingIdea, toNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return ideadomain.DiffRevisions(from, to), nil
+}
+
+// RestoreRevision sets an idea's title and content back to those of a stored revision.
+// AI-hint: Same authorization as UpdateIdea (creator or Product Owner). The restore is
+// itself an edit, so the text it replaces is recorded as a new revision and nothing
+// in the history is lost.
+func (s *IdeaApplicationService) RestoreRevision(ctx interface{}, ideaID uuid.UUID, number int, restoredByUserID string) (*ideadomain.Idea, error) {
+	context := ctx.(context.Context)
+
+	// Get the user context for authorization
+	userCtx, err := s.getUserContext(context, restoredByUserID)
+	if err != nil {
+		return nil, ideadomain.ErrCreatorNotFound
+	}
+
+	existingIdea, err := s.ideaRepo.FindByID(context, ideaID, s.moderationViewer(userCtx, auth.PermissionUpdateAnyIdea))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check authorization - creator or moderator only
+	if !s.canModify(userCtx, existingIdea, auth.PermissionUpdateAnyIdea) {
+		return nil, ideadomain.ErrUnauthorized
+	}
+
+	target, err := s.revisionRepo.FindByNumber(context, ideaID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	editorUUID, err := uuid.Parse(restoredByUserID)
+	if err != nil {
+		return nil, ideadomain.ErrCreatorNotFound
+	}
+
+	revision, err := existingIdea.Revise(target.Title, target.Content, editorUUID)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		// Already identical to the requested revision
+		return existingIdea, nil
+	}
+
+	if err := s.ideaRepo.Update(context, existingIdea, revision); err != nil {
+		return nil, err
+	}
+
+	return existingIdea, nil
+}
+
+// findRevision resolves a revision number, treating CurrentRevisionNumber as the live idea.
+func (s *IdeaApplicationService) findRevision(ctx context.Context, idea *ideadomain.Idea, number int) (*ideadomain.Revision, error) {
+	if number == ideadomain.CurrentRevisionNumber {
+		return idea.Snapshot(), nil
+	}
+	return s.revisionRepo.FindByNumber(ctx, idea.ID, number)
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ListTags retrieves all tags with the number of ideas carrying each.
+// AI-hint: Read access is open to every authenticated user.
+func (s *IdeaApplicationService) ListTags(ctx interface{}) ([]*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+	return s.tagRepo.FindAll(context)
+}
+
+// GetTag retrieves a tag by its ID.
+func (s *IdeaApplicationService) GetTag(ctx interface{}, tagID uuid.UUID) (*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+	return s.tagRepo.FindByID(context, tagID)
+}
+
+// CreateTag creates a new tag.
+// AI-hint: Requires the tag:create permission (Product Owners and Super Users).
+func (s *IdeaApplicationService) CreateTag(ctx interface{}, name string, createdByUserID string) (*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	if err := s.requireTagPermission(context, createdByUserID, auth.PermissionCreateTag); err != nil {
+		return nil, err
+	}
+
+	tag, err := ideadomain.NewTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Save(context, tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// RenameTag changes a tag's name on every idea carrying it.
+// AI-hint: Requires the tag:update permission. Renaming onto an existing tag's name
+// returns ErrTagAlreadyExists; merge the tags instead.
+func (s *IdeaApplicationService) RenameTag(ctx interface{}, tagID uuid.UUID, name string, updatedByUserID string) (*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	if err := s.requireTagPermission(context, updatedByUserID, auth.PermissionUpdateTag); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagRepo.FindByID(context, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tag.Rename(name); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Rename(context, tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// MergeTags moves all ideas from the source tag onto the target tag and deletes the source.
+// AI-hint: Requires the tag:update permission. Returns the updated target tag.
+func (s *IdeaApplicationService) MergeTags(ctx interface{}, sourceTagID, targetTagID uuid.UUID, mergedByUserID string) (*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	if err := s.requireTagPermission(context, mergedByUserID, auth.PermissionUpdateTag); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Merge(context, sourceTagID, targetTagID); err != nil {
+		return nil, err
+	}
+
+	return s.tagRepo.FindByID(context, targetTagID)
+}
+
+// DeleteTag deletes a tag and removes it from all ideas.
+// AI-hint: Requires the tag:delete permission.
+func (s *IdeaApplicationService) DeleteTag(ctx interface{}, tagID uuid.UUID, deletedByUserID string) error {
+	context := ctx.(context.Context)
+
+	if err := s.requireTagPermission(context, deletedByUserID, auth.PermissionDeleteTag); err != nil {
+		return err
+	}
+
+	return s.tagRepo.Delete(context, tagID)
+}
+
+// resolveTags validates tag names against existing tags and returns their canonical names.
+// AI-hint: Matching is case-insensitive; any unknown name fails the whole request
+// with ErrTagNotFound so ideas are never silently left untagged.
+func (s *IdeaApplicationService) resolveTags(ctx context.Context, names []string) ([]string, error) {
+	normalized, err := ideadomain.NormalizeTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 {
+		return normalized, nil
+	}
+
+	tags, err := s.tagRepo.FindByNames(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		canonical[strings.ToLower(tag.Name)] = tag.Name
+	}
+
+	resolved := make([]string, 0, len(normalized))
+	for _, name := range normalized {
+		tagName, ok := canonical[strings.ToLower(name)]
+		if !ok {
+			return nil, ideadomain.ErrTagNotFound
+		}
+		resolved = append(resolved, tagName)
+	}
+
+	return resolved, nil
+}
+
+// requireTagPermission checks that the user may perform a tag management action.
+func (s *IdeaApplicationService) requireTagPermission(ctx context.Context, userID string, permission auth.Permission) error {
+	userCtx, err := s.getUserContext(ctx, userID)
+	if err != nil {
+		return ideadomain.ErrCreatorNotFound
+	}
+
+	if !s.authService.CanPerform(userCtx, permission) {
+		return ideadomain.ErrUnauthorized
+	}
+
+	return nil
+}
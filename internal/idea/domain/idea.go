@@ -11,13 +11,24 @@ import (
 // Idea represents a feedback idea in the system.
 // AI-hint: Core domain entity for feedback ideas with business logic and invariants.
 // Enforces title/content validation and maintains creator relationship integrity.
+// CreatorUserID is uuid.Nil once the creator's account was deleted and the idea anonymized.
+// Visibility and the VisibleTo lists control who can see the idea (see IsVisibleTo).
 type Idea struct {
-	ID            uuid.UUID `json:"id"`
-	Title         string    `json:"title"`
-	Content       string    `json:"content"`
-	CreatorUserID uuid.UUID `json:"creator_user_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID               uuid.UUID   `json:"id"`
+	Title            string      `json:"title"`
+	Content          string      `json:"content"`
+	CreatorUserID    uuid.UUID   `json:"creator_user_id"`
+	Status           Status      `json:"status"`
+	StatusReason     string      `json:"status_reason,omitempty"`
+	Tags             []string    `json:"tags"`
+	VoteCount        int         `json:"vote_count"`
+	Visibility       Visibility  `json:"visibility"`
+	VisibleToRoleIDs []uuid.UUID `json:"visible_to_role_ids"`
+	VisibleToUserIDs []uuid.UUID `json:"visible_to_user_ids"`
+	ArchivedAt       *time.Time  `json:"archived_at,omitempty"`
+	DeletedAt        *time.Time  `json:"deleted_at,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
 }
 
 // NewIdea creates a new Idea with validation.
@@ -42,12 +53,17 @@ func NewIdea(title, content string, creatorUserID uuid.UUID) (*Idea, error) {
 
 	now := time.Now()
 	return &Idea{
-		ID:            uuid.New(),
-		Title:         strings.TrimSpace(title),
-		Content:       strings.TrimSpace(content),
-		CreatorUserID: creatorUserID,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		ID:               uuid.New(),
+		Title:            strings.TrimSpace(title),
+		Content:          strings.TrimSpace(content),
+		CreatorUserID:    creatorUserID,
+		Status:           StatusNew,
+		Tags:             []string{},
+		Visibility:       VisibilityPublic,
+		VisibleToRoleIDs: []uuid.UUID{},
+		VisibleToUserIDs: []uuid.UUID{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
@@ -76,12 +92,17 @@ func NewIdeaWithID(id uuid.UUID, title, content string, creatorUserID uuid.UUID)
 
 	now := time.Now()
 	return &Idea{
-		ID:            id,
-		Title:         strings.TrimSpace(title),
-		Content:       strings.TrimSpace(content),
-		CreatorUserID: creatorUserID,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		ID:               id,
+		Title:            strings.TrimSpace(title),
+		Content:          strings.TrimSpace(content),
+		CreatorUserID:    creatorUserID,
+		Status:           StatusNew,
+		Tags:             []string{},
+		Visibility:       VisibilityPublic,
+		VisibleToRoleIDs: []uuid.UUID{},
+		VisibleToUserIDs: []uuid.UUID{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
@@ -115,16 +136,63 @@ func (i *Idea) UpdateContent(content string) error {
 	return nil
 }
 
+// ChangeStatus moves the idea to a new workflow status and returns the previous one.
+// AI-hint: Business rule - only transitions listed in statusTransitions are allowed and
+// declining requires a reason. Each transition replaces the previous reason.
+func (i *Idea) ChangeStatus(next Status, reason string) (Status, error) {
+	if !next.IsValid() {
+		return i.Status, ErrInvalidStatus
+	}
+	if !i.Status.CanTransitionTo(next) {
+		return i.Status, ErrInvalidStatusTransition
+	}
+
+	reason = strings.TrimSpace(reason)
+	if next == StatusDeclined && reason == "" {
+		return i.Status, ErrDeclineReasonRequired
+	}
+
+	previous := i.Status
+	i.Status = next
+	i.StatusReason = reason
+	i.UpdatedAt = time.Now()
+	return previous, nil
+}
+
+// CanBeModifiedBy reports whether the given user may update or delete this idea.
+// AI-hint: Business rule - only the creator may change an idea unless the caller
+// holds a moderation permission (e.g. Product Owners curating the board).
+func (i *Idea) CanBeModifiedBy(userID uuid.UUID, canModerate bool) bool {
+	if canModerate {
+		return true
+	}
+	return userID != uuid.Nil && i.CreatorUserID == userID
+}
+
 // Repository defines the interface for idea persistence operations.
 // AI-hint: Repository pattern interface for dependency inversion.
-// Keeps domain logic independent of persistence implementation.
+// Keeps domain logic independent of persistence implementation. Update stores the
+// optional revision atomically with the edit it describes. Soft-deleted ideas are
+// invisible to every finder except FindDeletedByID; Delete is a soft delete and only
+// PurgeDeleted removes rows. Every finder only returns ideas visible to the given
+// viewer (ListQuery, SearchQuery, and DuplicateQuery carry theirs); hidden ideas are
+// reported as ErrIdeaNotFound.
 type Repository interface {
 	Save(ctx interface{}, idea *Idea) error
-	FindByID(ctx interface{}, id uuid.UUID) (*Idea, error)
-	FindByCreatorUserID(ctx interface{}, creatorUserID uuid.UUID) ([]*Idea, error)
-	FindAll(ctx interface{}) ([]*Idea, error)
-	Update(ctx interface{}, idea *Idea) error
+	FindByID(ctx interface{}, id uuid.UUID, viewer Viewer) (*Idea, error)
+	FindDeletedByID(ctx interface{}, id uuid.UUID, viewer Viewer) (*Idea, error)
+	FindByCreatorUserID(ctx interface{}, creatorUserID uuid.UUID, viewer Viewer) ([]*Idea, error)
+	FindAll(ctx interface{}, viewer Viewer) ([]*Idea, error)
+	List(ctx interface{}, query ListQuery) (*ListResult, error)
+	Search(ctx interface{}, query SearchQuery) (*SearchResult, error)
+	FindSimilar(ctx interface{}, query DuplicateQuery) ([]*DuplicateCandidate, error)
+	Update(ctx interface{}, idea *Idea, revision *Revision) error
+	UpdateStatus(ctx interface{}, idea *Idea, previous Status) error
+	UpdateArchived(ctx interface{}, idea *Idea) error
+	UpdateAudience(ctx interface{}, idea *Idea) error
 	Delete(ctx interface{}, id uuid.UUID) error
+	Restore(ctx interface{}, id uuid.UUID) error
+	PurgeDeleted(ctx interface{}, deletedBefore time.Time) (int64, error)
 }
 
 // Service defines the business operations for idea management.
@@ -135,8 +203,8 @@ type Service interface {
 	GetIdea(ctx interface{}, id uuid.UUID) (*Idea, error)
 	UpdateIdea(ctx interface{}, id uuid.UUID, title, content string, updatedByUserID uuid.UUID) (*Idea, error)
 	DeleteIdea(ctx interface{}, id uuid.UUID, deletedByUserID uuid.UUID) error
-	ListIdeas(ctx interface{}) ([]*Idea, error)
-	ListIdeasByCreator(ctx interface{}, creatorUserID uuid.UUID) ([]*Idea, error)
+	ListIdeas(ctx interface{}, query ListQuery) (*ListResult, error)
+	ListIdeasByCreator(ctx interface{}, creatorUserID uuid.UUID, query ListQuery) (*ListResult, error)
 }
 
 // Error types for the idea domain.
@@ -146,4 +214,6 @@ var (
 	ErrInvalidIdeaData = errors.New("invalid idea data")
 	ErrUnauthorized    = errors.New("unauthorized operation")
 	ErrCreatorNotFound = errors.New("creator user not found")
+
+	ErrEmailNotVerified = errors.New("email address must be verified first")
 )
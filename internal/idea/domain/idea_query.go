@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Listing defaults and limits.
+// AI-hint: Page size is capped to keep list queries bounded regardless of client input.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SortField identifies the attribute idea listings are ordered by.
+// AI-hint: Enum-like type whitelisting sortable columns; persistence maps each
+// value to a concrete column so client input never reaches SQL directly.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByVoteCount SortField = "vote_count"
+)
+
+// TagMatch controls how a multi-tag listing filter is applied.
+// AI-hint: Any returns ideas carrying at least one of the tags; All requires every tag.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// SortDirection identifies ascending or descending ordering.
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// ListQuery describes a filtered, sorted, cursor-paginated idea listing.
+// AI-hint: Query object passed to Repository.List. Zero values mean "no filter";
+// call Normalize before use to apply defaults and validate combinations. Archived ideas
+// are excluded unless IncludeArchived is set; soft-deleted ideas are always excluded.
+// Only ideas visible to Viewer are listed.
+type ListQuery struct {
+	CreatorUserID   *uuid.UUID
+	Status          *Status
+	Tags            []string
+	TagMatch        TagMatch
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	IncludeArchived bool
+	SortBy          SortField
+	SortDirection   SortDirection
+	Limit           int
+	Cursor          string
+	Viewer          Viewer
+}
+
+// ListResult is a single page of ideas plus the cursor for the next page.
+// AI-hint: NextCursor is empty when there are no further results.
+type ListResult struct {
+	Ideas      []*Idea
+	NextCursor string
+}
+
+// Cursor marks the position of the last item on a page for keyset pagination.
+// AI-hint: Carries the sort key together with the ID tiebreaker so pages stay stable
+// when many ideas share the same sort value.
+type Cursor struct {
+	SortBy    SortField `json:"s"`
+	SortValue string    `json:"v"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// Normalize applies defaults and validates the query.
+// AI-hint: Keeps listing rules (page size bounds, allowed sort fields, date range
+// ordering, status and tag filters, cursor/sort consistency) in the domain rather than in handlers.
+func (q *ListQuery) Normalize() error {
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+
+	if q.SortBy == "" {
+		q.SortBy = SortByCreatedAt
+	}
+	if !q.SortBy.IsValid() {
+		return ErrInvalidQuery
+	}
+
+	if q.SortDirection == "" {
+		q.SortDirection = SortDescending
+	}
+	if q.SortDirection != SortAscending && q.SortDirection != SortDescending {
+		return ErrInvalidQuery
+	}
+
+	if q.Status != nil && !q.Status.IsValid() {
+		return ErrInvalidQuery
+	}
+
+	if len(q.Tags) > 0 {
+		tags, err := NormalizeTagNames(q.Tags)
+		if err != nil {
+			return ErrInvalidQuery
+		}
+		q.Tags = tags
+	}
+	if q.TagMatch == "" {
+		q.TagMatch = TagMatchAny
+	}
+	if q.TagMatch != TagMatchAny && q.TagMatch != TagMatchAll {
+		return ErrInvalidQuery
+	}
+
+	if q.CreatedAfter != nil && q.CreatedBefore != nil && q.CreatedAfter.After(*q.CreatedBefore) {
+		return ErrInvalidQuery
+	}
+
+	if q.Cursor != "" {
+		cursor, err := DecodeCursor(q.Cursor)
+		if err != nil {
+			return err
+		}
+		if cursor.SortBy != q.SortBy {
+			return ErrInvalidCursor
+		}
+	}
+
+	return nil
+}
+
+// IsValid reports whether the sort field is supported.
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByUpdatedAt, SortByVoteCount:
+		return true
+	default:
+		return false
+	}
+}
+
+// EncodeCursor serializes a cursor into an opaque, URL-safe token.
+// AI-hint: Clients must treat the token as opaque; the format may change.
+func EncodeCursor(cursor Cursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses an opaque cursor token produced by EncodeCursor.
+// AI-hint: Returns ErrInvalidCursor for any malformed or tampered token.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID == uuid.Nil || cursor.SortValue == "" || !cursor.SortBy.IsValid() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// CursorFor builds the cursor pointing just after the given idea for a sort field.
+// AI-hint: Time-based sort values use RFC3339Nano so no precision is lost between pages.
+func CursorFor(ideaEntity *Idea, sortBy SortField) Cursor {
+	var value string
+	switch sortBy {
+	case SortByUpdatedAt:
+		value = ideaEntity.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case SortByVoteCount:
+		value = strconv.Itoa(ideaEntity.VoteCount)
+	default:
+		value = ideaEntity.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return Cursor{
+		SortBy:    sortBy,
+		SortValue: value,
+		ID:        ideaEntity.ID,
+	}
+}
+
+// Error types for idea listing queries.
+// AI-hint: Returned by Normalize/DecodeCursor and mapped to 400 responses.
+var (
+	ErrInvalidQuery  = errors.New("invalid idea query")
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+)
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestListQuery_Normalize(t *testing.T) {
+	t.Run("applies defaults", func(t *testing.T) {
+		query := ListQuery{}
+		if err := query.Normalize(); err != nil {
+			t.Fatalf("Normalize failed: %v", err)
+		}
+
+		if query.Limit != DefaultPageSize {
+			t.Errorf("Expected limit %d, got %d", DefaultPageSize, query.Limit)
+		}
+		if query.SortBy != SortByCreatedAt {
+			t.Errorf("Expected sort %s, got %s", SortByCreatedAt, query.SortBy)
+		}
+		if query.SortDirection != SortDescending {
+			t.Errorf("Expected direction %s, got %s", SortDescending, query.SortDirection)
+		}
+	})
+
+	t.Run("caps page size", func(t *testing.T) {
+		query := ListQuery{Limit: MaxPageSize + 50}
+		if err := query.Normalize(); err != nil {
+			t.Fatalf("Normalize failed: %v", err)
+		}
+		if query.Limit != MaxPageSize {
+			t.Errorf("Expected limit %d, got %d", MaxPageSize, query.Limit)
+		}
+	})
+
+	t.Run("rejects unknown sort field", func(t *testing.T) {
+		query := ListQuery{SortBy: "title; DROP TABLE ideas"}
+		if err := query.Normalize(); err != ErrInvalidQuery {
+			t.Errorf("Expected ErrInvalidQuery, got %v", err)
+		}
+	})
+
+	t.Run("rejects unknown sort direction", func(t *testing.T) {
+		query := ListQuery{SortDirection: "sideways"}
+		if err := query.Normalize(); err != ErrInvalidQuery {
+			t.Errorf("Expected ErrInvalidQuery, got %v", err)
+		}
+	})
+
+	t.Run("rejects unknown status filter", func(t *testing.T) {
+		status := Status("shipped")
+		query := ListQuery{Status: &status}
+		if err := query.Normalize(); err != ErrInvalidQuery {
+			t.Errorf("Expected ErrInvalidQuery, got %v", err)
+		}
+	})
+
+	t.Run("rejects inverted date range", func(t *testing.T) {
+		after := time.Now()
+		before := after.Add(-time.Hour)
+		query := ListQuery{CreatedAfter: &after, CreatedBefore: &before}
+		if err := query.Normalize(); err != ErrInvalidQuery {
+			t.Errorf("Expected ErrInvalidQuery, got %v", err)
+		}
+	})
+
+	t.Run("rejects cursor for a different sort field", func(t *testing.T) {
+		idea, _ := NewIdea("Title", "Content", uuid.New())
+		cursor, err := EncodeCursor(CursorFor(idea, SortByUpdatedAt))
+		if err != nil {
+			t.Fatalf("EncodeCursor failed: %v", err)
+		}
+
+		query := ListQuery{SortBy: SortByCreatedAt, Cursor: cursor}
+		if err := query.Normalize(); err != ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+
+	token, err := EncodeCursor(CursorFor(idea, SortByCreatedAt))
+	if err != nil {
+		t.Fatalf("EncodeCursor failed: %v", err)
+	}
+
+	cursor, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor failed: %v", err)
+	}
+
+	if cursor.ID != idea.ID {
+		t.Errorf("Expected cursor ID %s, got %s", idea.ID, cursor.ID)
+	}
+	if cursor.SortBy != SortByCreatedAt {
+		t.Errorf("Expected cursor sort %s, got %s", SortByCreatedAt, cursor.SortBy)
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, cursor.SortValue)
+	if err != nil {
+		t.Fatalf("Cursor sort value is not RFC3339Nano: %v", err)
+	}
+	if !parsed.Equal(idea.CreatedAt) {
+		t.Errorf("Expected cursor time %v, got %v", idea.CreatedAt, parsed)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tokens := []string{"", "not-base64!", "bm90LWpzb24", "e30"}
+	for _, token := range tokens {
+		if _, err := DecodeCursor(token); err != ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestCursorFor_VoteCount(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	idea.VoteCount = 42
+
+	cursor := CursorFor(idea, SortByVoteCount)
+	if cursor.SortValue != "42" {
+		t.Errorf("Expected sort value 42, got %s", cursor.SortValue)
+	}
+	if cursor.SortBy != SortByVoteCount {
+		t.Errorf("Expected sort %s, got %s", SortByVoteCount, cursor.SortBy)
+	}
+}
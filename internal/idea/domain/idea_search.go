@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Search limits and highlight markers.
+// AI-hint: Snippets wrap matched terms in HighlightStart/HighlightStop. Snippet text
+// is not HTML-escaped, so clients must escape it before rendering as HTML.
+const (
+	MaxSearchTermLength = 200
+	DefaultSnippetWords = 30
+	HighlightStart      = "<mark>"
+	HighlightStop       = "</mark>"
+)
+
+// SearchMode identifies how a search was executed.
+// AI-hint: FullText uses the PostgreSQL tsvector index; ILike is the substring
+// fallback used when the text search configuration is not available.
+type SearchMode string
+
+const (
+	SearchModeFullText SearchMode = "full_text"
+	SearchModeILike    SearchMode = "ilike"
+)
+
+// SearchQuery describes a ranked free-text search over idea titles and content.
+// AI-hint: Query object passed to Repository.Search; call Normalize before use. Only
+// ideas visible to Viewer are returned.
+type SearchQuery struct {
+	Text   string
+	Limit  int
+	Offset int
+	Viewer Viewer
+}
+
+// SearchHit is a single ranked search result with highlighted snippets.
+type SearchHit struct {
+	Idea           *Idea
+	Rank           float64
+	TitleSnippet   string
+	ContentSnippet string
+}
+
+// SearchResult is a page of ranked search hits.
+type SearchResult struct {
+	Hits []*SearchHit
+	Mode SearchMode
+}
+
+// Normalize trims the search text, applies paging defaults, and validates the query.
+// AI-hint: Empty or overly long search terms are rejected with ErrInvalidSearch.
+func (q *SearchQuery) Normalize() error {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" || utf8.RuneCountInString(q.Text) > MaxSearchTermLength {
+		return ErrInvalidSearch
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+	if q.Offset < 0 {
+		return ErrInvalidSearch
+	}
+
+	return nil
+}
+
+// HighlightSnippet returns an excerpt of text around the first case-insensitive match
+// of term, with every match wrapped in highlight markers.
+// AI-hint: Used by the ILIKE fallback to approximate PostgreSQL ts_headline output.
+// maxWords bounds the excerpt length; text without a match is truncated from the start.
+func HighlightSnippet(text, term string, maxWords int) string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return ""
+	}
+	if maxWords <= 0 {
+		maxWords = DefaultSnippetWords
+	}
+
+	lowerTerm := strings.ToLower(strings.TrimSpace(term))
+
+	// Center the excerpt on the first word containing the term
+	start := 0
+	if lowerTerm != "" {
+		for i, word := range words {
+			if strings.Contains(strings.ToLower(word), lowerTerm) {
+				start = i - maxWords/2
+				break
+			}
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxWords
+	if end > len(words) {
+		end = len(words)
+		if end-maxWords > 0 {
+			start = end - maxWords
+		}
+	}
+
+	excerpt := strings.Join(words[start:end], " ")
+	if lowerTerm == "" {
+		return excerpt
+	}
+
+	return highlightAll(excerpt, lowerTerm)
+}
+
+// highlightAll wraps every case-insensitive occurrence of lowerTerm in highlight markers.
+func highlightAll(text, lowerTerm string) string {
+	lowerText := strings.ToLower(text)
+	// Lowercasing can change byte lengths for some scripts; fall back to no highlighting
+	if len(lowerText) != len(text) {
+		return text
+	}
+
+	var builder strings.Builder
+	position := 0
+	for {
+		index := strings.Index(lowerText[position:], lowerTerm)
+		if index < 0 {
+			builder.WriteString(text[position:])
+			break
+		}
+		matchStart := position + index
+		matchEnd := matchStart + len(lowerTerm)
+		builder.WriteString(text[position:matchStart])
+		builder.WriteString(HighlightStart)
+		builder.WriteString(text[matchStart:matchEnd])
+		builder.WriteString(HighlightStop)
+		position = matchEnd
+	}
+
+	return builder.String()
+}
+
+// Error types for idea search.
+// AI-hint: Returned by SearchQuery.Normalize and mapped to 400 responses.
+var (
+	ErrInvalidSearch = errors.New("invalid search query")
+)
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchQuery_Normalize(t *testing.T) {
+	t.Run("trims text and applies defaults", func(t *testing.T) {
+		query := SearchQuery{Text: "  dashboard  "}
+		if err := query.Normalize(); err != nil {
+			t.Fatalf("Normalize failed: %v", err)
+		}
+		if query.Text != "dashboard" {
+			t.Errorf("Expected trimmed text, got %q", query.Text)
+		}
+		if query.Limit != DefaultPageSize {
+			t.Errorf("Expected limit %d, got %d", DefaultPageSize, query.Limit)
+		}
+	})
+
+	t.Run("rejects empty text", func(t *testing.T) {
+		query := SearchQuery{Text: "   "}
+		if err := query.Normalize(); err != ErrInvalidSearch {
+			t.Errorf("Expected ErrInvalidSearch, got %v", err)
+		}
+	})
+
+	t.Run("rejects overly long text", func(t *testing.T) {
+		query := SearchQuery{Text: strings.Repeat("a", MaxSearchTermLength+1)}
+		if err := query.Normalize(); err != ErrInvalidSearch {
+			t.Errorf("Expected ErrInvalidSearch, got %v", err)
+		}
+	})
+
+	t.Run("rejects negative offset", func(t *testing.T) {
+		query := SearchQuery{Text: "dashboard", Offset: -1}
+		if err := query.Normalize(); err != ErrInvalidSearch {
+			t.Errorf("Expected ErrInvalidSearch, got %v", err)
+		}
+	})
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	t.Run("highlights case-insensitive matches", func(t *testing.T) {
+		snippet := HighlightSnippet("Improve the Dashboard filters", "dashboard", 10)
+		expected := "Improve the <mark>Dashboard</mark> filters"
+		if snippet != expected {
+			t.Errorf("Expected %q, got %q", expected, snippet)
+		}
+	})
+
+	t.Run("centers the excerpt on the first match", func(t *testing.T) {
+		text := "one two three four five six seven eight nine ten target eleven twelve"
+		snippet := HighlightSnippet(text, "target", 4)
+		if !strings.Contains(snippet, "<mark>target</mark>") {
+			t.Errorf("Expected snippet to contain the highlighted match, got %q", snippet)
+		}
+		if len(strings.Fields(snippet)) != 4 {
+			t.Errorf("Expected 4 words, got %q", snippet)
+		}
+	})
+
+	t.Run("returns leading words when there is no match", func(t *testing.T) {
+		snippet := HighlightSnippet("alpha beta gamma delta", "zeta", 2)
+		if snippet != "alpha beta" {
+			t.Errorf("Expected %q, got %q", "alpha beta", snippet)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Status is the position of an idea in the product workflow.
+// AI-hint: Value object; allowed moves are defined by statusTransitions and enforced
+// by Idea.ChangeStatus, so no other layer should assign Idea.Status directly.
+type Status string
+
+const (
+	StatusNew         Status = "new"
+	StatusUnderReview Status = "under_review"
+	StatusPlanned     Status = "planned"
+	StatusInProgress  Status = "in_progress"
+	StatusDone        Status = "done"
+	StatusDeclined    Status = "declined"
+)
+
+// statusTransitions lists the statuses reachable from each status.
+// AI-hint: Ideas move forward one step at a time and may be declined from any open
+// status. Done and Declined are terminal.
+var statusTransitions = map[Status][]Status{
+	StatusNew:         {StatusUnderReview, StatusDeclined},
+	StatusUnderReview: {StatusPlanned, StatusDeclined},
+	StatusPlanned:     {StatusInProgress, StatusDeclined},
+	StatusInProgress:  {StatusDone, StatusDeclined},
+	StatusDone:        {},
+	StatusDeclined:    {},
+}
+
+// ParseStatus converts a string into a Status.
+// AI-hint: Accepts any letter case; returns ErrInvalidStatus for unknown values.
+func ParseStatus(value string) (Status, error) {
+	status := Status(strings.ToLower(strings.TrimSpace(value)))
+	if !status.IsValid() {
+		return "", ErrInvalidStatus
+	}
+	return status, nil
+}
+
+// IsValid reports whether the status is part of the workflow.
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from this status.
+func (s Status) IsTerminal() bool {
+	return len(statusTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether moving from this status to next is allowed.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Error types for the idea status workflow.
+// AI-hint: ErrInvalidStatusTransition maps to 409; the others map to 400 responses.
+var (
+	ErrInvalidStatus           = errors.New("invalid idea status")
+	ErrInvalidStatusTransition = errors.New("invalid idea status transition")
+	ErrDeclineReasonRequired   = errors.New("a reason is required to decline an idea")
+)
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus(" Under_Review ")
+	if err != nil {
+		t.Fatalf("ParseStatus failed: %v", err)
+	}
+	if status != StatusUnderReview {
+		t.Errorf("Expected %s, got %s", StatusUnderReview, status)
+	}
+
+	if _, err := ParseStatus("shipped"); err != ErrInvalidStatus {
+		t.Errorf("Expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	allowed := [][2]Status{
+		{StatusNew, StatusUnderReview},
+		{StatusUnderReview, StatusPlanned},
+		{StatusPlanned, StatusInProgress},
+		{StatusInProgress, StatusDone},
+		{StatusNew, StatusDeclined},
+		{StatusInProgress, StatusDeclined},
+	}
+	for _, transition := range allowed {
+		if !transition[0].CanTransitionTo(transition[1]) {
+			t.Errorf("Expected %s -> %s to be allowed", transition[0], transition[1])
+		}
+	}
+
+	denied := [][2]Status{
+		{StatusNew, StatusPlanned},
+		{StatusPlanned, StatusUnderReview},
+		{StatusDone, StatusDeclined},
+		{StatusDeclined, StatusNew},
+		{StatusNew, StatusNew},
+	}
+	for _, transition := range denied {
+		if transition[0].CanTransitionTo(transition[1]) {
+			t.Errorf("Expected %s -> %s to be denied", transition[0], transition[1])
+		}
+	}
+
+	if !StatusDone.IsTerminal() || !StatusDeclined.IsTerminal() || StatusNew.IsTerminal() {
+		t.Error("Expected only done and declined to be terminal")
+	}
+}
+
+func TestIdea_ChangeStatus(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	if idea.Status != StatusNew {
+		t.Fatalf("Expected new ideas to start as %s, got %s", StatusNew, idea.Status)
+	}
+
+	previous, err := idea.ChangeStatus(StatusUnderReview, "")
+	if err != nil {
+		t.Fatalf("ChangeStatus failed: %v", err)
+	}
+	if previous != StatusNew || idea.Status != StatusUnderReview {
+		t.Errorf("Expected new -> under_review, got %s -> %s", previous, idea.Status)
+	}
+
+	// Skipping steps is not allowed
+	if _, err := idea.ChangeStatus(StatusDone, ""); err != ErrInvalidStatusTransition {
+		t.Errorf("Expected ErrInvalidStatusTransition, got %v", err)
+	}
+	if _, err := idea.ChangeStatus(Status("shipped"), ""); err != ErrInvalidStatus {
+		t.Errorf("Expected ErrInvalidStatus, got %v", err)
+	}
+
+	// Declining requires a reason
+	if _, err := idea.ChangeStatus(StatusDeclined, "   "); err != ErrDeclineReasonRequired {
+		t.Errorf("Expected ErrDeclineReasonRequired, got %v", err)
+	}
+	if idea.Status != StatusUnderReview {
+		t.Errorf("Expected failed transitions to leave status unchanged, got %s", idea.Status)
+	}
+
+	if _, err := idea.ChangeStatus(StatusDeclined, " Out of scope "); err != nil {
+		t.Fatalf("ChangeStatus failed: %v", err)
+	}
+	if idea.StatusReason != "Out of scope" {
+		t.Errorf("Expected trimmed reason, got %q", idea.StatusReason)
+	}
+
+	// Declined is terminal
+	if _, err := idea.ChangeStatus(StatusUnderReview, ""); err != ErrInvalidStatusTransition {
+		t.Errorf("Expected ErrInvalidStatusTransition from declined, got %v", err)
+	}
+}
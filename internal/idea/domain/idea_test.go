@@ -114,3 +114,32 @@ func TestIdea_UpdateTitleAndContent(t *testing.T) {
 		t.Error("Expected UpdatedAt to be updated after both changes")
 	}
 }
+
+func TestIdea_CanBeModifiedBy(t *testing.T) {
+	creatorID := uuid.New()
+	idea, err := NewIdea("Title", "Content", creatorID)
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+
+	// Creator can modify their own idea
+	if !idea.CanBeModifiedBy(creatorID, false) {
+		t.Error("Expected creator to be able to modify the idea")
+	}
+
+	// Other users cannot modify the idea
+	otherID := uuid.New()
+	if idea.CanBeModifiedBy(otherID, false) {
+		t.Error("Expected non-creator to be denied")
+	}
+
+	// Moderators can modify any idea
+	if !idea.CanBeModifiedBy(otherID, true) {
+		t.Error("Expected moderator to be able to modify the idea")
+	}
+
+	// Nil user ID is never treated as the creator
+	if idea.CanBeModifiedBy(uuid.Nil, false) {
+		t.Error("Expected nil user ID to be denied")
+	}
+}
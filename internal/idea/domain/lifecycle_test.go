@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIdea_ArchiveAndUnarchive(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("NewIdea failed: %v", err)
+	}
+
+	if idea.IsArchived() {
+		t.Fatal("New idea should not be archived")
+	}
+	if err := idea.Unarchive(); err != ErrIdeaNotArchived {
+		t.Errorf("Expected ErrIdeaNotArchived, got %v", err)
+	}
+
+	if err := idea.Archive(); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if !idea.IsArchived() {
+		t.Error("Expected idea to be archived")
+	}
+	if err := idea.Archive(); err != ErrIdeaAlreadyArchived {
+		t.Errorf("Expected ErrIdeaAlreadyArchived, got %v", err)
+	}
+
+	if err := idea.Unarchive(); err != nil {
+		t.Fatalf("Unarchive failed: %v", err)
+	}
+	if idea.IsArchived() {
+		t.Error("Expected idea to be active again")
+	}
+}
+
+func TestIdea_HasCreator(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("NewIdea failed: %v", err)
+	}
+	if !idea.HasCreator() {
+		t.Error("Expected new idea to have a creator")
+	}
+
+	// Anonymized ideas cannot be modified by anyone but moderators
+	idea.CreatorUserID = uuid.Nil
+	if idea.HasCreator() {
+		t.Error("Expected anonymized idea to have no creator")
+	}
+	if idea.CanBeModifiedBy(uuid.Nil, false) {
+		t.Error("Anonymized idea should not be modifiable without moderation permission")
+	}
+	if !idea.CanBeModifiedBy(uuid.New(), true) {
+		t.Error("Moderators should be able to modify anonymized ideas")
+	}
+}
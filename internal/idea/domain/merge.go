@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Duplicate detection defaults.
+// AI-hint: Scores are pg_trgm similarities between 0 and 1; an idea's score is the
+// higher of its title and content similarity.
+const (
+	DefaultDuplicateThreshold = 0.3
+	DefaultDuplicateLimit     = 5
+	MaxDuplicateLimit         = 20
+)
+
+// DuplicateCandidate is an existing idea that looks similar to another idea.
+type DuplicateCandidate struct {
+	Idea       *Idea
+	Similarity float64
+}
+
+// DuplicateQuery describes a similarity lookup for possible duplicates.
+// AI-hint: ExcludeIdeaID keeps the idea being checked out of its own results, and only
+// ideas visible to Viewer are suggested. Call Normalize before use.
+type DuplicateQuery struct {
+	Title         string
+	Content       string
+	ExcludeIdeaID uuid.UUID
+	Threshold     float64
+	Limit         int
+	Viewer        Viewer
+}
+
+// Normalize applies defaults and clamps the query to supported bounds.
+func (q *DuplicateQuery) Normalize() {
+	if q.Threshold <= 0 || q.Threshold > 1 {
+		q.Threshold = DefaultDuplicateThreshold
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultDuplicateLimit
+	}
+	if q.Limit > MaxDuplicateLimit {
+		q.Limit = MaxDuplicateLimit
+	}
+}
+
+// IdeaMerge records that a duplicate idea was folded into a canonical idea.
+// AI-hint: The record outlives the source idea and serves as its redirect.
+// MovedVotes and MovedComments are filled in by MergeRepository.Merge and not stored.
+type IdeaMerge struct {
+	SourceIdeaID   uuid.UUID  `json:"source_idea_id"`
+	TargetIdeaID   uuid.UUID  `json:"target_idea_id"`
+	MergedByUserID *uuid.UUID `json:"merged_by_user_id,omitempty"`
+	MergedAt       time.Time  `json:"merged_at"`
+	MovedVotes     int        `json:"moved_votes"`
+	MovedComments  int        `json:"moved_comments"`
+}
+
+// NewIdeaMerge creates a merge of the source idea into the target idea.
+// AI-hint: Business rules - an idea cannot be merged into itself, and only into an idea
+// with the same audience so moved votes and comments stay visible to the same users.
+func NewIdeaMerge(source, target *Idea, mergedByUserID uuid.UUID) (*IdeaMerge, error) {
+	if source.ID == target.ID {
+		return nil, ErrInvalidIdeaMerge
+	}
+	if !source.HasSameAudience(target) {
+		return nil, ErrMergeAudienceMismatch
+	}
+
+	mergedBy := mergedByUserID
+	return &IdeaMerge{
+		SourceIdeaID:   source.ID,
+		TargetIdeaID:   target.ID,
+		MergedByUserID: &mergedBy,
+		MergedAt:       time.Now(),
+	}, nil
+}
+
+// MergeRepository defines the interface for idea merge persistence.
+// AI-hint: Merge moves votes (keeping one per user) and comments onto the target,
+// soft-deletes the source, and stores the redirect record in one transaction.
+type MergeRepository interface {
+	Merge(ctx interface{}, merge *IdeaMerge) error
+	FindBySourceID(ctx interface{}, sourceIdeaID uuid.UUID) (*IdeaMerge, error)
+}
+
+// Error types for duplicate detection and merging.
+// AI-hint: ErrInvalidIdeaMerge maps to 400; ErrMergeNotFound means the idea was
+// never merged and is reported as a plain 404. ErrIdeaMerged is returned when
+// restoring a merged duplicate, whose votes and comments now live on the target.
+var (
+	ErrInvalidIdeaMerge = errors.New("an idea cannot be merged into itself")
+	ErrMergeNotFound    = errors.New("idea merge not found")
+	ErrIdeaMerged       = errors.New("idea was merged into another idea")
+
+	ErrMergeAudienceMismatch = errors.New("ideas with different visibility cannot be merged")
+)
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewIdeaMerge(t *testing.T) {
+	source, _ := NewIdea("Dark mode", "Please add dark mode", uuid.New())
+	target, _ := NewIdea("Dark theme", "Support a dark theme", uuid.New())
+	mergedBy := uuid.New()
+
+	merge, err := NewIdeaMerge(source, target, mergedBy)
+	if err != nil {
+		t.Fatalf("NewIdeaMerge failed: %v", err)
+	}
+	if merge.SourceIdeaID != source.ID || merge.TargetIdeaID != target.ID {
+		t.Errorf("Expected %s -> %s, got %s -> %s", source.ID, target.ID, merge.SourceIdeaID, merge.TargetIdeaID)
+	}
+	if merge.MergedByUserID == nil || *merge.MergedByUserID != mergedBy {
+		t.Errorf("Expected merged by %s, got %v", mergedBy, merge.MergedByUserID)
+	}
+
+	if _, err := NewIdeaMerge(source, source, mergedBy); err != ErrInvalidIdeaMerge {
+		t.Errorf("Expected ErrInvalidIdeaMerge, got %v", err)
+	}
+}
+
+func TestDuplicateQuery_Normalize(t *testing.T) {
+	query := DuplicateQuery{}
+	query.Normalize()
+	if query.Threshold != DefaultDuplicateThreshold {
+		t.Errorf("Expected default threshold %v, got %v", DefaultDuplicateThreshold, query.Threshold)
+	}
+	if query.Limit != DefaultDuplicateLimit {
+		t.Errorf("Expected default limit %d, got %d", DefaultDuplicateLimit, query.Limit)
+	}
+
+	query = DuplicateQuery{Threshold: 0.5, Limit: 100}
+	query.Normalize()
+	if query.Threshold != 0.5 {
+		t.Errorf("Expected threshold 0.5, got %v", query.Threshold)
+	}
+	if query.Limit != MaxDuplicateLimit {
+		t.Errorf("Expected limit clamped to %d, got %d", MaxDuplicateLimit, query.Limit)
+	}
+}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CurrentRevisionNumber identifies the idea's live title/content in revision comparisons.
+// AI-hint: Stored revisions are numbered from 1; number 0 is never persisted.
+const CurrentRevisionNumber = 0
+
+// maxDiffCells bounds the LCS table size used by DiffLines.
+const maxDiffCells = 4_000_000
+
+// Revision is a snapshot of an idea's title and content taken before an edit.
+// AI-hint: Revision N holds the text as it was before edit N; EditorUserID and
+// CreatedAt describe that edit. EditorUserID is nil when the editor was deleted.
+type Revision struct {
+	ID           uuid.UUID  `json:"id"`
+	IdeaID       uuid.UUID  `json:"idea_id"`
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Content      string     `json:"content"`
+	EditorUserID *uuid.UUID `json:"editor_user_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Revise applies a title/content edit and returns the revision recording the previous text.
+// AI-hint: Returns a nil revision when neither title nor content changes, so no-op
+// edits (e.g. tag-only updates) do not clutter the history. The revision number is
+// assigned by the repository when it is saved.
+func (i *Idea) Revise(title, content string, editorUserID uuid.UUID) (*Revision, error) {
+	previous := i.Snapshot()
+
+	if err := i.UpdateTitle(title); err != nil {
+		return nil, err
+	}
+	if err := i.UpdateContent(content); err != nil {
+		return nil, err
+	}
+
+	if i.Title == previous.Title && i.Content == previous.Content {
+		return nil, nil
+	}
+
+	editorID := editorUserID
+	return &Revision{
+		ID:           uuid.New(),
+		IdeaID:       i.ID,
+		Title:        previous.Title,
+		Content:      previous.Content,
+		EditorUserID: &editorID,
+		CreatedAt:    i.UpdatedAt,
+	}, nil
+}
+
+// Snapshot returns the idea's current title and content as an unsaved revision.
+// AI-hint: Numbered CurrentRevisionNumber so it can be compared with stored revisions.
+func (i *Idea) Snapshot() *Revision {
+	return &Revision{
+		IdeaID:    i.ID,
+		Number:    CurrentRevisionNumber,
+		Title:     i.Title,
+		Content:   i.Content,
+		CreatedAt: i.UpdatedAt,
+	}
+}
+
+// DiffOp identifies a line-level change in a diff.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine is a single line of a diff.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiff is the difference between two versions of an idea.
+type RevisionDiff struct {
+	From    *Revision
+	To      *Revision
+	Title   []DiffLine
+	Content []DiffLine
+}
+
+// DiffRevisions compares two versions of an idea line by line.
+// AI-hint: Either side may be a Snapshot of the current idea.
+func DiffRevisions(from, to *Revision) *RevisionDiff {
+	return &RevisionDiff{
+		From:    from,
+		To:      to,
+		Title:   DiffLines(from.Title, to.Title),
+		Content: DiffLines(from.Content, to.Content),
+	}
+}
+
+// DiffLines computes a line-based diff turning before into after.
+// AI-hint: Uses a longest-common-subsequence table after trimming the common prefix
+// and suffix. Very large inputs fall back to deleting and re-inserting the changed
+// middle section to keep memory bounded.
+func DiffLines(before, after string) []DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// Trim the common prefix and suffix
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+	diff = append(diff, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	return diff
+}
+
+// diffMiddle diffs the differing middle sections of two line slices.
+func diffMiddle(a, b []string) []DiffLine {
+	diff := make([]DiffLine, 0, len(a)+len(b))
+
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			diff = append(diff, DiffLine{Op: DiffDelete, Text: line})
+		}
+		for _, line := range b {
+			diff = append(diff, DiffLine{Op: DiffInsert, Text: line})
+		}
+		return diff
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+
+	return diff
+}
+
+// splitLines splits text into lines, normalizing Windows line endings.
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// RevisionRepository defines the interface for reading idea revision history.
+// AI-hint: Revisions are written by Repository.Update in the same transaction as the
+// edit they describe; this interface is read-only.
+type RevisionRepository interface {
+	FindByIdeaID(ctx interface{}, ideaID uuid.UUID) ([]*Revision, error)
+	FindByNumber(ctx interface{}, ideaID uuid.UUID, number int) (*Revision, error)
+}
+
+// Error types for idea revisions.
+// AI-hint: ErrRevisionNotFound maps to 404 responses.
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+)
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIdea_Revise(t *testing.T) {
+	idea, err := NewIdea("Original Title", "Original Content", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	editorID := uuid.New()
+
+	revision, err := idea.Revise("New Title", "Original Content", editorID)
+	if err != nil {
+		t.Fatalf("Revise failed: %v", err)
+	}
+	if revision == nil {
+		t.Fatal("Expected a revision for a changed title")
+	}
+	if revision.Title != "Original Title" || revision.Content != "Original Content" {
+		t.Errorf("Expected revision to hold the previous text, got %q / %q", revision.Title, revision.Content)
+	}
+	if revision.EditorUserID == nil || *revision.EditorUserID != editorID {
+		t.Error("Expected revision to record the editor")
+	}
+	if revision.IdeaID != idea.ID {
+		t.Error("Expected revision to reference the idea")
+	}
+	if idea.Title != "New Title" {
+		t.Errorf("Expected idea title to be updated, got %s", idea.Title)
+	}
+
+	// No-op edits do not produce a revision
+	revision, err = idea.Revise(" New Title ", "Original Content", editorID)
+	if err != nil {
+		t.Fatalf("Revise failed: %v", err)
+	}
+	if revision != nil {
+		t.Error("Expected no revision when nothing changed")
+	}
+
+	if _, err := idea.Revise("", "Content", editorID); err == nil {
+		t.Error("Expected error for empty title")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines("one\ntwo\nthree", "one\n2\nthree\nfour")
+
+	expected := []DiffLine{
+		{Op: DiffEqual, Text: "one"},
+		{Op: DiffDelete, Text: "two"},
+		{Op: DiffInsert, Text: "2"},
+		{Op: DiffEqual, Text: "three"},
+		{Op: DiffInsert, Text: "four"},
+	}
+	if len(diff) != len(expected) {
+		t.Fatalf("Expected %d diff lines, got %d: %v", len(expected), len(diff), diff)
+	}
+	for i := range expected {
+		if diff[i] != expected[i] {
+			t.Errorf("Line %d: expected %v, got %v", i, expected[i], diff[i])
+		}
+	}
+}
+
+func TestDiffLines_Identical(t *testing.T) {
+	diff := DiffLines("same\ntext", "same\r\ntext")
+	for _, line := range diff {
+		if line.Op != DiffEqual {
+			t.Errorf("Expected only equal lines, got %v", diff)
+			break
+		}
+	}
+
+	if diff := DiffLines("", ""); len(diff) != 0 {
+		t.Errorf("Expected empty diff, got %v", diff)
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	idea, err := NewIdea("Title", "Line one", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	revision, err := idea.Revise("Title", "Line one\nLine two", uuid.New())
+	if err != nil {
+		t.Fatalf("Revise failed: %v", err)
+	}
+	revision.Number = 1
+
+	diff := DiffRevisions(revision, idea.Snapshot())
+	if diff.To.Number != CurrentRevisionNumber {
+		t.Errorf("Expected snapshot to use the current revision number, got %d", diff.To.Number)
+	}
+	if len(diff.Title) != 1 || diff.Title[0].Op != DiffEqual {
+		t.Errorf("Expected unchanged title, got %v", diff.Title)
+	}
+	if len(diff.Content) != 2 || diff.Content[1].Op != DiffInsert {
+		t.Errorf("Expected inserted second line, got %v", diff.Content)
+	}
+}
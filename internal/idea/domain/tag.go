@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Tag limits.
+// AI-hint: MaxTagsPerIdea also bounds how many tags a listing filter may combine.
+const (
+	MaxTagNameLength = 50
+	MaxTagsPerIdea   = 10
+)
+
+// Tag is a product-area label that ideas can be organized by.
+// AI-hint: Tag names are unique case-insensitively. Ideas reference tags by ID, so
+// renaming a tag is immediately reflected on every tagged idea. IdeaCount is a
+// read-only value filled in by listings.
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	IdeaCount int       `json:"idea_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTag creates a new Tag with validation.
+// AI-hint: Factory method that normalizes whitespace in the name and enforces length rules.
+func NewTag(name string) (*Tag, error) {
+	name, err := validateTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Tag{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Rename changes the tag's display name with validation.
+// AI-hint: Domain method that maintains name invariants and updates the modification timestamp.
+func (t *Tag) Rename(name string) error {
+	name, err := validateTagName(name)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// NormalizeTagName trims a tag name and collapses inner whitespace.
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeTagNames normalizes a list of tag names, dropping case-insensitive duplicates.
+// AI-hint: Preserves the first spelling and order of each tag. Returns ErrInvalidTagName
+// for empty or overly long names and ErrTooManyTags above MaxTagsPerIdea.
+func NormalizeTagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := validateTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+
+	if len(normalized) > MaxTagsPerIdea {
+		return nil, ErrTooManyTags
+	}
+
+	return normalized, nil
+}
+
+// validateTagName normalizes a tag name and enforces non-empty and length rules.
+func validateTagName(name string) (string, error) {
+	name = NormalizeTagName(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxTagNameLength {
+		return "", ErrInvalidTagName
+	}
+	return name, nil
+}
+
+// SetTags replaces the idea's tags.
+// AI-hint: Expects canonical tag names (as stored on existing tags); the application
+// layer resolves user input against the tag repository before calling this.
+func (i *Idea) SetTags(names []string) error {
+	normalized, err := NormalizeTagNames(names)
+	if err != nil {
+		return err
+	}
+	i.Tags = normalized
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// TagRepository defines the interface for tag persistence operations.
+// AI-hint: Rename and Merge run in a single transaction so every tagged idea sees
+// the change atomically.
+type TagRepository interface {
+	Save(ctx interface{}, tag *Tag) error
+	FindByID(ctx interface{}, id uuid.UUID) (*Tag, error)
+	FindByNames(ctx interface{}, names []string) ([]*Tag, error)
+	FindAll(ctx interface{}) ([]*Tag, error)
+	Rename(ctx interface{}, tag *Tag) error
+	Merge(ctx interface{}, sourceID, targetID uuid.UUID) error
+	Delete(ctx interface{}, id uuid.UUID) error
+}
+
+// Error types for idea tags.
+// AI-hint: ErrTagAlreadyExists maps to 409; ErrTagNotFound maps to 404 on tag endpoints
+// and to 400 when an idea references an unknown tag.
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrInvalidTagName   = errors.New("invalid tag name")
+	ErrTooManyTags      = errors.New("too many tags")
+	ErrInvalidTagMerge  = errors.New("a tag cannot be merged into itself")
+)
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewTag(t *testing.T) {
+	tag, err := NewTag("  Mobile   App ")
+	if err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+	if tag.Name != "Mobile App" {
+		t.Errorf("Expected normalized name 'Mobile App', got %q", tag.Name)
+	}
+
+	if _, err := NewTag("   "); err != ErrInvalidTagName {
+		t.Errorf("Expected ErrInvalidTagName for blank name, got %v", err)
+	}
+	if _, err := NewTag(strings.Repeat("a", MaxTagNameLength+1)); err != ErrInvalidTagName {
+		t.Errorf("Expected ErrInvalidTagName for long name, got %v", err)
+	}
+}
+
+func TestTag_Rename(t *testing.T) {
+	tag, err := NewTag("Billing")
+	if err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+
+	if err := tag.Rename(" Payments "); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if tag.Name != "Payments" {
+		t.Errorf("Expected name Payments, got %s", tag.Name)
+	}
+	if err := tag.Rename(""); err != ErrInvalidTagName {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+}
+
+func TestNormalizeTagNames(t *testing.T) {
+	names, err := NormalizeTagNames([]string{"Mobile", " mobile ", "Web  App", "MOBILE"})
+	if err != nil {
+		t.Fatalf("NormalizeTagNames failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "Mobile" || names[1] != "Web App" {
+		t.Errorf("Expected [Mobile Web App], got %v", names)
+	}
+
+	if _, err := NormalizeTagNames([]string{"ok", ""}); err != ErrInvalidTagName {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+
+	tooMany := make([]string, 0, MaxTagsPerIdea+1)
+	for i := 0; i <= MaxTagsPerIdea; i++ {
+		tooMany = append(tooMany, strings.Repeat("t", i+1))
+	}
+	if _, err := NormalizeTagNames(tooMany); err != ErrTooManyTags {
+		t.Errorf("Expected ErrTooManyTags, got %v", err)
+	}
+}
+
+func TestIdea_SetTags(t *testing.T) {
+	idea, err := NewIdea("Title", "Content", uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	if idea.Tags == nil || len(idea.Tags) != 0 {
+		t.Error("Expected new ideas to have an empty tag list")
+	}
+
+	if err := idea.SetTags([]string{"Mobile", "mobile", "Billing"}); err != nil {
+		t.Fatalf("SetTags failed: %v", err)
+	}
+	if len(idea.Tags) != 2 {
+		t.Errorf("Expected duplicates to be removed, got %v", idea.Tags)
+	}
+}
+
+func TestListQuery_NormalizeTags(t *testing.T) {
+	query := ListQuery{Tags: []string{"Mobile", "mobile"}}
+	if err := query.Normalize(); err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if len(query.Tags) != 1 {
+		t.Errorf("Expected duplicate tag filters to be removed, got %v", query.Tags)
+	}
+	if query.TagMatch != TagMatchAny {
+		t.Errorf("Expected default tag match %s, got %s", TagMatchAny, query.TagMatch)
+	}
+
+	query = ListQuery{Tags: []string{"Mobile"}, TagMatch: "some"}
+	if err := query.Normalize(); err != ErrInvalidQuery {
+		t.Errorf("Expected ErrInvalidQuery for unknown tag match, got %v", err)
+	}
+}
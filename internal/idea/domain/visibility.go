@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Visibility controls who besides its creator can see an idea.
+// AI-hint: Enum-like type for idea access control. Public ideas are visible to every
+// user; role-restricted ideas to members of the listed roles; private ideas to the
+// listed users only. Callers that may read any idea (idea:read_any) see all of them.
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"
+	VisibilityRoles   Visibility = "roles"
+	VisibilityPrivate Visibility = "private"
+)
+
+// IsValid reports whether the visibility is one of the defined values.
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityRoles, VisibilityPrivate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Audience describes an idea's visibility together with the roles or users it lists.
+// AI-hint: Role-restricted ideas need at least one role; private ideas may list no users,
+// in which case only the creator (and readers of any idea) can see them.
+type Audience struct {
+	Visibility Visibility
+	RoleIDs    []uuid.UUID
+	UserIDs    []uuid.UUID
+}
+
+// PublicAudience returns the audience of ideas visible to everyone.
+func PublicAudience() Audience {
+	return Audience{Visibility: VisibilityPublic, RoleIDs: []uuid.UUID{}, UserIDs: []uuid.UUID{}}
+}
+
+// Normalize validates the audience, removing duplicate IDs and sorting them.
+// AI-hint: Returns ErrInvalidVisibility for unknown visibilities, nil IDs, or lists that
+// do not belong to the visibility (e.g. users on a role-restricted idea).
+func (a *Audience) Normalize() error {
+	if a.Visibility == "" {
+		a.Visibility = VisibilityPublic
+	}
+	if !a.Visibility.IsValid() {
+		return ErrInvalidVisibility
+	}
+
+	roleIDs, err := normalizeAudienceIDs(a.RoleIDs)
+	if err != nil {
+		return err
+	}
+	userIDs, err := normalizeAudienceIDs(a.UserIDs)
+	if err != nil {
+		return err
+	}
+
+	switch a.Visibility {
+	case VisibilityPublic:
+		if len(roleIDs) > 0 || len(userIDs) > 0 {
+			return ErrInvalidVisibility
+		}
+	case VisibilityRoles:
+		if len(roleIDs) == 0 || len(userIDs) > 0 {
+			return ErrInvalidVisibility
+		}
+	case VisibilityPrivate:
+		if len(roleIDs) > 0 {
+			return ErrInvalidVisibility
+		}
+	}
+
+	a.RoleIDs = roleIDs
+	a.UserIDs = userIDs
+	return nil
+}
+
+// normalizeAudienceIDs deduplicates and sorts IDs, rejecting uuid.Nil.
+func normalizeAudienceIDs(ids []uuid.UUID) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	normalized := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil {
+			return nil, ErrInvalidVisibility
+		}
+		if !seen[id] {
+			seen[id] = true
+			normalized = append(normalized, id)
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		return bytes.Compare(normalized[i][:], normalized[j][:]) < 0
+	})
+	return normalized, nil
+}
+
+// Viewer identifies who is reading ideas, for visibility checks.
+// AI-hint: Every repository read takes a Viewer. The zero value is an anonymous viewer
+// that only sees public ideas, so forgetting to pass one fails closed. SeesAll is set
+// for callers holding idea:read_any and for internal lookups (see SystemViewer).
+type Viewer struct {
+	UserID  uuid.UUID
+	RoleID  uuid.UUID
+	SeesAll bool
+}
+
+// NewViewer creates a viewer from the string IDs used by the auth layer.
+// AI-hint: IDs that are not UUIDs are treated as absent, which only narrows what is visible.
+func NewViewer(userID, roleID string, seesAll bool) Viewer {
+	viewer := Viewer{SeesAll: seesAll}
+	if id, err := uuid.Parse(userID); err == nil {
+		viewer.UserID = id
+	}
+	if id, err := uuid.Parse(roleID); err == nil {
+		viewer.RoleID = id
+	}
+	return viewer
+}
+
+// SystemViewer returns a viewer that sees every idea.
+// AI-hint: Only for internal lookups that are not made on behalf of a user.
+func SystemViewer() Viewer {
+	return Viewer{SeesAll: true}
+}
+
+// Audience returns the idea's current audience.
+func (i *Idea) Audience() Audience {
+	return Audience{Visibility: i.Visibility, RoleIDs: i.VisibleToRoleIDs, UserIDs: i.VisibleToUserIDs}
+}
+
+// SetAudience changes who can see the idea.
+// AI-hint: Validates the audience with Normalize; the creator always keeps access.
+func (i *Idea) SetAudience(audience Audience) error {
+	if err := audience.Normalize(); err != nil {
+		return err
+	}
+	i.Visibility = audience.Visibility
+	i.VisibleToRoleIDs = audience.RoleIDs
+	i.VisibleToUserIDs = audience.UserIDs
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsVisibleTo reports whether the viewer may see the idea.
+// AI-hint: The access control rule for ideas. Persistence applies the same rule in SQL
+// (see visibilityCondition in the idea repository); keep the two in sync.
+func (i *Idea) IsVisibleTo(viewer Viewer) bool {
+	if viewer.SeesAll || i.Visibility == VisibilityPublic {
+		return true
+	}
+	if viewer.UserID != uuid.Nil && i.CreatorUserID == viewer.UserID {
+		return true
+	}
+
+	switch i.Visibility {
+	case VisibilityRoles:
+		return viewer.RoleID != uuid.Nil && containsID(i.VisibleToRoleIDs, viewer.RoleID)
+	case VisibilityPrivate:
+		return viewer.UserID != uuid.Nil && containsID(i.VisibleToUserIDs, viewer.UserID)
+	default:
+		return false
+	}
+}
+
+// HasSameAudience reports whether both ideas are visible to exactly the same users.
+// AI-hint: Merging requires this so votes and comments never become visible to users
+// who could not see them before.
+func (i *Idea) HasSameAudience(other *Idea) bool {
+	if i.Visibility != other.Visibility {
+		return false
+	}
+	if i.Visibility != VisibilityPublic && i.CreatorUserID != other.CreatorUserID {
+		return false
+	}
+	return equalIDs(i.VisibleToRoleIDs, other.VisibleToRoleIDs) && equalIDs(i.VisibleToUserIDs, other.VisibleToUserIDs)
+}
+
+// containsID reports whether ids contains id.
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// equalIDs reports whether both lists hold the same IDs, ignoring order.
+func equalIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !containsID(b, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// Error types for idea visibility.
+// AI-hint: Both map to 400 responses. Ideas a viewer may not see are reported as
+// ErrIdeaNotFound so their existence is not revealed.
+var (
+	ErrInvalidVisibility = errors.New("invalid idea visibility")
+	ErrUnknownAudience   = errors.New("idea visibility lists an unknown role or user")
+)
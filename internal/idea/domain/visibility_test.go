@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAudience_Normalize(t *testing.T) {
+	roleID := uuid.New()
+	userID := uuid.New()
+
+	valid := []Audience{
+		{},
+		{Visibility: VisibilityPublic},
+		{Visibility: VisibilityRoles, RoleIDs: []uuid.UUID{roleID}},
+		{Visibility: VisibilityPrivate},
+		{Visibility: VisibilityPrivate, UserIDs: []uuid.UUID{userID}},
+	}
+	for _, audience := range valid {
+		if err := audience.Normalize(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", audience, err)
+		}
+	}
+
+	invalid := []Audience{
+		{Visibility: "secret"},
+		{Visibility: VisibilityPublic, UserIDs: []uuid.UUID{userID}},
+		{Visibility: VisibilityRoles},
+		{Visibility: VisibilityRoles, RoleIDs: []uuid.UUID{roleID}, UserIDs: []uuid.UUID{userID}},
+		{Visibility: VisibilityPrivate, RoleIDs: []uuid.UUID{roleID}},
+		{Visibility: VisibilityPrivate, UserIDs: []uuid.UUID{uuid.Nil}},
+	}
+	for _, audience := range invalid {
+		if err := audience.Normalize(); err != ErrInvalidVisibility {
+			t.Errorf("Expected ErrInvalidVisibility for %+v, got %v", audience, err)
+		}
+	}
+
+	audience := Audience{Visibility: VisibilityPrivate, UserIDs: []uuid.UUID{userID, userID}}
+	if err := audience.Normalize(); err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if len(audience.UserIDs) != 1 {
+		t.Errorf("Expected duplicate users to be removed, got %v", audience.UserIDs)
+	}
+}
+
+func TestIdea_IsVisibleTo(t *testing.T) {
+	creatorID := uuid.New()
+	listedUserID := uuid.New()
+	allowedRoleID := uuid.New()
+	otherRoleID := uuid.New()
+
+	creator := Viewer{UserID: creatorID, RoleID: otherRoleID}
+	listedUser := Viewer{UserID: listedUserID, RoleID: otherRoleID}
+	roleMember := Viewer{UserID: uuid.New(), RoleID: allowedRoleID}
+	stranger := Viewer{UserID: uuid.New(), RoleID: otherRoleID}
+	reader := Viewer{UserID: uuid.New(), RoleID: otherRoleID, SeesAll: true}
+
+	ideaEntity, _ := NewIdea("Security report", "XSS in the search page", creatorID)
+
+	t.Run("public ideas are visible to everyone", func(t *testing.T) {
+		for _, viewer := range []Viewer{{}, creator, stranger, roleMember} {
+			if !ideaEntity.IsVisibleTo(viewer) {
+				t.Errorf("Expected public idea to be visible to %+v", viewer)
+			}
+		}
+	})
+
+	t.Run("role-restricted ideas", func(t *testing.T) {
+		if err := ideaEntity.SetAudience(Audience{Visibility: VisibilityRoles, RoleIDs: []uuid.UUID{allowedRoleID}}); err != nil {
+			t.Fatalf("SetAudience failed: %v", err)
+		}
+		for _, viewer := range []Viewer{creator, roleMember, reader} {
+			if !ideaEntity.IsVisibleTo(viewer) {
+				t.Errorf("Expected role-restricted idea to be visible to %+v", viewer)
+			}
+		}
+		for _, viewer := range []Viewer{{}, stranger, listedUser} {
+			if ideaEntity.IsVisibleTo(viewer) {
+				t.Errorf("Expected role-restricted idea to be hidden from %+v", viewer)
+			}
+		}
+	})
+
+	t.Run("private ideas", func(t *testing.T) {
+		if err := ideaEntity.SetAudience(Audience{Visibility: VisibilityPrivate, UserIDs: []uuid.UUID{listedUserID}}); err != nil {
+			t.Fatalf("SetAudience failed: %v", err)
+		}
+		for _, viewer := range []Viewer{creator, listedUser, reader} {
+			if !ideaEntity.IsVisibleTo(viewer) {
+				t.Errorf("Expected private idea to be visible to %+v", viewer)
+			}
+		}
+		for _, viewer := range []Viewer{{}, stranger, roleMember} {
+			if ideaEntity.IsVisibleTo(viewer) {
+				t.Errorf("Expected private idea to be hidden from %+v", viewer)
+			}
+		}
+	})
+
+	t.Run("anonymized ideas are not visible to viewers without a user", func(t *testing.T) {
+		anonymized, _ := NewIdea("Old report", "Reported by a deleted user", uuid.New())
+		anonymized.CreatorUserID = uuid.Nil
+		anonymized.SetAudience(Audience{Visibility: VisibilityPrivate})
+		if anonymized.IsVisibleTo(Viewer{}) {
+			t.Error("Expected anonymized private idea to be hidden from the anonymous viewer")
+		}
+	})
+}
+
+func TestNewViewer(t *testing.T) {
+	userID := uuid.New()
+	viewer := NewViewer(userID.String(), "not-a-uuid", false)
+	if viewer.UserID != userID || viewer.RoleID != uuid.Nil || viewer.SeesAll {
+		t.Errorf("Unexpected viewer %+v", viewer)
+	}
+	if !SystemViewer().SeesAll {
+		t.Error("Expected the system viewer to see all ideas")
+	}
+}
+
+func TestNewIdeaMerge_RequiresSameAudience(t *testing.T) {
+	creatorID := uuid.New()
+	source, _ := NewIdea("Leak", "Token in logs", creatorID)
+	target, _ := NewIdea("Token leak", "Tokens are logged", creatorID)
+	source.SetAudience(Audience{Visibility: VisibilityPrivate})
+
+	if _, err := NewIdeaMerge(source, target, uuid.New()); err != ErrMergeAudienceMismatch {
+		t.Errorf("Expected ErrMergeAudienceMismatch, got %v", err)
+	}
+
+	target.SetAudience(Audience{Visibility: VisibilityPrivate})
+	if _, err := NewIdeaMerge(source, target, uuid.New()); err != nil {
+		t.Errorf("Expected merge of ideas with the same audience to succeed, got %v", err)
+	}
+}
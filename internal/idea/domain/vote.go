@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vote represents a single user's upvote on an idea.
+// AI-hint: Each user may hold at most one vote per idea; persistence enforces this
+// with a unique (idea_id, user_id) constraint and keeps Idea.VoteCount in sync.
+type Vote struct {
+	IdeaID    uuid.UUID `json:"idea_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewVote creates a new Vote with validation.
+// AI-hint: Factory method that ensures both sides of the vote are identified.
+func NewVote(ideaID, userID uuid.UUID) (*Vote, error) {
+	if ideaID == uuid.Nil {
+		return nil, errors.New("idea ID cannot be empty")
+	}
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+
+	return &Vote{
+		IdeaID:    ideaID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// VoteRepository defines the interface for vote persistence operations.
+// AI-hint: AddVote and RemoveVote return the idea's vote count after the change so
+// callers can report it without a second round trip.
+type VoteRepository interface {
+	AddVote(ctx interface{}, vote *Vote) (int, error)
+	RemoveVote(ctx interface{}, ideaID, userID uuid.UUID) (int, error)
+	HasVoted(ctx interface{}, ideaID, userID uuid.UUID) (bool, error)
+}
+
+// Error types for idea voting.
+// AI-hint: Mapped to 409 and 404 responses respectively.
+var (
+	ErrAlreadyVoted = errors.New("user has already voted for this idea")
+	ErrVoteNotFound = errors.New("vote not found")
+)
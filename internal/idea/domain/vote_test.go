@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewVote(t *testing.T) {
+	ideaID := uuid.New()
+	userID := uuid.New()
+
+	vote, err := NewVote(ideaID, userID)
+	if err != nil {
+		t.Fatalf("NewVote failed: %v", err)
+	}
+	if vote.IdeaID != ideaID || vote.UserID != userID {
+		t.Error("Expected vote to reference the given idea and user")
+	}
+	if vote.CreatedAt.IsZero() {
+		t.Error("Expected CreatedAt to be set")
+	}
+
+	if _, err := NewVote(uuid.Nil, userID); err == nil {
+		t.Error("Expected error for empty idea ID")
+	}
+	if _, err := NewVote(ideaID, uuid.Nil); err == nil {
+		t.Error("Expected error for empty user ID")
+	}
+}
@@ -5,8 +5,11 @@ import (
 	ideaapp "feedback_hub_2/internal/idea/application"
 	ideadomain "feedback_hub_2/internal/idea/domain"
 	"feedback_hub_2/internal/shared/web"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -27,23 +30,139 @@ func NewIdeaHandler(ideaService *ideaapp.IdeaApplicationService) *IdeaHandler {
 }
 
 // CreateIdeaRequest represents the request body for creating an idea.
-// AI-hint: DTO for idea creation API with validation-friendly structure.
+// AI-hint: DTO for idea creation API with validation-friendly structure. Omitting
+// visibility creates a public idea.
 type CreateIdeaRequest struct {
-	Title   string `json:"title" example:"Improve user dashboard"`
-	Content string `json:"content" example:"The current dashboard could be enhanced with better data visualization and filtering options."`
+	Title      string   `json:"title" example:"Improve user dashboard"`
+	Content    string   `json:"content" example:"The current dashboard could be enhanced with better data visualization and filtering options."`
+	Tags       []string `json:"tags,omitempty" example:"Dashboard,Analytics"`
+	Visibility string   `json:"visibility,omitempty" example:"public" enums:"public,roles,private"`
+	RoleIDs    []string `json:"role_ids,omitempty"`
+	UserIDs    []string `json:"user_ids,omitempty"`
+}
+
+// ChangeVisibilityRequest represents the request body for changing who can see an idea.
+// AI-hint: role_ids is required for "roles"; user_ids is optional for "private" and
+// the creator always keeps access.
+type ChangeVisibilityRequest struct {
+	Visibility string   `json:"visibility" example:"roles" enums:"public,roles,private"`
+	RoleIDs    []string `json:"role_ids,omitempty"`
+	UserIDs    []string `json:"user_ids,omitempty"`
 }
 
 // CreateIdeaResponse represents the response body for idea creation.
 // AI-hint: DTO for idea creation API responses with consistent structure.
+// possible_duplicates lists similar existing ideas so the client can suggest merging.
 type CreateIdeaResponse struct {
-	ID string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ID                 string              `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PossibleDuplicates []DuplicateResponse `json:"possible_duplicates"`
 }
 
 // UpdateIdeaRequest represents the request body for updating an idea.
-// AI-hint: DTO for idea update API with validation-friendly structure.
+// AI-hint: DTO for idea update API with validation-friendly structure. Omitting tags
+// keeps the current tags; sending an empty list removes them.
 type UpdateIdeaRequest struct {
-	Title   string `json:"title" example:"Improved user dashboard"`
-	Content string `json:"content" example:"The dashboard has been enhanced with better data visualization and filtering options."`
+	Title   string   `json:"title" example:"Improved user dashboard"`
+	Content string   `json:"content" example:"The dashboard has been enhanced with better data visualization and filtering options."`
+	Tags    []string `json:"tags,omitempty" example:"Dashboard,Analytics"`
+}
+
+// IdeaResponse represents the response body for idea retrieval operations.
+// AI-hint: DTO for idea API responses with consistent structure. creator_user_id is
+// empty for ideas whose creator's account was deleted.
+type IdeaResponse struct {
+	ID               string   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title            string   `json:"title" example:"Improve user dashboard"`
+	Content          string   `json:"content" example:"The current dashboard could be enhanced with better data visualization and filtering options."`
+	CreatorUserID    string   `json:"creator_user_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Status           string   `json:"status" example:"under_review"`
+	StatusReason     string   `json:"status_reason,omitempty" example:"Duplicate of an existing roadmap item"`
+	Tags             []string `json:"tags" example:"Dashboard,Analytics"`
+	VoteCount        int      `json:"vote_count" example:"12"`
+	Visibility       string   `json:"visibility" example:"public"`
+	VisibleToRoleIDs []string `json:"visible_to_role_ids,omitempty"`
+	VisibleToUserIDs []string `json:"visible_to_user_ids,omitempty"`
+	ArchivedAt       string   `json:"archived_at,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// newIdeaResponse converts a domain idea into its API representation.
+// AI-hint: Single mapping point from domain entity to response DTO.
+func newIdeaResponse(ideaEntity *ideadomain.Idea) IdeaResponse {
+	response := IdeaResponse{
+		ID:           ideaEntity.ID.String(),
+		Title:        ideaEntity.Title,
+		Content:      ideaEntity.Content,
+		Status:       string(ideaEntity.Status),
+		StatusReason: ideaEntity.StatusReason,
+		Tags:         ideaEntity.Tags,
+		VoteCount:    ideaEntity.VoteCount,
+		Visibility:   string(ideaEntity.Visibility),
+		CreatedAt:    ideaEntity.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    ideaEntity.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	for _, roleID := range ideaEntity.VisibleToRoleIDs {
+		response.VisibleToRoleIDs = append(response.VisibleToRoleIDs, roleID.String())
+	}
+	for _, userID := range ideaEntity.VisibleToUserIDs {
+		response.VisibleToUserIDs = append(response.VisibleToUserIDs, userID.String())
+	}
+	if ideaEntity.HasCreator() {
+		response.CreatorUserID = ideaEntity.CreatorUserID.String()
+	}
+	if ideaEntity.ArchivedAt != nil {
+		response.ArchivedAt = ideaEntity.ArchivedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response
+}
+
+// newIdeaResponses converts a list of domain ideas into API representations.
+// AI-hint: Always returns a non-nil slice so empty listings encode as [].
+func newIdeaResponses(ideas []*ideadomain.Idea) []IdeaResponse {
+	responses := make([]IdeaResponse, 0, len(ideas))
+	for _, ideaEntity := range ideas {
+		responses = append(responses, newIdeaResponse(ideaEntity))
+	}
+	return responses
+}
+
+// ListIdeasResponse represents a page of ideas in a listing response.
+// AI-hint: Envelope for cursor pagination; pass next_cursor back as ?cursor= to
+// fetch the following page. next_cursor is omitted on the last page.
+type ListIdeasResponse struct {
+	Items      []IdeaResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJzIjoiY3JlYXRlZF9hdCJ9"`
+}
+
+// SearchHitResponse represents a single ranked search result.
+// AI-hint: Snippets contain <mark> highlight tags around matches and are NOT HTML-escaped.
+type SearchHitResponse struct {
+	Idea           IdeaResponse `json:"idea"`
+	Rank           float64      `json:"rank" example:"0.42"`
+	TitleSnippet   string       `json:"title_snippet" example:"Improve user <mark>dashboard</mark>"`
+	ContentSnippet string       `json:"content_snippet" example:"The current <mark>dashboard</mark> could be enhanced"`
+}
+
+// SearchIdeasResponse represents the response body for idea search.
+// AI-hint: mode reports whether full-text search or the ILIKE fallback was used.
+type SearchIdeasResponse struct {
+	Items []SearchHitResponse `json:"items"`
+	Mode  string              `json:"mode" example:"full_text"`
+}
+
+// ChangeStatusRequest represents the request body for changing an idea's status.
+// AI-hint: reason is required when status is "declined" and optional otherwise.
+type ChangeStatusRequest struct {
+	Status string `json:"status" example:"declined" enums:"new,under_review,planned,in_progress,done,declined"`
+	Reason string `json:"reason,omitempty" example:"Out of scope for this year's roadmap"`
+}
+
+// VoteResponse represents the response body for vote and unvote operations.
+// AI-hint: Returns the idea's vote count after the operation was applied.
+type VoteResponse struct {
+	IdeaID    string `json:"idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	VoteCount int    `json:"vote_count" example:"12"`
 }
 
 // CreateIdea handles POST /ideas requests.
@@ -58,8 +177,9 @@ type UpdateIdeaRequest struct {
 // @Success 201 {object} CreateIdeaResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /ideas [post]
 func (h *IdeaHandler) CreateIdea(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -86,23 +206,45 @@ func (h *IdeaHandler) CreateIdea(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var audience *ideadomain.Audience
+	if req.Visibility != "" || len(req.RoleIDs) > 0 || len(req.UserIDs) > 0 {
+		parsed, ok := parseAudience(w, req.Visibility, req.RoleIDs, req.UserIDs)
+		if !ok {
+			return
+		}
+		audience = &parsed
+	}
+
 	// Call the application service
-	newIdea, err := h.ideaService.CreateIdea(r.Context(), req.Title, req.Content, userID)
+	newIdea, err := h.ideaService.CreateIdea(r.Context(), req.Title, req.Content, req.Tags, audience, userID)
 	if err != nil {
 		switch err {
 		case ideadomain.ErrInvalidIdeaData:
 			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea data")
+		case ideadomain.ErrTagNotFound, ideadomain.ErrInvalidTagName, ideadomain.ErrTooManyTags:
+			writeIdeaTagsError(w, err)
+		case ideadomain.ErrInvalidVisibility, ideadomain.ErrUnknownAudience:
+			writeVisibilityError(w, err)
 		case ideadomain.ErrCreatorNotFound:
 			web.WriteErrorResponse(w, http.StatusBadRequest, "Creator user not found")
+		case ideadomain.ErrEmailNotVerified:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Verify your email address before creating ideas")
 		default:
 			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 		}
 		return
 	}
 
+	// Suggest likely duplicates; a failed lookup must not fail the creation
+	duplicates, err := h.ideaService.FindDuplicates(r.Context(), newIdea.ID, ideadomain.DefaultDuplicateLimit, userID)
+	if err != nil {
+		log.Printf("Warning: failed to find duplicates for idea %s: %v", newIdea.ID, err)
+	}
+
 	// Return the created idea ID
 	response := CreateIdeaResponse{
-		ID: newIdea.ID.String(),
+		ID:                 newIdea.ID.String(),
+		PossibleDuplicates: newDuplicateResponses(duplicates),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -114,7 +256,7 @@ func (h *IdeaHandler) CreateIdea(w http.ResponseWriter, r *http.Request) {
 // AI-hint: Idea update endpoint with authentication, authorization, validation, and proper error handling.
 //
 // @Summary Update an existing idea
-// @Description Update the title and content of an existing feedback idea (authentication required, creator only)
+// @Description Update the title and content of an existing feedback idea (authentication required, creator or Product Owner only)
 // @Tags ideas
 // @Accept json
 // @Produce json
@@ -126,7 +268,7 @@ func (h *IdeaHandler) CreateIdea(w http.ResponseWriter, r *http.Request) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /ideas/{ideaId} [put]
 func (h *IdeaHandler) UpdateIdea(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -137,16 +279,8 @@ func (h *IdeaHandler) UpdateIdea(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract idea ID from URL path
-	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(pathParts) != 2 || pathParts[0] != "ideas" {
-		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
-		return
-	}
-
-	ideaIDStr := pathParts[1]
-	ideaID, err := uuid.Parse(ideaIDStr)
-	if err != nil {
-		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+	ideaID, ok := parseIdeaIDFromPath(w, r)
+	if !ok {
 		return
 	}
 
@@ -168,15 +302,19 @@ func (h *IdeaHandler) UpdateIdea(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call the application service
-	updatedIdea, err := h.ideaService.UpdateIdea(r.Context(), ideaID, req.Title, req.Content, userID)
+	updatedIdea, err := h.ideaService.UpdateIdea(r.Context(), ideaID, req.Title, req.Content, req.Tags, userID)
 	if err != nil {
 		switch err {
+		case ideadomain.ErrTagNotFound, ideadomain.ErrInvalidTagName, ideadomain.ErrTooManyTags:
+			writeIdeaTagsError(w, err)
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
 		case ideadomain.ErrInvalidIdeaData:
 			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea data")
 		case ideadomain.ErrIdeaNotFound:
 			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
 		case ideadomain.ErrUnauthorized:
-			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator can update this idea")
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can update this idea")
 		default:
 			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 		}
@@ -193,3 +331,711 @@ func (h *IdeaHandler) UpdateIdea(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// GetIdea handles GET /ideas/{ideaId} requests.
+// AI-hint: Idea retrieval endpoint with proper error handling for not found cases.
+//
+// @Summary Get an idea by ID
+// @Description Get a feedback idea by its ID (authentication required). Ideas merged into another idea answer 301 with a Location header pointing at the canonical idea.
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} IdeaResponse
+// @Failure 301 {object} MergedIdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId} [get]
+func (h *IdeaHandler) GetIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract idea ID from URL path
+	ideaID, ok := parseIdeaIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Get the idea
+	foundIdea, err := h.ideaService.GetIdea(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			h.writeNotFoundOrMergeRedirect(w, r, ideaID, userID)
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(foundIdea))
+}
+
+// ListIdeas handles GET /ideas requests.
+// AI-hint: Cursor-paginated idea listing with sorting and filtering via query parameters.
+//
+// @Summary List ideas
+// @Description Get a page of feedback ideas with optional filters (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, updated_at, vote_count)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Param creator_id query string false "Filter by creator user ID" format(uuid)
+// @Param status query string false "Filter by status" Enums(new, under_review, planned, in_progress, done, declined)
+// @Param tags query string false "Comma-separated tag names to filter by"
+// @Param tag_match query string false "Whether ideas need any or all of the tags (default any)" Enums(any, all)
+// @Param created_after query string false "Only ideas created at or after this time (RFC3339)"
+// @Param created_before query string false "Only ideas created before this time (RFC3339)"
+// @Param include_archived query bool false "Include archived ideas (default false)"
+// @Success 200 {object} ListIdeasResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas [get]
+func (h *IdeaHandler) ListIdeas(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	query, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.ideaService.ListIdeas(r.Context(), query, userID)
+	if err != nil {
+		writeListError(w, err)
+		return
+	}
+
+	writeListResponse(w, result)
+}
+
+// ListIdeasByUser handles GET /users/{id}/ideas requests.
+// AI-hint: Cursor-paginated idea listing filtered by creator, validating that the user exists.
+//
+// @Summary List ideas by creator
+// @Description Get a page of feedback ideas created by a specific user (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, updated_at, vote_count)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Param status query string false "Filter by status" Enums(new, under_review, planned, in_progress, done, declined)
+// @Param tags query string false "Comma-separated tag names to filter by"
+// @Param tag_match query string false "Whether ideas need any or all of the tags (default any)" Enums(any, all)
+// @Param created_after query string false "Only ideas created at or after this time (RFC3339)"
+// @Param created_before query string false "Only ideas created before this time (RFC3339)"
+// @Param include_archived query bool false "Include archived ideas (default false)"
+// @Success 200 {object} ListIdeasResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /users/{id}/ideas [get]
+func (h *IdeaHandler) ListIdeasByUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract creator user ID from URL path
+	creatorUserID := web.ExtractIDFromPath(r.URL.Path, "/users/")
+	if _, err := uuid.Parse(creatorUserID); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	query, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.ideaService.GetIdeasByCreator(r.Context(), creatorUserID, query, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "User not found")
+		default:
+			writeListError(w, err)
+		}
+		return
+	}
+
+	writeListResponse(w, result)
+}
+
+// SearchIdeas handles GET /ideas/search requests.
+// AI-hint: Ranked full-text search with highlighted snippets; uses ILIKE matching
+// when PostgreSQL text search is unavailable.
+//
+// @Summary Search ideas
+// @Description Full-text search over idea titles and content, ranked by relevance (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param q query string true "Search text (supports quotes, OR and -term)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of results to skip"
+// @Success 200 {object} SearchIdeasResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/search [get]
+func (h *IdeaHandler) SearchIdeas(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	params := r.URL.Query()
+	query := ideadomain.SearchQuery{Text: params.Get("q")}
+
+	if limit := params.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		query.Limit = value
+	}
+
+	if offset := params.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		query.Offset = value
+	}
+
+	result, err := h.ideaService.SearchIdeas(r.Context(), query, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrInvalidSearch:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Search text q is required and must be at most 200 characters")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := SearchIdeasResponse{
+		Items: make([]SearchHitResponse, 0, len(result.Hits)),
+		Mode:  string(result.Mode),
+	}
+	for _, hit := range result.Hits {
+		response.Items = append(response.Items, SearchHitResponse{
+			Idea:           newIdeaResponse(hit.Idea),
+			Rank:           hit.Rank,
+			TitleSnippet:   hit.TitleSnippet,
+			ContentSnippet: hit.ContentSnippet,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteIdea handles DELETE /ideas/{ideaId} requests.
+// AI-hint: Idea deletion endpoint restricted to the creator or a Product Owner. The idea
+// is soft-deleted and can be restored until the retention period expires.
+//
+// @Summary Delete an idea
+// @Description Soft-delete a feedback idea; it can be restored until purged (authentication required, creator or Product Owner only)
+// @Tags ideas
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId} [delete]
+func (h *IdeaHandler) DeleteIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract idea ID from URL path
+	ideaID, ok := parseIdeaIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Call the application service
+	err := h.ideaService.DeleteIdea(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can delete this idea")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return 204 No Content for successful deletion
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangeIdeaStatus handles PUT /ideas/{ideaId}/status requests.
+// AI-hint: Workflow endpoint restricted to roles with idea:change_status; invalid
+// transitions return 409 Conflict.
+//
+// @Summary Change an idea's status
+// @Description Move an idea through the workflow new → under_review → planned → in_progress → done, or decline it with a reason (Product Owner only)
+// @Tags ideas
+// @Accept json
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param status body ChangeStatusRequest true "Status change request"
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/status [put]
+func (h *IdeaHandler) ChangeIdeaStatus(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "status")
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var req ChangeStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if strings.TrimSpace(req.Status) == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Status is required")
+		return
+	}
+
+	// Call the application service
+	updatedIdea, err := h.ideaService.ChangeIdeaStatus(r.Context(), ideaID, req.Status, req.Reason, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions to change idea status")
+		case ideadomain.ErrInvalidStatus:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid status")
+		case ideadomain.ErrDeclineReasonRequired:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "A reason is required to decline an idea")
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrInvalidStatusTransition:
+			web.WriteErrorResponse(w, http.StatusConflict, "Status transition not allowed from the idea's current status")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(updatedIdea))
+}
+
+// ChangeIdeaVisibility handles PUT /ideas/{ideaId}/visibility requests.
+// AI-hint: Restricted to the creator or a Product Owner, like UpdateIdea. Listed roles
+// and users must exist.
+//
+// @Summary Change who can see an idea
+// @Description Make an idea public, restrict it to roles, or make it private to listed users (authentication required, creator or Product Owner only)
+// @Tags ideas
+// @Accept json
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param visibility body ChangeVisibilityRequest true "Visibility change request"
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/visibility [put]
+func (h *IdeaHandler) ChangeIdeaVisibility(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "visibility")
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var req ChangeVisibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if strings.TrimSpace(req.Visibility) == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Visibility is required")
+		return
+	}
+	audience, ok := parseAudience(w, req.Visibility, req.RoleIDs, req.UserIDs)
+	if !ok {
+		return
+	}
+
+	// Call the application service
+	updatedIdea, err := h.ideaService.ChangeVisibility(r.Context(), ideaID, audience, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can change who can see this idea")
+		case ideadomain.ErrInvalidVisibility, ideadomain.ErrUnknownAudience:
+			writeVisibilityError(w, err)
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(updatedIdea))
+}
+
+// parseAudience converts the visibility fields of a request into a domain audience.
+// AI-hint: Writes a 400 response and returns false when an ID is not a UUID; the
+// remaining rules are checked by the domain.
+func parseAudience(w http.ResponseWriter, visibility string, roleIDs, userIDs []string) (ideadomain.Audience, bool) {
+	audience := ideadomain.Audience{Visibility: ideadomain.Visibility(strings.TrimSpace(visibility))}
+	for _, raw := range roleIDs {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid role ID format")
+			return ideadomain.Audience{}, false
+		}
+		audience.RoleIDs = append(audience.RoleIDs, id)
+	}
+	for _, raw := range userIDs {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid user ID format")
+			return ideadomain.Audience{}, false
+		}
+		audience.UserIDs = append(audience.UserIDs, id)
+	}
+	return audience, true
+}
+
+// writeVisibilityError writes the 400 response for an invalid idea audience.
+func writeVisibilityError(w http.ResponseWriter, err error) {
+	switch err {
+	case ideadomain.ErrUnknownAudience:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Visibility lists an unknown role or user")
+	default:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid visibility: use public, roles with role_ids, or private with optional user_ids")
+	}
+}
+
+// VoteIdea handles POST /ideas/{ideaId}/vote requests.
+// AI-hint: Voting endpoint; each user may vote for an idea at most once.
+//
+// @Summary Vote for an idea
+// @Description Cast the current user's vote for an idea (authentication required, one vote per user)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/vote [post]
+func (h *IdeaHandler) VoteIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "vote")
+	if !ok {
+		return
+	}
+
+	// Call the application service
+	voteCount, err := h.ideaService.VoteForIdea(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrAlreadyVoted:
+			web.WriteErrorResponse(w, http.StatusConflict, "You have already voted for this idea")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	writeVoteResponse(w, ideaID, voteCount)
+}
+
+// UnvoteIdea handles DELETE /ideas/{ideaId}/vote requests.
+// AI-hint: Withdraws the current user's vote; 404 when the user had not voted.
+//
+// @Summary Remove a vote from an idea
+// @Description Withdraw the current user's vote for an idea (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/vote [delete]
+func (h *IdeaHandler) UnvoteIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "vote")
+	if !ok {
+		return
+	}
+
+	// Call the application service
+	voteCount, err := h.ideaService.RemoveVote(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrVoteNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "You have not voted for this idea")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	writeVoteResponse(w, ideaID, voteCount)
+}
+
+// writeVoteResponse writes the vote count of an idea after a vote operation.
+func writeVoteResponse(w http.ResponseWriter, ideaID uuid.UUID, voteCount int) {
+	response := VoteResponse{
+		IdeaID:    ideaID.String(),
+		VoteCount: voteCount,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseIdeaIDFromPath extracts the idea ID from a /ideas/{ideaId} URL path.
+// AI-hint: Writes a 400 response and returns false when the path or ID is malformed.
+func parseIdeaIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 2 || pathParts[0] != "ideas" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return uuid.Nil, false
+	}
+
+	ideaID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+		return uuid.Nil, false
+	}
+
+	return ideaID, true
+}
+
+// parseIdeaSubresourcePath extracts the idea ID from a /ideas/{ideaId}/{resource} URL path.
+// AI-hint: Writes a 400 response and returns false when the path or ID is malformed.
+func parseIdeaSubresourcePath(w http.ResponseWriter, r *http.Request, resource string) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 3 || pathParts[0] != "ideas" || pathParts[2] != resource {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return uuid.Nil, false
+	}
+
+	ideaID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+		return uuid.Nil, false
+	}
+
+	return ideaID, true
+}
+
+// parseListQuery builds an idea listing query from URL query parameters.
+// AI-hint: Only parses and type-checks input; defaults and business validation
+// happen in ideadomain.ListQuery.Normalize. Writes a 400 response on malformed input.
+func parseListQuery(w http.ResponseWriter, r *http.Request) (ideadomain.ListQuery, bool) {
+	params := r.URL.Query()
+	query := ideadomain.ListQuery{
+		SortBy:        ideadomain.SortField(params.Get("sort")),
+		SortDirection: ideadomain.SortDirection(strings.ToLower(params.Get("order"))),
+		Cursor:        params.Get("cursor"),
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "limit must be a positive integer")
+			return query, false
+		}
+		query.Limit = value
+	}
+
+	if creatorID := params.Get("creator_id"); creatorID != "" {
+		value, err := uuid.Parse(creatorID)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid creator_id format")
+			return query, false
+		}
+		query.CreatorUserID = &value
+	}
+
+	if status := params.Get("status"); status != "" {
+		value, err := ideadomain.ParseStatus(status)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid status filter")
+			return query, false
+		}
+		query.Status = &value
+	}
+
+	if tags := params.Get("tags"); tags != "" {
+		query.Tags = strings.Split(tags, ",")
+		query.TagMatch = ideadomain.TagMatch(strings.ToLower(params.Get("tag_match")))
+	}
+
+	if createdAfter := params.Get("created_after"); createdAfter != "" {
+		value, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "created_after must be an RFC3339 timestamp")
+			return query, false
+		}
+		query.CreatedAfter = &value
+	}
+
+	if createdBefore := params.Get("created_before"); createdBefore != "" {
+		value, err := time.Parse(time.RFC3339, createdBefore)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "created_before must be an RFC3339 timestamp")
+			return query, false
+		}
+		query.CreatedBefore = &value
+	}
+
+	if includeArchived := params.Get("include_archived"); includeArchived != "" {
+		value, err := strconv.ParseBool(includeArchived)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "include_archived must be true or false")
+			return query, false
+		}
+		query.IncludeArchived = value
+	}
+
+	return query, true
+}
+
+// writeIdeaTagsError maps tag validation errors on idea create/update onto 400 responses.
+func writeIdeaTagsError(w http.ResponseWriter, err error) {
+	switch err {
+	case ideadomain.ErrTagNotFound:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Unknown tag; tags must be created by a Product Owner first")
+	case ideadomain.ErrTooManyTags:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Too many tags")
+	default:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid tag name")
+	}
+}
+
+// writeListError maps listing errors onto HTTP responses.
+func writeListError(w http.ResponseWriter, err error) {
+	switch err {
+	case ideadomain.ErrInvalidQuery:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid query parameters")
+	case ideadomain.ErrInvalidCursor:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid pagination cursor")
+	case ideadomain.ErrCreatorNotFound:
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+	default:
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeListResponse writes a page of ideas using the listing envelope.
+func writeListResponse(w http.ResponseWriter, result *ideadomain.ListResult) {
+	response := ListIdeasResponse{
+		Items:      newIdeaResponses(result.Ideas),
+		NextCursor: result.NextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,146 @@
+package interfaces
+
+import (
+	"encoding/json"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/web"
+	"net/http"
+)
+
+// RestoreIdea handles POST /ideas/{ideaId}/restore requests.
+// AI-hint: Brings back a soft-deleted idea; restricted to the creator or a Product Owner.
+//
+// @Summary Restore a deleted idea
+// @Description Restore a soft-deleted idea before it is purged (creator or Product Owner only)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/restore [post]
+func (h *IdeaHandler) RestoreIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "restore")
+	if !ok {
+		return
+	}
+
+	restoredIdea, err := h.ideaService.RestoreIdea(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can restore this idea")
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrIdeaNotDeleted:
+			web.WriteErrorResponse(w, http.StatusConflict, "Idea is not deleted")
+		case ideadomain.ErrIdeaMerged:
+			web.WriteErrorResponse(w, http.StatusConflict, "Idea was merged into another idea and cannot be restored")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(restoredIdea))
+}
+
+// ArchiveIdea handles POST /ideas/{ideaId}/archive requests.
+// AI-hint: Archived ideas are hidden from listings and search but stay readable by ID.
+//
+// @Summary Archive an idea
+// @Description Hide an idea from listings and search without deleting it (creator or Product Owner only)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/archive [post]
+func (h *IdeaHandler) ArchiveIdea(w http.ResponseWriter, r *http.Request) {
+	h.changeArchived(w, r, true)
+}
+
+// UnarchiveIdea handles DELETE /ideas/{ideaId}/archive requests.
+// AI-hint: Returns an archived idea to listings and search.
+//
+// @Summary Unarchive an idea
+// @Description Return an archived idea to listings and search (creator or Product Owner only)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/archive [delete]
+func (h *IdeaHandler) UnarchiveIdea(w http.ResponseWriter, r *http.Request) {
+	h.changeArchived(w, r, false)
+}
+
+// changeArchived archives or unarchives the idea in the request path.
+func (h *IdeaHandler) changeArchived(w http.ResponseWriter, r *http.Request, archive bool) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "archive")
+	if !ok {
+		return
+	}
+
+	var updatedIdea *ideadomain.Idea
+	var err error
+	if archive {
+		updatedIdea, err = h.ideaService.ArchiveIdea(r.Context(), ideaID, userID)
+	} else {
+		updatedIdea, err = h.ideaService.UnarchiveIdea(r.Context(), ideaID, userID)
+	}
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can archive this idea")
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrIdeaAlreadyArchived:
+			web.WriteErrorResponse(w, http.StatusConflict, "Idea is already archived")
+		case ideadomain.ErrIdeaNotArchived:
+			web.WriteErrorResponse(w, http.StatusConflict, "Idea is not archived")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(updatedIdea))
+}
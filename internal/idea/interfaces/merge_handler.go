@@ -0,0 +1,219 @@
+package interfaces
+
+import (
+	"encoding/json"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/web"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MergeIdeaRequest represents the request body for merging a duplicate idea.
+// AI-hint: The idea in the URL path is the duplicate and is soft-deleted after the merge.
+type MergeIdeaRequest struct {
+	TargetIdeaID string `json:"target_idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// MergeIdeaResponse represents the result of merging a duplicate into a canonical idea.
+type MergeIdeaResponse struct {
+	SourceIdeaID  string       `json:"source_idea_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	TargetIdea    IdeaResponse `json:"target_idea"`
+	MovedVotes    int          `json:"moved_votes" example:"3"`
+	MovedComments int          `json:"moved_comments" example:"5"`
+}
+
+// MergedIdeaResponse is returned with a 301 redirect for ideas merged into another idea.
+type MergedIdeaResponse struct {
+	Error      string `json:"error" example:"Moved Permanently"`
+	Message    string `json:"message" example:"Idea was merged into another idea"`
+	MergedInto string `json:"merged_into" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// DuplicateResponse represents a possible duplicate idea.
+// AI-hint: similarity is a pg_trgm score between 0 and 1; higher is more similar.
+type DuplicateResponse struct {
+	Idea       IdeaResponse `json:"idea"`
+	Similarity float64      `json:"similarity" example:"0.62"`
+}
+
+// ListDuplicatesResponse represents the possible duplicates of an idea.
+type ListDuplicatesResponse struct {
+	IdeaID     string              `json:"idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Duplicates []DuplicateResponse `json:"duplicates"`
+}
+
+// newDuplicateResponses converts duplicate candidates into their API representation.
+// AI-hint: Always returns a non-nil slice so empty results encode as [].
+func newDuplicateResponses(candidates []*ideadomain.DuplicateCandidate) []DuplicateResponse {
+	responses := make([]DuplicateResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		responses = append(responses, DuplicateResponse{
+			Idea:       newIdeaResponse(candidate.Idea),
+			Similarity: candidate.Similarity,
+		})
+	}
+	return responses
+}
+
+// ListDuplicates handles GET /ideas/{ideaId}/duplicates requests.
+// AI-hint: Suggests existing ideas similar to this one by trigram similarity.
+//
+// @Summary List possible duplicates
+// @Description Get existing ideas whose title or content is similar to this idea (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param limit query int false "Maximum number of suggestions (default 5, max 20)"
+// @Success 200 {object} ListDuplicatesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/duplicates [get]
+func (h *IdeaHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "duplicates")
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		value, err := strconv.Atoi(rawLimit)
+		if err != nil || value <= 0 {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = value
+	}
+
+	duplicates, err := h.ideaService.FindDuplicates(r.Context(), ideaID, limit, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := ListDuplicatesResponse{
+		IdeaID:     ideaID.String(),
+		Duplicates: newDuplicateResponses(duplicates),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// MergeIdea handles POST /ideas/{ideaId}/merge requests.
+// AI-hint: Folds the duplicate in the path into target_idea_id; Product Owners only.
+//
+// @Summary Merge a duplicate idea
+// @Description Move a duplicate idea's votes and comments to a canonical idea and redirect the duplicate to it (Product Owner only)
+// @Tags ideas
+// @Accept json
+// @Produce json
+// @Param ideaId path string true "Duplicate idea ID" format(uuid)
+// @Param merge body MergeIdeaRequest true "Merge target"
+// @Success 200 {object} MergeIdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/merge [post]
+func (h *IdeaHandler) MergeIdea(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	sourceIdeaID, ok := parseIdeaSubresourcePath(w, r, "merge")
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var req MergeIdeaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	targetIdeaID, err := uuid.Parse(strings.TrimSpace(req.TargetIdeaID))
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid target idea ID format")
+		return
+	}
+
+	target, merge, err := h.ideaService.MergeIdeas(r.Context(), sourceIdeaID, targetIdeaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only Product Owners can merge ideas")
+		case ideadomain.ErrInvalidIdeaMerge:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "An idea cannot be merged into itself")
+		case ideadomain.ErrMergeAudienceMismatch:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Only ideas visible to the same users can be merged")
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := MergeIdeaResponse{
+		SourceIdeaID:  merge.SourceIdeaID.String(),
+		TargetIdea:    newIdeaResponse(target),
+		MovedVotes:    merge.MovedVotes,
+		MovedComments: merge.MovedComments,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeNotFoundOrMergeRedirect answers a missing idea with a 301 to its canonical idea
+// when it was merged, and with 404 otherwise.
+func (h *IdeaHandler) writeNotFoundOrMergeRedirect(w http.ResponseWriter, r *http.Request, ideaID uuid.UUID, userID string) {
+	merge, err := h.ideaService.GetMergeRedirect(r.Context(), ideaID, userID)
+	if err != nil {
+		if err == ideadomain.ErrMergeNotFound {
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		} else {
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := MergedIdeaResponse{
+		Error:      http.StatusText(http.StatusMovedPermanently),
+		Message:    "Idea was merged into another idea",
+		MergedInto: merge.TargetIdeaID.String(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/ideas/"+merge.TargetIdeaID.String())
+	w.WriteHeader(http.StatusMovedPermanently)
+	json.NewEncoder(w).Encode(response)
+}
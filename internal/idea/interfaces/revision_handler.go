@@ -0,0 +1,285 @@
+package interfaces
+
+import (
+	"encoding/json"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/web"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// currentRevisionKeyword selects the idea's live title/content in diff requests.
+const currentRevisionKeyword = "current"
+
+// RevisionResponse represents a stored idea revision in API responses.
+// AI-hint: Title and content are the text as it was before edit Number;
+// editor_user_id is omitted when the editing user has since been deleted.
+type RevisionResponse struct {
+	Number       int    `json:"number" example:"1"`
+	Title        string `json:"title" example:"Add dark mode"`
+	Content      string `json:"content" example:"Support a dark color scheme."`
+	EditorUserID string `json:"editor_user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// ListRevisionsResponse represents an idea's revision history.
+type ListRevisionsResponse struct {
+	IdeaID    string             `json:"idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Revisions []RevisionResponse `json:"revisions"`
+}
+
+// DiffLineResponse represents a single line of a revision diff.
+// AI-hint: Op is one of "equal", "insert", or "delete".
+type DiffLineResponse struct {
+	Op   string `json:"op" example:"insert"`
+	Text string `json:"text" example:"Support a dark color scheme."`
+}
+
+// RevisionDiffResponse represents a line-based diff between two versions of an idea.
+// AI-hint: Revision number 0 stands for the idea's current title and content.
+type RevisionDiffResponse struct {
+	IdeaID  string             `json:"idea_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From    int                `json:"from" example:"1"`
+	To      int                `json:"to" example:"0"`
+	Title   []DiffLineResponse `json:"title"`
+	Content []DiffLineResponse `json:"content"`
+}
+
+// newRevisionResponse converts a domain revision into its API representation.
+func newRevisionResponse(revision *ideadomain.Revision) RevisionResponse {
+	response := RevisionResponse{
+		Number:    revision.Number,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		CreatedAt: revision.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if revision.EditorUserID != nil {
+		response.EditorUserID = revision.EditorUserID.String()
+	}
+	return response
+}
+
+// newDiffLineResponses converts domain diff lines into their API representation.
+func newDiffLineResponses(lines []ideadomain.DiffLine) []DiffLineResponse {
+	responses := make([]DiffLineResponse, 0, len(lines))
+	for _, line := range lines {
+		responses = append(responses, DiffLineResponse{Op: string(line.Op), Text: line.Text})
+	}
+	return responses
+}
+
+// ListRevisions handles GET /ideas/{ideaId}/revisions requests.
+// AI-hint: Returns the idea's edit history, oldest revision first.
+//
+// @Summary List idea revisions
+// @Description Get the revision history of an idea; each revision holds the title and content as they were before that edit (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Success 200 {object} ListRevisionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/revisions [get]
+func (h *IdeaHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	ideaID, ok := parseIdeaSubresourcePath(w, r, "revisions")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.ideaService.ListRevisions(r.Context(), ideaID, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := ListRevisionsResponse{
+		IdeaID:    ideaID.String(),
+		Revisions: make([]RevisionResponse, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		response.Revisions = append(response.Revisions, newRevisionResponse(revision))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DiffRevisions handles GET /ideas/{ideaId}/revisions/diff requests.
+// AI-hint: Both from and to accept a revision number or "current"; to defaults to "current".
+//
+// @Summary Diff idea revisions
+// @Description Compare two versions of an idea line by line (authentication required)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param from query string true "Revision number or 'current'"
+// @Param to query string false "Revision number or 'current'" default(current)
+// @Success 200 {object} RevisionDiffResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/revisions/diff [get]
+func (h *IdeaHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "ideas" || pathParts[2] != "revisions" || pathParts[3] != "diff" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return
+	}
+
+	ideaID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+		return
+	}
+
+	params := r.URL.Query()
+	if params.Get("from") == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "from is required")
+		return
+	}
+	from, ok := parseRevisionNumber(params.Get("from"))
+	if !ok {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid from revision")
+		return
+	}
+	to := ideadomain.CurrentRevisionNumber
+	if raw := params.Get("to"); raw != "" {
+		if to, ok = parseRevisionNumber(raw); !ok {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid to revision")
+			return
+		}
+	}
+
+	diff, err := h.ideaService.DiffRevisions(r.Context(), ideaID, from, to, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrRevisionNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Revision not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	response := RevisionDiffResponse{
+		IdeaID:  ideaID.String(),
+		From:    diff.From.Number,
+		To:      diff.To.Number,
+		Title:   newDiffLineResponses(diff.Title),
+		Content: newDiffLineResponses(diff.Content),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RestoreRevision handles POST /ideas/{ideaId}/revisions/{revision}/restore requests.
+// AI-hint: Only the creator or a Product Owner may restore. The replaced text is kept
+// as a new revision, so a restore can itself be undone.
+//
+// @Summary Restore an idea revision
+// @Description Set an idea's title and content back to those of a previous revision (creator or Product Owner only)
+// @Tags ideas
+// @Produce json
+// @Param ideaId path string true "Idea ID" format(uuid)
+// @Param revision path int true "Revision number"
+// @Success 200 {object} IdeaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /ideas/{ideaId}/revisions/{revision}/restore [post]
+func (h *IdeaHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[0] != "ideas" || pathParts[2] != "revisions" || pathParts[4] != "restore" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return
+	}
+
+	ideaID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid idea ID format")
+		return
+	}
+
+	number, err := strconv.Atoi(pathParts[3])
+	if err != nil || number <= 0 {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid revision number")
+		return
+	}
+
+	restoredIdea, err := h.ideaService.RestoreRevision(r.Context(), ideaID, number, userID)
+	if err != nil {
+		switch err {
+		case ideadomain.ErrCreatorNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		case ideadomain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Only the creator or a Product Owner can restore this idea")
+		case ideadomain.ErrIdeaNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Idea not found")
+		case ideadomain.ErrRevisionNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Revision not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newIdeaResponse(restoredIdea))
+}
+
+// parseRevisionNumber parses a revision number or the "current" keyword.
+func parseRevisionNumber(raw string) (int, bool) {
+	if strings.EqualFold(raw, currentRevisionKeyword) {
+		return ideadomain.CurrentRevisionNumber, true
+	}
+
+	number, err := strconv.Atoi(raw)
+	if err != nil || number < 0 {
+		return 0, false
+	}
+	return number, true
+}
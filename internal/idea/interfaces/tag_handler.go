@@ -0,0 +1,340 @@
+package interfaces
+
+import (
+	"encoding/json"
+	ideaapp "feedback_hub_2/internal/idea/application"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/web"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TagHandler handles HTTP requests for tag management operations.
+// AI-hint: HTTP transport layer for idea tags. Reading is open to authenticated users;
+// create, rename, merge, and delete are restricted to Product Owners and Super Users.
+type TagHandler struct {
+	ideaService *ideaapp.IdeaApplicationService
+}
+
+// NewTagHandler creates a new TagHandler instance.
+// AI-hint: Factory method for tag handler with dependency injection of idea service.
+func NewTagHandler(ideaService *ideaapp.IdeaApplicationService) *TagHandler {
+	return &TagHandler{
+		ideaService: ideaService,
+	}
+}
+
+// TagRequest represents the request body for creating or renaming a tag.
+type TagRequest struct {
+	Name string `json:"name" example:"Mobile App"`
+}
+
+// MergeTagRequest represents the request body for merging a tag into another tag.
+// AI-hint: The tag in the URL path is the source and is deleted after the merge.
+type MergeTagRequest struct {
+	TargetTagID string `json:"target_tag_id" example:"550e8400-e29b-41d4-a716-446655440010"`
+}
+
+// TagResponse represents a tag in API responses.
+// AI-hint: DTO for tag API responses with consistent structure.
+type TagResponse struct {
+	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	Name      string `json:"name" example:"Mobile App"`
+	IdeaCount int    `json:"idea_count" example:"7"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// newTagResponse converts a domain tag into its API representation.
+func newTagResponse(tag *ideadomain.Tag) TagResponse {
+	return TagResponse{
+		ID:        tag.ID.String(),
+		Name:      tag.Name,
+		IdeaCount: tag.IdeaCount,
+		CreatedAt: tag.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: tag.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ListTags handles GET /tags requests.
+// AI-hint: Returns all tags ordered by name with their idea counts.
+//
+// @Summary List tags
+// @Description Get all idea tags with the number of ideas carrying each (authentication required)
+// @Tags tags
+// @Produce json
+// @Success 200 {array} TagResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags [get]
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.ideaService.ListTags(r.Context())
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	responses := make([]TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		responses = append(responses, newTagResponse(tag))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responses)
+}
+
+// GetTag handles GET /tags/{tagId} requests.
+//
+// @Summary Get a tag by ID
+// @Description Get an idea tag by its ID (authentication required)
+// @Tags tags
+// @Produce json
+// @Param tagId path string true "Tag ID" format(uuid)
+// @Success 200 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags/{tagId} [get]
+func (h *TagHandler) GetTag(w http.ResponseWriter, r *http.Request) {
+	tagID, ok := parseTagPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	tag, err := h.ideaService.GetTag(r.Context(), tagID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTagResponse(tag))
+}
+
+// CreateTag handles POST /tags requests.
+// AI-hint: Tag names are unique case-insensitively; duplicates return 409.
+//
+// @Summary Create a tag
+// @Description Create a new idea tag (Product Owner or Super User only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body TagRequest true "Tag creation request"
+// @Success 201 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag, err := h.ideaService.CreateTag(r.Context(), req.Name, userID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newTagResponse(tag))
+}
+
+// RenameTag handles PUT /tags/{tagId} requests.
+// AI-hint: The new name is visible on every tagged idea at once.
+//
+// @Summary Rename a tag
+// @Description Rename an idea tag on all tagged ideas (Product Owner or Super User only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tagId path string true "Tag ID" format(uuid)
+// @Param tag body TagRequest true "Tag rename request"
+// @Success 200 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags/{tagId} [put]
+func (h *TagHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tagID, ok := parseTagPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag, err := h.ideaService.RenameTag(r.Context(), tagID, req.Name, userID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTagResponse(tag))
+}
+
+// MergeTag handles POST /tags/{tagId}/merge requests.
+// AI-hint: Moves every idea from the path tag onto the target tag, then deletes the path tag.
+//
+// @Summary Merge a tag into another tag
+// @Description Retag all ideas from this tag with the target tag and delete this tag (Product Owner or Super User only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tagId path string true "Source tag ID" format(uuid)
+// @Param merge body MergeTagRequest true "Merge target"
+// @Success 200 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags/{tagId}/merge [post]
+func (h *TagHandler) MergeTag(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	sourceTagID, ok := parseTagPath(w, r, "merge")
+	if !ok {
+		return
+	}
+
+	var req MergeTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	targetTagID, err := uuid.Parse(req.TargetTagID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid target_tag_id format")
+		return
+	}
+
+	tag, err := h.ideaService.MergeTags(r.Context(), sourceTagID, targetTagID, userID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTagResponse(tag))
+}
+
+// DeleteTag handles DELETE /tags/{tagId} requests.
+// AI-hint: Removes the tag from every idea carrying it.
+//
+// @Summary Delete a tag
+// @Description Delete an idea tag and remove it from all ideas (Product Owner or Super User only)
+// @Tags tags
+// @Param tagId path string true "Tag ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /tags/{tagId} [delete]
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tagID, ok := parseTagPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := h.ideaService.DeleteTag(r.Context(), tagID, userID); err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	// Return 204 No Content for successful deletion
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTagPath extracts the tag ID from /tags/{tagId} or, when action is set,
+// /tags/{tagId}/{action} URL paths.
+// AI-hint: Writes a 400 response and returns false when the path or ID is malformed.
+func parseTagPath(w http.ResponseWriter, r *http.Request, action string) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	expectedParts := 2
+	if action != "" {
+		expectedParts = 3
+	}
+	if len(pathParts) != expectedParts || pathParts[0] != "tags" || (action != "" && pathParts[2] != action) {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid URL path")
+		return uuid.Nil, false
+	}
+
+	tagID, err := uuid.Parse(pathParts[1])
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid tag ID format")
+		return uuid.Nil, false
+	}
+
+	return tagID, true
+}
+
+// writeTagError maps tag management errors onto HTTP responses.
+func writeTagError(w http.ResponseWriter, err error) {
+	switch err {
+	case ideadomain.ErrCreatorNotFound:
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+	case ideadomain.ErrUnauthorized:
+		web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions to manage tags")
+	case ideadomain.ErrInvalidTagName:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Tag name must be between 1 and 50 characters")
+	case ideadomain.ErrInvalidTagMerge:
+		web.WriteErrorResponse(w, http.StatusBadRequest, "A tag cannot be merged into itself")
+	case ideadomain.ErrTagNotFound:
+		web.WriteErrorResponse(w, http.StatusNotFound, "Tag not found")
+	case ideadomain.ErrTagAlreadyExists:
+		web.WriteErrorResponse(w, http.StatusConflict, "A tag with this name already exists")
+	default:
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
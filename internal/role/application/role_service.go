@@ -191,6 +191,90 @@ func (s *RoleService) DeleteRole(ctx interface{}, id string, deletedByUserID str
 	return s.roleRepo.Delete(context, id)
 }
 
+// GrantPermission grants a permission to a role with authorization checks.
+// AI-hint: Requires the role update permission, and granters can only hand out permissions
+// they hold themselves. Publishes role.updated so cached role permissions are reloaded.
+func (s *RoleService) GrantPermission(ctx interface{}, id, permission string, grantedByUserID string) (*roledomain.Role, error) {
+	context := ctx.(context.Context)
+
+	existingRole, err := s.authorizePermissionChange(context, id, permission, grantedByUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := existingRole.GrantPermission(permission)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return existingRole, nil
+	}
+
+	if err := s.roleRepo.GrantPermission(context, existingRole.ID, permission); err != nil {
+		return nil, err
+	}
+
+	s.publishRoleUpdated(context, existingRole)
+	return existingRole, nil
+}
+
+// RevokePermission revokes a permission from a role with authorization checks.
+// AI-hint: Same rules as GrantPermission; revoking a permission the role lacks is a no-op.
+func (s *RoleService) RevokePermission(ctx interface{}, id, permission string, revokedByUserID string) (*roledomain.Role, error) {
+	context := ctx.(context.Context)
+
+	existingRole, err := s.authorizePermissionChange(context, id, permission, revokedByUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := existingRole.RevokePermission(permission)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return existingRole, nil
+	}
+
+	if err := s.roleRepo.RevokePermission(context, existingRole.ID, permission); err != nil {
+		return nil, err
+	}
+
+	s.publishRoleUpdated(context, existingRole)
+	return existingRole, nil
+}
+
+// authorizePermissionChange checks that the user may grant or revoke the permission and loads the role.
+// AI-hint: Unknown permission names return auth.ErrUnknownPermission.
+func (s *RoleService) authorizePermissionChange(ctx context.Context, id, permission, userID string) (*roledomain.Role, error) {
+	userCtx, err := s.getUserContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.authService.CanPerform(userCtx, auth.PermissionUpdateRole) {
+		return nil, roledomain.ErrUnauthorized
+	}
+
+	parsed, err := auth.ParsePermission(permission)
+	if err != nil {
+		return nil, err
+	}
+	if !s.authService.CanPerform(userCtx, parsed) {
+		return nil, roledomain.ErrUnauthorized
+	}
+
+	return s.roleRepo.GetByID(ctx, id)
+}
+
+// publishRoleUpdated publishes a role.updated event, logging failures.
+func (s *RoleService) publishRoleUpdated(ctx context.Context, updatedRole *roledomain.Role) {
+	roleUpdatedEvent := events.NewRoleUpdatedEvent(updatedRole.ID, updatedRole.Name, 2) // Assuming version 2 for now
+	if err := s.eventPublisher.PublishEvent(ctx, roleUpdatedEvent); err != nil {
+		log.Printf("Warning: failed to publish role updated event: %v", err)
+	}
+}
+
 // ListRoles retrieves all roles with authorization checks.
 // AI-hint: Role listing with read permission validation.
 func (s *RoleService) ListRoles(ctx interface{}) ([]*roledomain.Role, error) {
@@ -217,6 +301,9 @@ func (s *RoleService) EnsurePredefinedRoles(ctx interface{}) error {
 			if err != nil {
 				return err
 			}
+			for _, permission := range auth.DefaultRolePermissions[roleName] {
+				newRole.Permissions = append(newRole.Permissions, string(permission))
+			}
 
 			if err := s.roleRepo.Create(context, newRole); err != nil {
 				return err
@@ -224,7 +311,14 @@ func (s *RoleService) EnsurePredefinedRoles(ctx interface{}) error {
 		}
 	}
 
-	return nil
+	// Give predefined roles created before permissions were stored their defaults, once
+	defaults := make(map[string][]string, len(auth.DefaultRolePermissions))
+	for roleName, permissions := range auth.DefaultRolePermissions {
+		for _, permission := range permissions {
+			defaults[roleName] = append(defaults[roleName], string(permission))
+		}
+	}
+	return s.roleRepo.SeedPermissions(context, defaults)
 }
 
 // GetRoleByName retrieves a role by name.
@@ -241,6 +335,11 @@ func (s *RoleService) getUserContext(ctx context.Context, userID string) (*auth.
 		return nil, auth.ErrInvalidContext
 	}
 
+	// Reuse the caller resolved by the auth middleware
+	if userCtx, ok := auth.UserContextFor(ctx, userID); ok {
+		return userCtx, nil
+	}
+
 	// Get the user to determine their role
 	user, err := s.userQueries.GetUserByID(ctx, userID)
 	if err != nil {
@@ -254,7 +353,10 @@ func (s *RoleService) getUserContext(ctx context.Context, userID string) (*auth.
 	}
 
 	return &auth.UserContext{
-		UserID:   userID,
-		RoleName: userRole.Name,
+		UserID:      userID,
+		RoleID:      userRole.ID,
+		RoleName:    userRole.Name,
+		Scopes:      auth.ScopesFromContext(ctx),
+		MFAVerified: auth.MFAVerifiedFromContext(ctx),
 	}, nil
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"sort"
 	"strings"
 	"time"
 )
@@ -22,11 +23,14 @@ var PredefinedRoles = []string{
 // Role represents a role in the system with specific permissions.
 // AI-hint: Core domain entity for role-based access control.
 // Enforces business rules around role naming and Super User protection.
+// Permissions is a sorted set of permission names (see auth.Permission); the Super User
+// role implicitly has every permission and stores none.
 type Role struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // NewRole creates a new Role with validation.
@@ -47,10 +51,11 @@ func NewRole(id, name string) (*Role, error) {
 
 	now := time.Now()
 	return &Role{
-		ID:        id,
-		Name:      name,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:          id,
+		Name:        name,
+		Permissions: []string{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}, nil
 }
 
@@ -76,6 +81,47 @@ func (r *Role) UpdateName(name string) error {
 	return nil
 }
 
+// HasPermission reports whether the role was granted the permission.
+// AI-hint: Only looks at stored permissions; callers treat the Super User role separately.
+func (r *Role) HasPermission(permission string) bool {
+	i := sort.SearchStrings(r.Permissions, permission)
+	return i < len(r.Permissions) && r.Permissions[i] == permission
+}
+
+// GrantPermission adds a permission to the role and reports whether it was missing.
+// AI-hint: The Super User role cannot be changed; it always has every permission.
+func (r *Role) GrantPermission(permission string) (bool, error) {
+	if r.IsSuperUser() {
+		return false, ErrCannotModifySuperUserRole
+	}
+	if permission == "" {
+		return false, ErrInvalidRoleData
+	}
+	if r.HasPermission(permission) {
+		return false, nil
+	}
+
+	r.Permissions = append(r.Permissions, permission)
+	sort.Strings(r.Permissions)
+	r.UpdatedAt = time.Now()
+	return true, nil
+}
+
+// RevokePermission removes a permission from the role and reports whether it was granted.
+func (r *Role) RevokePermission(permission string) (bool, error) {
+	if r.IsSuperUser() {
+		return false, ErrCannotModifySuperUserRole
+	}
+	if !r.HasPermission(permission) {
+		return false, nil
+	}
+
+	i := sort.SearchStrings(r.Permissions, permission)
+	r.Permissions = append(r.Permissions[:i], r.Permissions[i+1:]...)
+	r.UpdatedAt = time.Now()
+	return true, nil
+}
+
 // IsSuperUser returns true if this role is the Super User role.
 // AI-hint: Business logic to identify the special Super User role.
 func (r *Role) IsSuperUser() bool {
@@ -90,7 +136,9 @@ func (r *Role) CanBeDeleted() bool {
 
 // Repository defines the interface for role persistence operations.
 // AI-hint: Repository pattern interface for dependency inversion.
-// Keeps domain logic independent of persistence implementation.
+// Keeps domain logic independent of persistence implementation. SeedPermissions
+// grants default permissions by role name only the first time it is called, so
+// permissions revoked later stay revoked.
 type Repository interface {
 	Create(ctx interface{}, role *Role) error
 	GetByID(ctx interface{}, id string) (*Role, error)
@@ -99,6 +147,9 @@ type Repository interface {
 	Delete(ctx interface{}, id string) error
 	List(ctx interface{}) ([]*Role, error)
 	Exists(ctx interface{}, name string) (bool, error)
+	GrantPermission(ctx interface{}, roleID, permission string) error
+	RevokePermission(ctx interface{}, roleID, permission string) error
+	SeedPermissions(ctx interface{}, permissionsByRoleName map[string][]string) error
 }
 
 // Service defines the business operations for role management.
@@ -111,6 +162,8 @@ type Service interface {
 	DeleteRole(ctx interface{}, id string, deletedByUserID string) error
 	ListRoles(ctx interface{}) ([]*Role, error)
 	EnsurePredefinedRoles(ctx interface{}) error
+	GrantPermission(ctx interface{}, id, permission string, grantedByUserID string) (*Role, error)
+	RevokePermission(ctx interface{}, id, permission string, revokedByUserID string) (*Role, error)
 }
 
 // Error types for the role domain.
@@ -102,3 +102,41 @@ func TestPredefinedRoles(t *testing.T) {
 		assert.Len(t, PredefinedRoles, 3)
 	})
 }
+
+func TestRole_Permissions(t *testing.T) {
+	t.Run("grant and revoke keep a sorted set", func(t *testing.T) {
+		role, _ := NewRole("123", "Moderator")
+
+		changed, err := role.GrantPermission("tag:delete")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = role.GrantPermission("idea:merge")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = role.GrantPermission("idea:merge")
+		assert.NoError(t, err)
+		assert.False(t, changed, "granting twice changes nothing")
+
+		assert.Equal(t, []string{"idea:merge", "tag:delete"}, role.Permissions)
+		assert.True(t, role.HasPermission("idea:merge"))
+
+		changed, err = role.RevokePermission("idea:merge")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = role.RevokePermission("idea:merge")
+		assert.NoError(t, err)
+		assert.False(t, changed)
+
+		assert.Equal(t, []string{"tag:delete"}, role.Permissions)
+		assert.False(t, role.HasPermission("idea:merge"))
+	})
+
+	t.Run("Super User role cannot be changed", func(t *testing.T) {
+		role, _ := NewRole("123", SuperUserRoleName)
+
+		_, err := role.GrantPermission("idea:merge")
+		assert.Equal(t, ErrCannotModifySuperUserRole, err)
+		_, err = role.RevokePermission("idea:merge")
+		assert.Equal(t, ErrCannotModifySuperUserRole, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	roleapp "feedback_hub_2/internal/role/application"
 	"feedback_hub_2/internal/role/domain"
+	"feedback_hub_2/internal/shared/auth"
 	"feedback_hub_2/internal/shared/web"
 	"net/http"
 	"strings"
@@ -33,10 +34,11 @@ type CreateRoleRequest struct {
 // RoleResponse represents the response body for role operations.
 // AI-hint: DTO for role API responses with consistent structure.
 type RoleResponse struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
 }
 
 // UpdateRoleRequest represents the request body for updating a role.
@@ -60,7 +62,7 @@ type UpdateRoleRequest struct {
 // @Failure 403 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /roles [post]
 func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -97,12 +99,7 @@ func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the created role
-	response := RoleResponse{
-		ID:        newRole.ID,
-		Name:      newRole.Name,
-		CreatedAt: newRole.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: newRole.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	response := toRoleResponse(newRole)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -121,7 +118,7 @@ func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /roles/{id} [get]
 func (h *RoleHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -151,12 +148,7 @@ func (h *RoleHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the role
-	response := RoleResponse{
-		ID:        foundRole.ID,
-		Name:      foundRole.Name,
-		CreatedAt: foundRole.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: foundRole.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	response := toRoleResponse(foundRole)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -172,7 +164,7 @@ func (h *RoleHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} RoleResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /roles [get]
 func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -192,12 +184,7 @@ func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 	// Convert to response format
 	var responses []RoleResponse
 	for _, role := range roles {
-		responses = append(responses, RoleResponse{
-			ID:        role.ID,
-			Name:      role.Name,
-			CreatedAt: role.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: role.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		})
+		responses = append(responses, toRoleResponse(role))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -221,7 +208,7 @@ func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /roles/{id} [put]
 func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -269,12 +256,7 @@ func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the updated role
-	response := RoleResponse{
-		ID:        updatedRole.ID,
-		Name:      updatedRole.Name,
-		CreatedAt: updatedRole.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: updatedRole.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	response := toRoleResponse(updatedRole)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -293,7 +275,7 @@ func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /roles/{id} [delete]
 func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -331,3 +313,99 @@ func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 	// Return 204 No Content for successful deletion
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GrantPermission handles PUT /roles/{id}/permissions/{permission} requests.
+// AI-hint: Idempotent; granters must hold the permission themselves. Members of the role
+// get the permission on their next request once cached permissions are reloaded.
+//
+// @Summary Grant a permission to a role
+// @Description Grant a permission such as idea:merge to a role
+// @Tags roles
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param permission path string true "Permission name"
+// @Success 200 {object} RoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /roles/{id}/permissions/{permission} [put]
+func (h *RoleHandler) GrantPermission(w http.ResponseWriter, r *http.Request) {
+	h.changePermission(w, r, h.roleService.GrantPermission)
+}
+
+// RevokePermission handles DELETE /roles/{id}/permissions/{permission} requests.
+// AI-hint: Idempotent; the same rules as GrantPermission apply.
+//
+// @Summary Revoke a permission from a role
+// @Description Revoke a permission from a role
+// @Tags roles
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param permission path string true "Permission name"
+// @Success 200 {object} RoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /roles/{id}/permissions/{permission} [delete]
+func (h *RoleHandler) RevokePermission(w http.ResponseWriter, r *http.Request) {
+	h.changePermission(w, r, h.roleService.RevokePermission)
+}
+
+// changePermission runs a grant or revoke for the role and permission named in the path.
+func (h *RoleHandler) changePermission(w http.ResponseWriter, r *http.Request, change func(ctx interface{}, id, permission, userID string) (*domain.Role, error)) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	roleID := web.ExtractIDFromPath(r.URL.Path, "/roles/")
+	_, permission, _ := strings.Cut(r.URL.Path, "/permissions/")
+	if roleID == "" || permission == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Role ID and permission are required")
+		return
+	}
+
+	updatedRole, err := change(r.Context(), roleID, permission, userID)
+	if err != nil {
+		switch err {
+		case auth.ErrUnknownPermission:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Unknown permission")
+		case domain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
+		case domain.ErrRoleNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Role not found")
+		case domain.ErrCannotModifySuperUserRole:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Cannot modify Super User role")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toRoleResponse(updatedRole))
+}
+
+// toRoleResponse converts a role into its API representation.
+func toRoleResponse(role *domain.Role) RoleResponse {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   role.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
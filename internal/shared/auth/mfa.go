@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"feedback_hub_2/internal/role/domain"
+)
+
+// AuthorizationPolicy holds deployment-specific authorization rules.
+// AI-hint: The zero value imposes no extra rules, which is what NewAuthorizationService uses.
+type AuthorizationPolicy struct {
+	// MFARequiredRoles lists roles whose members can only use their permissions from a
+	// login that completed two-factor authentication.
+	MFARequiredRoles []string
+
+	// Permissions resolves what each role may do; nil uses DefaultRolePermissions.
+	Permissions PermissionResolver
+}
+
+// PrivilegedRoles returns the roles that can manage users and roles.
+// AI-hint: Used to build the policy for REQUIRE_MFA_FOR_PRIVILEGED_ROLES.
+func PrivilegedRoles() []string {
+	return []string{domain.SuperUserRoleName, "Product Owner"}
+}
+
+// mfaContextKey is the context key for the two-factor authentication flag.
+type mfaContextKey struct{}
+
+// WithMFAVerified records that the request's credentials completed two-factor authentication.
+// AI-hint: Set by the auth middleware; application services copy it into UserContext.MFAVerified.
+func WithMFAVerified(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mfaContextKey{}, true)
+}
+
+// MFAVerifiedFromContext reports whether WithMFAVerified was applied to the context.
+func MFAVerifiedFromContext(ctx context.Context) bool {
+	verified, _ := ctx.Value(mfaContextKey{}).(bool)
+	return verified
+}
@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"feedback_hub_2/internal/role/domain"
+	"log"
 )
 
 // Permission represents an action that can be performed in the system.
@@ -26,69 +28,195 @@ const (
 	// Special permissions
 	PermissionCreateAnyUser     Permission = "user:create_any"         // Can create users with any role
 	PermissionCreateContributor Permission = "user:create_contributor" // Can only create contributor users
+	PermissionUnlockUser        Permission = "user:unlock"             // Can lift login lockouts
+	PermissionAssignRole        Permission = "user:assign_role"        // Can change the role of other users
+
+	// Idea moderation permissions
+	PermissionUpdateAnyIdea Permission = "idea:update_any" // Can update ideas created by other users
+	PermissionDeleteAnyIdea Permission = "idea:delete_any" // Can delete ideas created by other users
+	PermissionReadAnyIdea   Permission = "idea:read_any"   // Can see private and role-restricted ideas
+
+	// Idea workflow permissions
+	PermissionChangeIdeaStatus Permission = "idea:change_status" // Can move ideas through the status workflow
+	PermissionMergeIdeas       Permission = "idea:merge"         // Can fold duplicate ideas into a canonical idea
+
+	// Tag management permissions
+	PermissionCreateTag Permission = "tag:create"
+	PermissionUpdateTag Permission = "tag:update" // Rename tags and merge them into other tags
+	PermissionDeleteTag Permission = "tag:delete"
+
+	// Comment moderation permissions
+	PermissionUpdateAnyComment Permission = "comment:update_any" // Can edit comments written by other users
+	PermissionDeleteAnyComment Permission = "comment:delete_any" // Can delete comments written by other users
+
+	// API access permissions, used as coarse scopes for personal access tokens
+	PermissionAPIRead  Permission = "api:read"  // Can make read-only API requests
+	PermissionAPIWrite Permission = "api:write" // Can make API requests that create, change, or delete data
 )
 
+// AllPermissions lists every permission known to the system.
+// AI-hint: Used to validate permission names coming from clients, e.g. token scopes.
+var AllPermissions = []Permission{
+	PermissionCreateRole, PermissionReadRole, PermissionUpdateRole, PermissionDeleteRole,
+	PermissionCreateUser, PermissionReadUser, PermissionUpdateUser, PermissionDeleteUser,
+	PermissionCreateAnyUser, PermissionCreateContributor, PermissionUnlockUser, PermissionAssignRole,
+	PermissionUpdateAnyIdea, PermissionDeleteAnyIdea, PermissionReadAnyIdea,
+	PermissionChangeIdeaStatus, PermissionMergeIdeas,
+	PermissionCreateTag, PermissionUpdateTag, PermissionDeleteTag,
+	PermissionUpdateAnyComment, PermissionDeleteAnyComment,
+	PermissionAPIRead, PermissionAPIWrite,
+}
+
+// ParsePermission converts a permission name into a Permission.
+// AI-hint: Returns ErrUnknownPermission for names not listed in AllPermissions.
+func ParsePermission(name string) (Permission, error) {
+	for _, permission := range AllPermissions {
+		if string(permission) == name {
+			return permission, nil
+		}
+	}
+	return "", ErrUnknownPermission
+}
+
 // UserContext represents the current user making a request.
 // AI-hint: Security context carrying user identity and role information
-// for authorization decisions throughout the system.
+// for authorization decisions throughout the system. Scopes is nil for session
+// logins; requests made with a personal access token are limited to its scopes.
+// MFAVerified is true when the login completed two-factor authentication.
+// Permissions holds the role's permissions once resolved (see ResolvePermissions);
+// while nil, CanPerform looks them up by role name. RoleID is used for idea visibility.
 type UserContext struct {
-	UserID   string
-	RoleName string
+	UserID      string
+	RoleID      string
+	RoleName    string
+	Scopes      []Permission
+	MFAVerified bool
+	Permissions []Permission
+}
+
+// HasScope reports whether the request's credentials allow the permission.
+// AI-hint: Always true for session logins; the role still has to grant the permission.
+func (c *UserContext) HasScope(permission Permission) bool {
+	if c.Scopes == nil {
+		return true
+	}
+	for _, scope := range c.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+	return false
 }
 
 // AuthorizationService handles permission checking based on roles.
 // AI-hint: Domain service for authorization logic that encapsulates
 // business rules about who can perform what actions.
-type AuthorizationService struct{}
+type AuthorizationService struct {
+	policy AuthorizationPolicy
+}
 
 // NewAuthorizationService creates a new authorization service.
-// AI-hint: Factory method for the authorization service.
+// AI-hint: Factory method for the authorization service without extra policy rules;
+// roles have their DefaultRolePermissions.
 func NewAuthorizationService() *AuthorizationService {
-	return &AuthorizationService{}
+	return NewAuthorizationServiceWithPolicy(AuthorizationPolicy{})
+}
+
+// NewAuthorizationServiceWithPolicy creates an authorization service that also enforces the policy.
+// AI-hint: Used by the server to apply deployment settings such as mandatory 2FA and to
+// read role permissions from the database.
+func NewAuthorizationServiceWithPolicy(policy AuthorizationPolicy) *AuthorizationService {
+	if policy.Permissions == nil {
+		policy.Permissions = defaultPermissionResolver{}
+	}
+	return &AuthorizationService{policy: policy}
+}
+
+// RequiresMFA reports whether members of the role must use two-factor authentication.
+func (s *AuthorizationService) RequiresMFA(roleName string) bool {
+	for _, role := range s.policy.MFARequiredRoles {
+		if role == roleName {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvePermissions loads the permissions of the user's role into userCtx.Permissions.
+// AI-hint: Called once per request by the auth middleware. These are the role's
+// permissions; CanPerform still applies token scopes and the 2FA policy on top.
+func (s *AuthorizationService) ResolvePermissions(ctx context.Context, userCtx *UserContext) error {
+	if userCtx.RoleName == domain.SuperUserRoleName {
+		userCtx.Permissions = append([]Permission{}, AllPermissions...)
+		return nil
+	}
+
+	permissions, err := s.policy.Permissions.PermissionsForRole(ctx, userCtx.RoleName)
+	if err != nil {
+		return err
+	}
+	userCtx.Permissions = append([]Permission{}, permissions...)
+	return nil
 }
 
 // CanPerform checks if a user has permission to perform a specific action.
 // AI-hint: Core authorization method implementing role-based access control.
-// Returns true if the user's role allows the requested permission.
+// Returns true if the user's role allows the requested permission. Role permissions
+// come from the policy's PermissionResolver; lookup failures deny access.
 func (s *AuthorizationService) CanPerform(userCtx *UserContext, permission Permission) bool {
 	if userCtx == nil {
 		return false
 	}
 
+	// Access tokens can never exceed their scopes
+	if !userCtx.HasScope(permission) {
+		return false
+	}
+
+	// Roles with mandatory 2FA get no permissions until it was completed
+	if s.RequiresMFA(userCtx.RoleName) && !userCtx.MFAVerified {
+		return false
+	}
+
 	// Super User can do anything
 	if userCtx.RoleName == domain.SuperUserRoleName {
 		return true
 	}
 
-	switch userCtx.RoleName {
-	case "Product Owner":
-		return s.canProductOwnerPerform(permission)
-	case "Contributor":
-		return s.canContributorPerform(permission)
-	default:
-		return false
-	}
+	return s.roleHasPermission(userCtx, permission)
 }
 
 // CanCreateUserWithRole checks if a user can create another user with a specific role.
 // AI-hint: Specialized authorization check for user creation with role assignment.
-// Implements business rule that Product Owners can only create Contributors.
+// Besides user:create, the role needs user:create_any for any target role, or
+// user:create_contributor for Contributors (by default, Product Owners only create Contributors).
 func (s *AuthorizationService) CanCreateUserWithRole(userCtx *UserContext, targetRoleName string) bool {
 	if userCtx == nil {
 		return false
 	}
 
+	// Access tokens need the user:create scope
+	if !userCtx.HasScope(PermissionCreateUser) {
+		return false
+	}
+
+	// Roles with mandatory 2FA get no permissions until it was completed
+	if s.RequiresMFA(userCtx.RoleName) && !userCtx.MFAVerified {
+		return false
+	}
+
 	// Super User can create users with any role
 	if userCtx.RoleName == domain.SuperUserRoleName {
 		return true
 	}
 
-	// Product Owner can only create Contributors
-	if userCtx.RoleName == "Product Owner" && targetRoleName == "Contributor" {
+	if !s.roleHasPermission(userCtx, PermissionCreateUser) {
+		return false
+	}
+	if s.roleHasPermission(userCtx, PermissionCreateAnyUser) {
 		return true
 	}
-
-	return false
+	return targetRoleName == "Contributor" && s.roleHasPermission(userCtx, PermissionCreateContributor)
 }
 
 // ValidateUserContext ensures the user context is valid for authorization.
@@ -106,30 +234,25 @@ func (s *AuthorizationService) ValidateUserContext(userCtx *UserContext) error {
 	return nil
 }
 
-// canProductOwnerPerform defines what Product Owners can do.
-// AI-hint: Business rules for Product Owner role permissions.
-func (s *AuthorizationService) canProductOwnerPerform(permission Permission) bool {
-	switch permission {
-	case PermissionReadRole, PermissionReadUser:
-		return true
-	case PermissionCreateUser, PermissionUpdateUser, PermissionDeleteUser:
-		return true
-	case PermissionCreateContributor:
-		return true
-	default:
-		return false
+// roleHasPermission reports whether the user's role was granted the permission.
+// AI-hint: Uses the resolved Permissions when present. Fails closed: if the permissions
+// cannot be loaded, nothing is allowed.
+func (s *AuthorizationService) roleHasPermission(userCtx *UserContext, permission Permission) bool {
+	permissions := userCtx.Permissions
+	if permissions == nil {
+		var err error
+		permissions, err = s.policy.Permissions.PermissionsForRole(context.Background(), userCtx.RoleName)
+		if err != nil {
+			log.Printf("Error: failed to load permissions of role %s: %v", userCtx.RoleName, err)
+			return false
+		}
 	}
-}
-
-// canContributorPerform defines what Contributors can do.
-// AI-hint: Business rules for Contributor role permissions.
-func (s *AuthorizationService) canContributorPerform(permission Permission) bool {
-	switch permission {
-	case PermissionReadRole, PermissionReadUser:
-		return true
-	default:
-		return false
+	for _, granted := range permissions {
+		if granted == permission {
+			return true
+		}
 	}
+	return false
 }
 
 // Error types for the auth domain.
@@ -138,4 +261,6 @@ var (
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrForbidden      = errors.New("forbidden")
 	ErrInvalidContext = errors.New("invalid user context")
+
+	ErrUnknownPermission = errors.New("unknown permission")
 )
@@ -19,7 +19,11 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 		permissions := []Permission{
 			PermissionCreateRole, PermissionReadRole, PermissionUpdateRole, PermissionDeleteRole,
 			PermissionCreateUser, PermissionReadUser, PermissionUpdateUser, PermissionDeleteUser,
-			PermissionCreateAnyUser, PermissionCreateContributor,
+			PermissionCreateAnyUser, PermissionCreateContributor, PermissionUnlockUser, PermissionAssignRole,
+			PermissionUpdateAnyIdea, PermissionDeleteAnyIdea, PermissionReadAnyIdea, PermissionChangeIdeaStatus, PermissionMergeIdeas,
+			PermissionCreateTag, PermissionUpdateTag, PermissionDeleteTag,
+			PermissionUpdateAnyComment, PermissionDeleteAnyComment,
+			PermissionAPIRead, PermissionAPIWrite,
 		}
 
 		for _, permission := range permissions {
@@ -39,6 +43,10 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 			PermissionReadRole, PermissionReadUser,
 			PermissionCreateUser, PermissionUpdateUser, PermissionDeleteUser,
 			PermissionCreateContributor,
+			PermissionUpdateAnyIdea, PermissionDeleteAnyIdea, PermissionChangeIdeaStatus, PermissionMergeIdeas,
+			PermissionCreateTag, PermissionUpdateTag, PermissionDeleteTag,
+			PermissionUpdateAnyComment, PermissionDeleteAnyComment,
+			PermissionAPIRead, PermissionAPIWrite,
 		}
 
 		for _, permission := range allowedPermissions {
@@ -49,7 +57,7 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 		// Cannot perform these actions
 		deniedPermissions := []Permission{
 			PermissionCreateRole, PermissionUpdateRole, PermissionDeleteRole,
-			PermissionCreateAnyUser,
+			PermissionCreateAnyUser, PermissionUnlockUser, PermissionAssignRole, PermissionReadAnyIdea,
 		}
 
 		for _, permission := range deniedPermissions {
@@ -67,6 +75,7 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 		// Can perform these actions
 		allowedPermissions := []Permission{
 			PermissionReadRole, PermissionReadUser,
+			PermissionAPIRead, PermissionAPIWrite,
 		}
 
 		for _, permission := range allowedPermissions {
@@ -78,7 +87,10 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 		deniedPermissions := []Permission{
 			PermissionCreateRole, PermissionUpdateRole, PermissionDeleteRole,
 			PermissionCreateUser, PermissionUpdateUser, PermissionDeleteUser,
-			PermissionCreateAnyUser, PermissionCreateContributor,
+			PermissionCreateAnyUser, PermissionCreateContributor, PermissionUnlockUser, PermissionAssignRole,
+			PermissionUpdateAnyIdea, PermissionDeleteAnyIdea, PermissionReadAnyIdea, PermissionChangeIdeaStatus, PermissionMergeIdeas,
+			PermissionCreateTag, PermissionUpdateTag, PermissionDeleteTag,
+			PermissionUpdateAnyComment, PermissionDeleteAnyComment,
 		}
 
 		for _, permission := range deniedPermissions {
@@ -103,6 +115,85 @@ func TestAuthorizationService_CanPerform(t *testing.T) {
 	})
 }
 
+func TestAuthorizationService_CanPerformWithScopes(t *testing.T) {
+	service := NewAuthorizationService()
+
+	t.Run("scopes narrow the role's permissions", func(t *testing.T) {
+		userCtx := &UserContext{
+			UserID:   "po-user-id",
+			RoleName: "Product Owner",
+			Scopes:   []Permission{PermissionAPIRead, PermissionChangeIdeaStatus},
+		}
+
+		assert.True(t, service.CanPerform(userCtx, PermissionChangeIdeaStatus))
+		assert.False(t, service.CanPerform(userCtx, PermissionMergeIdeas), "scope was not granted")
+		assert.False(t, service.CanCreateUserWithRole(userCtx, "Contributor"), "user:create scope was not granted")
+	})
+
+	t.Run("scopes never widen the role's permissions", func(t *testing.T) {
+		userCtx := &UserContext{
+			UserID:   "contributor-user-id",
+			RoleName: "Contributor",
+			Scopes:   []Permission{PermissionMergeIdeas},
+		}
+
+		assert.False(t, service.CanPerform(userCtx, PermissionMergeIdeas))
+	})
+
+	t.Run("Super User tokens are limited too", func(t *testing.T) {
+		userCtx := &UserContext{
+			UserID:   "super-user-id",
+			RoleName: domain.SuperUserRoleName,
+			Scopes:   []Permission{},
+		}
+
+		assert.False(t, service.CanPerform(userCtx, PermissionReadUser))
+	})
+}
+
+func TestAuthorizationService_MFAPolicy(t *testing.T) {
+	service := NewAuthorizationServiceWithPolicy(AuthorizationPolicy{MFARequiredRoles: PrivilegedRoles()})
+
+	t.Run("privileged roles need a 2FA login", func(t *testing.T) {
+		for _, roleName := range []string{domain.SuperUserRoleName, "Product Owner"} {
+			userCtx := &UserContext{UserID: "user-id", RoleName: roleName}
+
+			assert.True(t, service.RequiresMFA(roleName))
+			assert.False(t, service.CanPerform(userCtx, PermissionReadUser), "%s without 2FA", roleName)
+			assert.False(t, service.CanCreateUserWithRole(userCtx, "Contributor"), "%s without 2FA", roleName)
+
+			userCtx.MFAVerified = true
+			assert.True(t, service.CanPerform(userCtx, PermissionReadUser), "%s with 2FA", roleName)
+			assert.True(t, service.CanCreateUserWithRole(userCtx, "Contributor"), "%s with 2FA", roleName)
+		}
+	})
+
+	t.Run("other roles are unaffected", func(t *testing.T) {
+		userCtx := &UserContext{UserID: "contributor-user-id", RoleName: "Contributor"}
+
+		assert.False(t, service.RequiresMFA("Contributor"))
+		assert.True(t, service.CanPerform(userCtx, PermissionReadUser))
+	})
+
+	t.Run("no policy by default", func(t *testing.T) {
+		userCtx := &UserContext{UserID: "super-user-id", RoleName: domain.SuperUserRoleName}
+
+		assert.False(t, NewAuthorizationService().RequiresMFA(domain.SuperUserRoleName))
+		assert.True(t, NewAuthorizationService().CanPerform(userCtx, PermissionDeleteRole))
+	})
+}
+
+func TestParsePermission(t *testing.T) {
+	for _, permission := range AllPermissions {
+		parsed, err := ParsePermission(string(permission))
+		assert.NoError(t, err)
+		assert.Equal(t, permission, parsed)
+	}
+
+	_, err := ParsePermission("idea:launch_rockets")
+	assert.ErrorIs(t, err, ErrUnknownPermission)
+}
+
 func TestAuthorizationService_CanCreateUserWithRole(t *testing.T) {
 	service := NewAuthorizationService()
 
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultRolePermissions lists the permissions the predefined roles start with.
+// AI-hint: Seeded into role_permissions when the roles are created, and used directly by
+// an AuthorizationService without a PermissionResolver. The Super User role is missing
+// on purpose: it always has every permission.
+var DefaultRolePermissions = map[string][]Permission{
+	"Product Owner": {
+		PermissionReadRole, PermissionReadUser,
+		PermissionCreateUser, PermissionUpdateUser, PermissionDeleteUser,
+		PermissionCreateContributor,
+		PermissionUpdateAnyIdea, PermissionDeleteAnyIdea,
+		PermissionChangeIdeaStatus, PermissionMergeIdeas,
+		PermissionCreateTag, PermissionUpdateTag, PermissionDeleteTag,
+		PermissionUpdateAnyComment, PermissionDeleteAnyComment,
+		PermissionAPIRead, PermissionAPIWrite,
+	},
+	"Contributor": {
+		PermissionReadRole, PermissionReadUser,
+		PermissionAPIRead, PermissionAPIWrite,
+	},
+}
+
+// PermissionResolver looks up the permissions granted to a role.
+// AI-hint: Lets AuthorizationService read role permissions from storage; unknown roles
+// resolve to no permissions rather than an error.
+type PermissionResolver interface {
+	PermissionsForRole(ctx context.Context, roleName string) ([]Permission, error)
+}
+
+// RolePermissionSource loads the permission names stored for a role.
+// AI-hint: Implemented by queries.RoleQueryService; returns an empty list for unknown roles.
+type RolePermissionSource interface {
+	GetRolePermissions(ctx context.Context, roleName string) ([]string, error)
+}
+
+// PermissionInvalidatingEvents lists the event types after which cached role
+// permissions must be reloaded.
+var PermissionInvalidatingEvents = []string{"role.updated", "role.deleted", "user.role_updated"}
+
+// CachedPermissionResolver caches role permissions loaded from a RolePermissionSource.
+// AI-hint: Entries are dropped by Invalidate (subscribe it to PermissionInvalidatingEvents)
+// and expire after the TTL, which bounds staleness when another instance changed a role.
+type CachedPermissionResolver struct {
+	source  RolePermissionSource
+	ttl     time.Duration
+	mutex   sync.RWMutex
+	entries map[string]cachedRolePermissions
+}
+
+type cachedRolePermissions struct {
+	permissions []Permission
+	loadedAt    time.Time
+}
+
+// NewCachedPermissionResolver creates a resolver that caches each role's permissions for ttl.
+func NewCachedPermissionResolver(source RolePermissionSource, ttl time.Duration) *CachedPermissionResolver {
+	return &CachedPermissionResolver{
+		source:  source,
+		ttl:     ttl,
+		entries: make(map[string]cachedRolePermissions),
+	}
+}
+
+// PermissionsForRole returns the role's permissions, loading them on a cache miss.
+// AI-hint: Stored names that are no longer valid permissions are skipped.
+func (r *CachedPermissionResolver) PermissionsForRole(ctx context.Context, roleName string) ([]Permission, error) {
+	r.mutex.RLock()
+	entry, ok := r.entries[roleName]
+	r.mutex.RUnlock()
+	if ok && time.Since(entry.loadedAt) < r.ttl {
+		return entry.permissions, nil
+	}
+
+	names, err := r.source.GetRolePermissions(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]Permission, 0, len(names))
+	for _, name := range names {
+		permission, err := ParsePermission(name)
+		if err != nil {
+			log.Printf("Warning: role %s has unknown permission %q", roleName, name)
+			continue
+		}
+		permissions = append(permissions, permission)
+	}
+
+	r.mutex.Lock()
+	r.entries[roleName] = cachedRolePermissions{permissions: permissions, loadedAt: time.Now()}
+	r.mutex.Unlock()
+
+	return permissions, nil
+}
+
+// Invalidate drops every cached entry.
+func (r *CachedPermissionResolver) Invalidate() {
+	r.mutex.Lock()
+	r.entries = make(map[string]cachedRolePermissions)
+	r.mutex.Unlock()
+}
+
+// defaultPermissionResolver serves DefaultRolePermissions.
+type defaultPermissionResolver struct{}
+
+// PermissionsForRole returns the role's default permissions.
+func (defaultPermissionResolver) PermissionsForRole(ctx context.Context, roleName string) ([]Permission, error) {
+	return DefaultRolePermissions[roleName], nil
+}
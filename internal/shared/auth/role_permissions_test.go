@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePermissionSource serves role permissions from a map and counts lookups.
+type fakePermissionSource struct {
+	permissions map[string][]string
+	err         error
+	calls       int
+}
+
+func (f *fakePermissionSource) GetRolePermissions(ctx context.Context, roleName string) ([]string, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.permissions[roleName], nil
+}
+
+func TestAuthorizationService_ResolvedPermissions(t *testing.T) {
+	source := &fakePermissionSource{permissions: map[string][]string{
+		"Moderator":     {"idea:merge", "comment:delete_any", "retired:permission"},
+		"Product Owner": {"user:create"},
+	}}
+	resolver := NewCachedPermissionResolver(source, time.Minute)
+	service := NewAuthorizationServiceWithPolicy(AuthorizationPolicy{Permissions: resolver})
+
+	t.Run("custom roles get their stored permissions", func(t *testing.T) {
+		moderator := &UserContext{UserID: "moderator-id", RoleName: "Moderator"}
+
+		assert.True(t, service.CanPerform(moderator, PermissionMergeIdeas))
+		assert.True(t, service.CanPerform(moderator, PermissionDeleteAnyComment))
+		assert.False(t, service.CanPerform(moderator, PermissionDeleteTag))
+	})
+
+	t.Run("stored permissions replace the defaults", func(t *testing.T) {
+		productOwner := &UserContext{UserID: "po-id", RoleName: "Product Owner"}
+
+		assert.False(t, service.CanPerform(productOwner, PermissionChangeIdeaStatus))
+		assert.False(t, service.CanCreateUserWithRole(productOwner, "Contributor"), "user:create_contributor was revoked")
+	})
+
+	t.Run("lookups are cached until invalidated", func(t *testing.T) {
+		resolver.Invalidate()
+		source.calls = 0
+		moderator := &UserContext{UserID: "moderator-id", RoleName: "Moderator"}
+
+		service.CanPerform(moderator, PermissionMergeIdeas)
+		service.CanPerform(moderator, PermissionDeleteTag)
+		assert.Equal(t, 1, source.calls)
+
+		source.permissions["Moderator"] = []string{"tag:delete"}
+		assert.False(t, service.CanPerform(moderator, PermissionDeleteTag), "cached entry is still used")
+
+		resolver.Invalidate()
+		assert.True(t, service.CanPerform(moderator, PermissionDeleteTag))
+		assert.Equal(t, 2, source.calls)
+	})
+
+	t.Run("unknown permission names are skipped", func(t *testing.T) {
+		resolver.Invalidate()
+		source.permissions["Moderator"] = []string{"retired:permission", "idea:merge"}
+
+		permissions, err := resolver.PermissionsForRole(context.Background(), "Moderator")
+		require.NoError(t, err)
+		assert.Equal(t, []Permission{PermissionMergeIdeas}, permissions)
+	})
+
+	t.Run("lookup failures deny access", func(t *testing.T) {
+		resolver.Invalidate()
+		source.err = errors.New("database unavailable")
+		defer func() { source.err = nil }()
+
+		assert.False(t, service.CanPerform(&UserContext{UserID: "moderator-id", RoleName: "Moderator"}, PermissionMergeIdeas))
+	})
+}
+
+func TestAuthorizationService_CanCreateUserWithRole_CreateAny(t *testing.T) {
+	source := &fakePermissionSource{permissions: map[string][]string{
+		"Admin": {"user:create", "user:create_any"},
+	}}
+	service := NewAuthorizationServiceWithPolicy(AuthorizationPolicy{Permissions: NewCachedPermissionResolver(source, time.Minute)})
+	admin := &UserContext{UserID: "admin-id", RoleName: "Admin"}
+
+	assert.True(t, service.CanCreateUserWithRole(admin, "Product Owner"))
+	assert.True(t, service.CanCreateUserWithRole(admin, "Contributor"))
+}
@@ -0,0 +1,22 @@
+package auth
+
+import "context"
+
+// scopesContextKey is the context key for access token scopes.
+type scopesContextKey struct{}
+
+// WithScopes records the scopes of the credential that authenticated a request.
+// AI-hint: Set by the auth middleware for personal access tokens only; application
+// services copy them into UserContext.Scopes so CanPerform enforces them.
+func WithScopes(ctx context.Context, scopes []Permission) context.Context {
+	return context.WithValue(ctx, scopesContextKey{}, scopes)
+}
+
+// ScopesFromContext returns the scopes recorded by WithScopes.
+// AI-hint: Returns nil for session logins, which are not limited by scopes.
+func ScopesFromContext(ctx context.Context) []Permission {
+	if scopes, ok := ctx.Value(scopesContextKey{}).([]Permission); ok {
+		return scopes
+	}
+	return nil
+}
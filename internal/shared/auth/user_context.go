@@ -0,0 +1,30 @@
+package auth
+
+import "context"
+
+// userContextKey is the context key for the resolved caller of a request.
+type userContextKey struct{}
+
+// WithUserContext records the resolved caller of a request.
+// AI-hint: Set by the auth middleware once per request, so handlers and application
+// services can authorize without looking up the user and their role again.
+func WithUserContext(ctx context.Context, userCtx *UserContext) context.Context {
+	return context.WithValue(ctx, userContextKey{}, userCtx)
+}
+
+// UserContextFromContext returns the caller recorded by WithUserContext.
+func UserContextFromContext(ctx context.Context) (*UserContext, bool) {
+	userCtx, ok := ctx.Value(userContextKey{}).(*UserContext)
+	return userCtx, ok && userCtx != nil
+}
+
+// UserContextFor returns the recorded caller if it is the given user.
+// AI-hint: Application services use this before falling back to building the context
+// from storage, e.g. when called outside an HTTP request.
+func UserContextFor(ctx context.Context, userID string) (*UserContext, bool) {
+	userCtx, ok := UserContextFromContext(ctx)
+	if !ok || userID == "" || userCtx.UserID != userID {
+		return nil, false
+	}
+	return userCtx, true
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserContextFromContext(t *testing.T) {
+	t.Run("returns the recorded caller", func(t *testing.T) {
+		userCtx := &UserContext{UserID: "user-1", RoleName: "Contributor"}
+		ctx := WithUserContext(context.Background(), userCtx)
+
+		found, ok := UserContextFromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, userCtx, found)
+	})
+
+	t.Run("reports a missing caller", func(t *testing.T) {
+		_, ok := UserContextFromContext(context.Background())
+		assert.False(t, ok)
+
+		_, ok = UserContextFromContext(WithUserContext(context.Background(), nil))
+		assert.False(t, ok)
+	})
+
+	t.Run("UserContextFor only matches the same user", func(t *testing.T) {
+		ctx := WithUserContext(context.Background(), &UserContext{UserID: "user-1", RoleName: "Contributor"})
+
+		_, ok := UserContextFor(ctx, "user-1")
+		assert.True(t, ok)
+		_, ok = UserContextFor(ctx, "user-2")
+		assert.False(t, ok)
+		_, ok = UserContextFor(ctx, "")
+		assert.False(t, ok)
+	})
+}
+
+func TestAuthorizationService_ResolvePermissions(t *testing.T) {
+	source := &fakePermissionSource{permissions: map[string][]string{
+		"Moderator": {"idea:merge"},
+	}}
+	service := NewAuthorizationServiceWithPolicy(AuthorizationPolicy{
+		Permissions: NewCachedPermissionResolver(source, time.Minute),
+	})
+
+	t.Run("loads the role's permissions once", func(t *testing.T) {
+		moderator := &UserContext{UserID: "moderator-id", RoleName: "Moderator"}
+		require.NoError(t, service.ResolvePermissions(context.Background(), moderator))
+		assert.Equal(t, []Permission{PermissionMergeIdeas}, moderator.Permissions)
+
+		calls := source.calls
+		source.permissions["Moderator"] = nil
+		assert.True(t, service.CanPerform(moderator, PermissionMergeIdeas))
+		assert.False(t, service.CanPerform(moderator, PermissionDeleteTag))
+		assert.Equal(t, calls, source.calls, "resolved permissions should not be looked up again")
+	})
+
+	t.Run("Super Users get every permission", func(t *testing.T) {
+		superUser := &UserContext{UserID: "admin-id", RoleName: "Super User"}
+		require.NoError(t, service.ResolvePermissions(context.Background(), superUser))
+		assert.ElementsMatch(t, AllPermissions, superUser.Permissions)
+	})
+
+	t.Run("scopes still limit resolved permissions", func(t *testing.T) {
+		superUser := &UserContext{UserID: "admin-id", RoleName: "Super User", Scopes: []Permission{PermissionAPIRead}}
+		require.NoError(t, service.ResolvePermissions(context.Background(), superUser))
+		assert.False(t, service.CanPerform(superUser, PermissionAssignRole))
+	})
+
+	t.Run("returns lookup errors", func(t *testing.T) {
+		failing := NewAuthorizationServiceWithPolicy(AuthorizationPolicy{
+			Permissions: NewCachedPermissionResolver(&fakePermissionSource{err: errors.New("database down")}, time.Minute),
+		})
+		userCtx := &UserContext{UserID: "moderator-id", RoleName: "Moderator"}
+		assert.Error(t, failing.ResolvePermissions(context.Background(), userCtx))
+		assert.Nil(t, userCtx.Permissions)
+	})
+}
@@ -2,28 +2,38 @@ package bootstrap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	roleapp "feedback_hub_2/internal/role/application"
 	userapp "feedback_hub_2/internal/user/application"
+	userdomain "feedback_hub_2/internal/user/domain"
+	"feedback_hub_2/internal/user/infrastructure/auth"
 )
 
 // BootstrapService handles system initialization and setup.
 // AI-hint: System bootstrap service for initializing required data and first user.
 // Ensures predefined roles exist and creates initial Super User from environment variables.
+// Until setup is complete, it logs a one-time token for POST /setup.
 type BootstrapService struct {
-	roleService *roleapp.RoleService
-	userService *userapp.UserService
+	roleService     *roleapp.RoleService
+	userService     *userapp.UserService
+	setupService    *userapp.SetupService
+	passwordService *auth.PasswordService
 }
 
 // NewBootstrapService creates a new BootstrapService instance.
-// AI-hint: Factory method for bootstrap service with dependency injection.
-func NewBootstrapService(roleService *roleapp.RoleService, userService *userapp.UserService) *BootstrapService {
+// AI-hint: Factory method for bootstrap service with dependency injection. passwordService
+// checks and hashes SUPER_USER_PASSWORD.
+func NewBootstrapService(roleService *roleapp.RoleService, userService *userapp.UserService, setupService *userapp.SetupService, passwordService *auth.PasswordService) *BootstrapService {
 	return &BootstrapService{
-		roleService: roleService,
-		userService: userService,
+		roleService:     roleService,
+		userService:     userService,
+		setupService:    setupService,
+		passwordService: passwordService,
 	}
 }
 
@@ -44,23 +54,41 @@ func (s *BootstrapService) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to create initial Super User: %w", err)
 	}
 
+	// Offer first-run setup until it is complete
+	if err := s.offerSetup(ctx); err != nil {
+		return fmt.Errorf("failed to prepare first-run setup: %w", err)
+	}
+
 	log.Println("System initialization completed successfully")
 	return nil
 }
 
 // createInitialSuperUser creates the initial Super User from environment variables.
 // AI-hint: Initial Super User creation for system bootstrapping.
-// Uses SUPER_USER_EMAIL and SUPER_USER_NAME environment variables.
+// Uses SUPER_USER_EMAIL and SUPER_USER_NAME environment variables, plus SUPER_USER_PASSWORD
+// or SUPER_USER_PASSWORD_FILE for the password. The password is ignored once any Super User
+// has one, so changing it later does not reset anyone's credentials.
 func (s *BootstrapService) createInitialSuperUser(ctx context.Context) error {
 	email := os.Getenv("SUPER_USER_EMAIL")
 	name := os.Getenv("SUPER_USER_NAME")
+	password, err := superUserPassword()
+	if err != nil {
+		return err
+	}
 
 	// If no Super User environment variables are set, skip creation
 	if email == "" || name == "" {
+		if password != "" {
+			return errors.New("SUPER_USER_PASSWORD requires SUPER_USER_EMAIL and SUPER_USER_NAME")
+		}
 		log.Println("No Super User environment variables set (SUPER_USER_EMAIL, SUPER_USER_NAME), skipping initial Super User creation")
 		return nil
 	}
 
+	if password != "" {
+		return s.configureSuperUser(ctx, email, name, password)
+	}
+
 	// Check if a Super User already exists
 	superUserRole, err := s.roleService.GetRoleByName(ctx, "Super User")
 	if err != nil {
@@ -85,3 +113,65 @@ func (s *BootstrapService) createInitialSuperUser(ctx context.Context) error {
 	log.Printf("Created initial Super User: %s (%s) with role %s", superUser.Name, superUser.Email, superUserRole.Name)
 	return nil
 }
+
+// configureSuperUser creates the initial Super User, or sets the password of a
+// passwordless one, with the configured password.
+func (s *BootstrapService) configureSuperUser(ctx context.Context, email, name, password string) error {
+	if err := s.passwordService.ValidatePassword(password, email, name); err != nil {
+		return fmt.Errorf("SUPER_USER_PASSWORD: %w", err)
+	}
+	passwordHash, err := s.passwordService.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	superUser, err := s.setupService.ConfigureSuperUser(ctx, email, name, passwordHash)
+	if err != nil {
+		switch err {
+		case userdomain.ErrSetupCompleted:
+			log.Println("A Super User with a password already exists, ignoring SUPER_USER_PASSWORD")
+			return nil
+		case userdomain.ErrEmailAlreadyExists:
+			return fmt.Errorf("user with email %s already exists and is not a Super User", email)
+		}
+		return err
+	}
+
+	log.Printf("Initial Super User %s (%s) can log in with the configured password", superUser.Name, superUser.Email)
+	return nil
+}
+
+// offerSetup logs a one-time setup token until first-run setup is complete.
+// AI-hint: The token is accepted once by POST /setup. Every start issues a new token and
+// invalidates the previous one; with several instances, use the most recently logged token.
+func (s *BootstrapService) offerSetup(ctx context.Context) error {
+	token, err := s.setupService.IssueToken(ctx)
+	if err != nil {
+		if err == userdomain.ErrSetupCompleted {
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("No Super User can log in yet. Complete first-run setup with POST /setup and this one-time token: %s", token)
+	return nil
+}
+
+// superUserPassword reads the initial Super User password from SUPER_USER_PASSWORD or,
+// for secret mounts, from the file named by SUPER_USER_PASSWORD_FILE.
+func superUserPassword() (string, error) {
+	password := os.Getenv("SUPER_USER_PASSWORD")
+	path := os.Getenv("SUPER_USER_PASSWORD_FILE")
+	if path == "" {
+		return password, nil
+	}
+	if password != "" {
+		return "", errors.New("set either SUPER_USER_PASSWORD or SUPER_USER_PASSWORD_FILE, not both")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read SUPER_USER_PASSWORD_FILE: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,24 @@
+package events
+
+// CommentCreatedEvent represents the event when a comment is posted on an idea.
+// AI-hint: Domain event for comment creation. ParentID is empty for top-level
+// comments, letting subscribers notify either the idea creator or the parent author.
+type CommentCreatedEvent struct {
+	BaseDomainEvent
+	CommentID    string `json:"comment_id"`
+	IdeaID       string `json:"idea_id"`
+	ParentID     string `json:"parent_id,omitempty"`
+	AuthorUserID string `json:"author_user_id"`
+}
+
+// NewCommentCreatedEvent creates a new comment created event.
+// AI-hint: Factory method for comment creation events.
+func NewCommentCreatedEvent(commentID, ideaID, parentID, authorUserID string) *CommentCreatedEvent {
+	return &CommentCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("comment.created", commentID, 1),
+		CommentID:       commentID,
+		IdeaID:          ideaID,
+		ParentID:        parentID,
+		AuthorUserID:    authorUserID,
+	}
+}
@@ -261,3 +261,181 @@ func TestRoleDeletedEvent(t *testing.T) {
 		}
 	})
 }
+
+func TestIdeaVotedEvent(t *testing.T) {
+	t.Run("should create idea voted event with correct values", func(t *testing.T) {
+		ideaID := "idea-123"
+		userID := "user-456"
+		voteCount := 7
+
+		event := NewIdeaVotedEvent(ideaID, userID, voteCount)
+
+		if event.EventType() != "idea.voted" {
+			t.Errorf("expected event type 'idea.voted', got %s", event.EventType())
+		}
+		if event.AggregateID() != ideaID {
+			t.Errorf("expected aggregate ID %s, got %s", ideaID, event.AggregateID())
+		}
+		if event.UserID != userID {
+			t.Errorf("expected user ID %s, got %s", userID, event.UserID)
+		}
+		if event.VoteCount != voteCount {
+			t.Errorf("expected vote count %d, got %d", voteCount, event.VoteCount)
+		}
+	})
+}
+
+func TestIdeaUnvotedEvent(t *testing.T) {
+	t.Run("should create idea unvoted event with correct values", func(t *testing.T) {
+		ideaID := "idea-123"
+		userID := "user-456"
+		voteCount := 6
+
+		event := NewIdeaUnvotedEvent(ideaID, userID, voteCount)
+
+		if event.EventType() != "idea.unvoted" {
+			t.Errorf("expected event type 'idea.unvoted', got %s", event.EventType())
+		}
+		if event.AggregateID() != ideaID {
+			t.Errorf("expected aggregate ID %s, got %s", ideaID, event.AggregateID())
+		}
+		if event.UserID != userID {
+			t.Errorf("expected user ID %s, got %s", userID, event.UserID)
+		}
+		if event.VoteCount != voteCount {
+			t.Errorf("expected vote count %d, got %d", voteCount, event.VoteCount)
+		}
+	})
+}
+
+func TestCommentCreatedEvent(t *testing.T) {
+	t.Run("should create comment created event with correct values", func(t *testing.T) {
+		commentID := "comment-123"
+		ideaID := "idea-456"
+		parentID := "comment-789"
+		authorUserID := "user-012"
+
+		event := NewCommentCreatedEvent(commentID, ideaID, parentID, authorUserID)
+
+		if event.EventType() != "comment.created" {
+			t.Errorf("expected event type 'comment.created', got %s", event.EventType())
+		}
+		if event.AggregateID() != commentID {
+			t.Errorf("expected aggregate ID %s, got %s", commentID, event.AggregateID())
+		}
+		if event.IdeaID != ideaID {
+			t.Errorf("expected idea ID %s, got %s", ideaID, event.IdeaID)
+		}
+		if event.ParentID != parentID {
+			t.Errorf("expected parent ID %s, got %s", parentID, event.ParentID)
+		}
+		if event.AuthorUserID != authorUserID {
+			t.Errorf("expected author user ID %s, got %s", authorUserID, event.AuthorUserID)
+		}
+	})
+}
+
+func TestIdeaStatusChangedEvent(t *testing.T) {
+	t.Run("should create idea status changed event with correct values", func(t *testing.T) {
+		ideaID := "idea-123"
+		changedBy := "user-456"
+
+		event := NewIdeaStatusChangedEvent(ideaID, "in_progress", "declined", "Out of scope", changedBy)
+
+		if event.EventType() != "idea.status_changed" {
+			t.Errorf("expected event type 'idea.status_changed', got %s", event.EventType())
+		}
+		if event.AggregateID() != ideaID {
+			t.Errorf("expected aggregate ID %s, got %s", ideaID, event.AggregateID())
+		}
+		if event.OldStatus != "in_progress" || event.NewStatus != "declined" {
+			t.Errorf("expected in_progress -> declined, got %s -> %s", event.OldStatus, event.NewStatus)
+		}
+		if event.Reason != "Out of scope" {
+			t.Errorf("expected reason 'Out of scope', got %s", event.Reason)
+		}
+		if event.ChangedByUserID != changedBy {
+			t.Errorf("expected changed by %s, got %s", changedBy, event.ChangedByUserID)
+		}
+	})
+}
+
+func TestIdeaMergedEvent(t *testing.T) {
+	t.Run("should create idea merged event with correct values", func(t *testing.T) {
+		sourceID := "idea-123"
+		targetID := "idea-456"
+		mergedBy := "user-789"
+
+		event := NewIdeaMergedEvent(sourceID, targetID, mergedBy, 4, 2)
+
+		if event.EventType() != "idea.merged" {
+			t.Errorf("expected event type 'idea.merged', got %s", event.EventType())
+		}
+		if event.AggregateID() != targetID {
+			t.Errorf("expected aggregate ID %s, got %s", targetID, event.AggregateID())
+		}
+		if event.SourceIdeaID != sourceID || event.TargetIdeaID != targetID {
+			t.Errorf("expected %s -> %s, got %s -> %s", sourceID, targetID, event.SourceIdeaID, event.TargetIdeaID)
+		}
+		if event.MergedByUserID != mergedBy {
+			t.Errorf("expected merged by %s, got %s", mergedBy, event.MergedByUserID)
+		}
+		if event.MovedVotes != 4 || event.MovedComments != 2 {
+			t.Errorf("expected 4 votes and 2 comments moved, got %d and %d", event.MovedVotes, event.MovedComments)
+		}
+	})
+}
+
+func TestUserLockedOutEvent(t *testing.T) {
+	t.Run("should create user locked out event with correct values", func(t *testing.T) {
+		userID := "user-123"
+		lockedUntil := time.Now().Add(15 * time.Minute)
+
+		event := NewUserLockedOutEvent(userID, "test@example.com", 5, lockedUntil)
+
+		if event.EventType() != "user.locked_out" {
+			t.Errorf("expected event type 'user.locked_out', got %s", event.EventType())
+		}
+		if event.AggregateID() != userID {
+			t.Errorf("expected aggregate ID %s, got %s", userID, event.AggregateID())
+		}
+		if event.Email != "test@example.com" || event.Failures != 5 {
+			t.Errorf("expected test@example.com after 5 failures, got %s after %d", event.Email, event.Failures)
+		}
+		if !event.LockedUntil.Equal(lockedUntil) {
+			t.Errorf("expected locked until %v, got %v", lockedUntil, event.LockedUntil)
+		}
+	})
+}
+
+func TestUserUnlockedEvent(t *testing.T) {
+	t.Run("should create user unlocked event with correct values", func(t *testing.T) {
+		event := NewUserUnlockedEvent("user-123", "admin-456")
+
+		if event.EventType() != "user.unlocked" {
+			t.Errorf("expected event type 'user.unlocked', got %s", event.EventType())
+		}
+		if event.AggregateID() != "user-123" {
+			t.Errorf("expected aggregate ID user-123, got %s", event.AggregateID())
+		}
+		if event.UnlockedByUserID != "admin-456" {
+			t.Errorf("expected unlocked by admin-456, got %s", event.UnlockedByUserID)
+		}
+	})
+}
+
+func TestLoginIPLockedOutEvent(t *testing.T) {
+	t.Run("should create IP locked out event with correct values", func(t *testing.T) {
+		event := NewLoginIPLockedOutEvent("203.0.113.7", 20, time.Now().Add(time.Minute))
+
+		if event.EventType() != "auth.ip_locked_out" {
+			t.Errorf("expected event type 'auth.ip_locked_out', got %s", event.EventType())
+		}
+		if event.AggregateID() != "203.0.113.7" || event.IPAddress != "203.0.113.7" {
+			t.Errorf("expected IP 203.0.113.7, got %s", event.IPAddress)
+		}
+		if event.Failures != 20 {
+			t.Errorf("expected 20 failures, got %d", event.Failures)
+		}
+	})
+}
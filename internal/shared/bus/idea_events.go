@@ -0,0 +1,92 @@
+package events
+
+// IdeaVotedEvent represents the event when a user votes for an idea.
+// AI-hint: Domain event for idea voting, carrying the resulting vote count so
+// subscribers (notifications, analytics) don't need to re-query the idea.
+type IdeaVotedEvent struct {
+	BaseDomainEvent
+	IdeaID    string `json:"idea_id"`
+	UserID    string `json:"user_id"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// NewIdeaVotedEvent creates a new idea voted event.
+// AI-hint: Factory method for idea vote events.
+func NewIdeaVotedEvent(ideaID, userID string, voteCount int) *IdeaVotedEvent {
+	return &IdeaVotedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("idea.voted", ideaID, 1),
+		IdeaID:          ideaID,
+		UserID:          userID,
+		VoteCount:       voteCount,
+	}
+}
+
+// IdeaUnvotedEvent represents the event when a user withdraws their vote for an idea.
+// AI-hint: Counterpart of IdeaVotedEvent, carrying the resulting vote count.
+type IdeaUnvotedEvent struct {
+	BaseDomainEvent
+	IdeaID    string `json:"idea_id"`
+	UserID    string `json:"user_id"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// NewIdeaUnvotedEvent creates a new idea unvoted event.
+// AI-hint: Factory method for idea vote withdrawal events.
+func NewIdeaUnvotedEvent(ideaID, userID string, voteCount int) *IdeaUnvotedEvent {
+	return &IdeaUnvotedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("idea.unvoted", ideaID, 1),
+		IdeaID:          ideaID,
+		UserID:          userID,
+		VoteCount:       voteCount,
+	}
+}
+
+// IdeaStatusChangedEvent represents the event when an idea moves through the status workflow.
+// AI-hint: Carries both the old and new status so subscribers can react to specific
+// transitions (e.g. notify voters when an idea is planned or declined).
+type IdeaStatusChangedEvent struct {
+	BaseDomainEvent
+	IdeaID          string `json:"idea_id"`
+	OldStatus       string `json:"old_status"`
+	NewStatus       string `json:"new_status"`
+	Reason          string `json:"reason,omitempty"`
+	ChangedByUserID string `json:"changed_by_user_id"`
+}
+
+// NewIdeaStatusChangedEvent creates a new idea status changed event.
+// AI-hint: Factory method for idea status transition events.
+func NewIdeaStatusChangedEvent(ideaID, oldStatus, newStatus, reason, changedByUserID string) *IdeaStatusChangedEvent {
+	return &IdeaStatusChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("idea.status_changed", ideaID, 1),
+		IdeaID:          ideaID,
+		OldStatus:       oldStatus,
+		NewStatus:       newStatus,
+		Reason:          reason,
+		ChangedByUserID: changedByUserID,
+	}
+}
+
+// IdeaMergedEvent represents the event when a duplicate idea is folded into a canonical idea.
+// AI-hint: Aggregate is the canonical (target) idea. Subscribers can notify the
+// duplicate's voters and followers that the discussion moved.
+type IdeaMergedEvent struct {
+	BaseDomainEvent
+	SourceIdeaID   string `json:"source_idea_id"`
+	TargetIdeaID   string `json:"target_idea_id"`
+	MergedByUserID string `json:"merged_by_user_id"`
+	MovedVotes     int    `json:"moved_votes"`
+	MovedComments  int    `json:"moved_comments"`
+}
+
+// NewIdeaMergedEvent creates a new idea merged event.
+// AI-hint: Factory method for idea merge events.
+func NewIdeaMergedEvent(sourceIdeaID, targetIdeaID, mergedByUserID string, movedVotes, movedComments int) *IdeaMergedEvent {
+	return &IdeaMergedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("idea.merged", targetIdeaID, 1),
+		SourceIdeaID:    sourceIdeaID,
+		TargetIdeaID:    targetIdeaID,
+		MergedByUserID:  mergedByUserID,
+		MovedVotes:      movedVotes,
+		MovedComments:   movedComments,
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import "time"
+
+// UserLockedOutEvent represents the event when an account is locked after failed logins.
+// AI-hint: Security event for alerting and audit logs; the lock lifts by itself at
+// LockedUntil or earlier when an admin unlocks the account.
+type UserLockedOutEvent struct {
+	BaseDomainEvent
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewUserLockedOutEvent creates a new user locked out event.
+// AI-hint: Factory method for account lockout events.
+func NewUserLockedOutEvent(userID, email string, failures int, lockedUntil time.Time) *UserLockedOutEvent {
+	return &UserLockedOutEvent{
+		BaseDomainEvent: NewBaseDomainEvent("user.locked_out", userID, 1),
+		UserID:          userID,
+		Email:           email,
+		Failures:        failures,
+		LockedUntil:     lockedUntil,
+	}
+}
+
+// UserUnlockedEvent represents the event when an admin unlocks a locked account.
+// AI-hint: Security event for audit logs, recording who lifted the lock.
+type UserUnlockedEvent struct {
+	BaseDomainEvent
+	UserID           string `json:"user_id"`
+	UnlockedByUserID string `json:"unlocked_by_user_id"`
+}
+
+// NewUserUnlockedEvent creates a new user unlocked event.
+// AI-hint: Factory method for account unlock events.
+func NewUserUnlockedEvent(userID, unlockedByUserID string) *UserUnlockedEvent {
+	return &UserUnlockedEvent{
+		BaseDomainEvent:  NewBaseDomainEvent("user.unlocked", userID, 1),
+		UserID:           userID,
+		UnlockedByUserID: unlockedByUserID,
+	}
+}
+
+// LoginIPLockedOutEvent represents the event when a client IP is locked after failed logins.
+// AI-hint: Security event; many failures from one IP across accounts usually means
+// password spraying. The aggregate ID is the IP address.
+type LoginIPLockedOutEvent struct {
+	BaseDomainEvent
+	IPAddress   string    `json:"ip_address"`
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewLoginIPLockedOutEvent creates a new login IP locked out event.
+// AI-hint: Factory method for IP lockout events.
+func NewLoginIPLockedOutEvent(ipAddress string, failures int, lockedUntil time.Time) *LoginIPLockedOutEvent {
+	return &LoginIPLockedOutEvent{
+		BaseDomainEvent: NewBaseDomainEvent("auth.ip_locked_out", ipAddress, 1),
+		IPAddress:       ipAddress,
+		Failures:        failures,
+		LockedUntil:     lockedUntil,
+	}
+}
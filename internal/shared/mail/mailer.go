@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email.
+// AI-hint: Kept minimal on purpose; transactional mails such as password resets only
+// need a single recipient, a subject, and a text body.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the interface for sending emails.
+// AI-hint: Application services depend on this interface only. SMTPMailer is used when
+// SMTP is configured; OutboxMailer writes mails to disk or the log for local development and tests.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// formatMessage renders a message as an RFC 5322 email with the given sender.
+// AI-hint: Shared by all mailers so the outbox files look exactly like what SMTP would send.
+// Header values containing line breaks are rejected to prevent header injection.
+func formatMessage(from string, msg Message) ([]byte, error) {
+	if msg.To == "" {
+		return nil, ErrMissingRecipient
+	}
+	for _, value := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes(), nil
+}
+
+// Error types for sending mail.
+// AI-hint: Validation errors returned before anything is sent.
+var (
+	ErrMissingRecipient = errors.New("mail recipient is required")
+	ErrInvalidHeader    = errors.New("mail header contains a line break")
+)
@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMailer stores emails locally instead of sending them.
+// AI-hint: For local development and tests. With a directory every mail is written as
+// an .eml file there; without one the mail is printed to the log.
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+// NewOutboxMailer creates a new OutboxMailer instance.
+// AI-hint: An empty dir selects console output.
+func NewOutboxMailer(dir, from string) *OutboxMailer {
+	return &OutboxMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send writes the message to the outbox directory or the log.
+// AI-hint: Files are named "<UTC timestamp>-<uuid>.eml" so they sort by send time.
+func (m *OutboxMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := formatMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	if m.dir == "" {
+		log.Printf("Outbox mail:\n%s", body)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail outbox: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000Z"), uuid.New().String())
+	// Mails may contain secrets such as reset links, so keep them private
+	if err := os.WriteFile(filepath.Join(m.dir, name), body, 0o600); err != nil {
+		return fmt.Errorf("failed to write mail to outbox: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxMailer_Send(t *testing.T) {
+	t.Run("writes an eml file per message", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "outbox")
+		mailer := NewOutboxMailer(dir, "noreply@example.com")
+
+		err := mailer.Send(context.Background(), Message{
+			To:      "user@example.com",
+			Subject: "Reset your password",
+			Body:    "Open this link:\nhttps://example.com/reset?token=abc",
+		})
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.True(t, strings.HasSuffix(entries[0].Name(), ".eml"))
+
+		content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "From: noreply@example.com\r\n")
+		assert.Contains(t, string(content), "To: user@example.com\r\n")
+		assert.Contains(t, string(content), "Subject: Reset your password\r\n")
+		assert.Contains(t, string(content), "\r\n\r\nOpen this link:\r\nhttps://example.com/reset?token=abc")
+	})
+
+	t.Run("logs to the console without a directory", func(t *testing.T) {
+		mailer := NewOutboxMailer("", "noreply@example.com")
+
+		err := mailer.Send(context.Background(), Message{To: "user@example.com", Subject: "Hi", Body: "Hello"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects header injection", func(t *testing.T) {
+		mailer := NewOutboxMailer(t.TempDir(), "noreply@example.com")
+
+		err := mailer.Send(context.Background(), Message{To: "user@example.com\r\nBcc: victim@example.com", Subject: "Hi", Body: "Hello"})
+
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("requires a recipient", func(t *testing.T) {
+		mailer := NewOutboxMailer(t.TempDir(), "noreply@example.com")
+
+		err := mailer.Send(context.Background(), Message{Subject: "Hi", Body: "Hello"})
+
+		assert.ErrorIs(t, err, ErrMissingRecipient)
+	})
+}
@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccessTokenRepository implements the user.AccessTokenRepository interface using PostgreSQL.
+// AI-hint: Tokens are looked up by the unique hash on every request they authenticate.
+// Deleting a user cascades to their tokens.
+type AccessTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAccessTokenRepository creates a new AccessTokenRepository instance.
+// AI-hint: Factory method for access token repository with dependency injection of DB pool.
+func NewAccessTokenRepository(pool *pgxpool.Pool) *AccessTokenRepository {
+	return &AccessTokenRepository{
+		pool: pool,
+	}
+}
+
+// Create inserts a new personal access token.
+func (r *AccessTokenRepository) Create(ctx interface{}, token *userdomain.PersonalAccessToken) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO personal_access_tokens (id, user_id, name, display_prefix, token_hash, scopes, mfa_verified, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, token.ID, token.UserID, token.Name, token.DisplayPrefix, token.TokenHash, token.Scopes, token.MFAVerified, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the token with the given hash, including expired tokens.
+func (r *AccessTokenRepository) GetByHash(ctx interface{}, tokenHash string) (*userdomain.PersonalAccessToken, error) {
+	context := ctx.(context.Context)
+
+	token, err := scanAccessToken(r.pool.QueryRow(context, `
+		SELECT id, user_id, name, display_prefix, token_hash, scopes, mfa_verified, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE token_hash = $1
+	`, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrAccessTokenNotFound
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListByUserID retrieves a user's tokens, newest first.
+func (r *AccessTokenRepository) ListByUserID(ctx interface{}, userID string) ([]*userdomain.PersonalAccessToken, error) {
+	context := ctx.(context.Context)
+
+	rows, err := r.pool.Query(context, `
+		SELECT id, user_id, name, display_prefix, token_hash, scopes, mfa_verified, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*userdomain.PersonalAccessToken{}
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Delete removes one of the user's tokens.
+// AI-hint: Returns ErrAccessTokenNotFound for tokens owned by someone else.
+func (r *AccessTokenRepository) Delete(ctx interface{}, id, userID string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrAccessTokenNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that a token was just used.
+// AI-hint: Skips the write when the token was already marked within the last minute
+// so busy integrations don't turn every request into an UPDATE.
+func (r *AccessTokenRepository) TouchLastUsed(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		UPDATE personal_access_tokens
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`, id)
+	return err
+}
+
+// scanAccessToken scans a personal_access_tokens row.
+func scanAccessToken(row rowScanner) (*userdomain.PersonalAccessToken, error) {
+	var token userdomain.PersonalAccessToken
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.DisplayPrefix,
+		&token.TokenHash,
+		&token.Scopes,
+		&token.MFAVerified,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -0,0 +1,171 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	commentdomain "feedback_hub_2/internal/comment/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CommentRepository implements the comment.Repository interface using PostgreSQL.
+// AI-hint: Persistence layer implementation for the comment domain using pgx.
+// Replies are stored flat with a parent_id; threading happens in the domain.
+type CommentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommentRepository creates a new CommentRepository instance.
+// AI-hint: Factory method for comment repository with dependency injection of DB pool.
+func NewCommentRepository(pool *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{
+		pool: pool,
+	}
+}
+
+// commentSelectColumns is the column list scanned by scanComment.
+const commentSelectColumns = `id, idea_id, parent_id, author_user_id, content, depth, created_at, updated_at`
+
+// scanComment scans commentSelectColumns into a comment entity.
+// AI-hint: Single mapping point from database row to domain entity.
+func scanComment(row rowScanner) (*commentdomain.Comment, error) {
+	var comment commentdomain.Comment
+	err := row.Scan(
+		&comment.ID,
+		&comment.IdeaID,
+		&comment.ParentID,
+		&comment.AuthorUserID,
+		&comment.Content,
+		&comment.Depth,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Save inserts a new comment into the database.
+// AI-hint: Foreign key violations are reported as missing idea, parent, or author
+// depending on which constraint failed.
+func (r *CommentRepository) Save(ctx interface{}, comment *commentdomain.Comment) error {
+	context := ctx.(context.Context)
+
+	query := `
+		INSERT INTO comments (id, idea_id, parent_id, author_user_id, content, depth, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.pool.Exec(context, query,
+		comment.ID, comment.IdeaID, comment.ParentID, comment.AuthorUserID,
+		comment.Content, comment.Depth, comment.CreatedAt, comment.UpdatedAt,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return foreignKeyCommentError(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindByID retrieves a comment by its ID.
+// AI-hint: Returns ErrCommentNotFound when no row matches.
+func (r *CommentRepository) FindByID(ctx interface{}, id uuid.UUID) (*commentdomain.Comment, error) {
+	context := ctx.(context.Context)
+
+	query := `SELECT ` + commentSelectColumns + ` FROM comments WHERE id = $1`
+
+	comment, err := scanComment(r.pool.QueryRow(context, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, commentdomain.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// FindByIdeaID retrieves all comments on an idea in chronological order.
+// AI-hint: Chronological order lets the domain build threads in a single pass.
+func (r *CommentRepository) FindByIdeaID(ctx interface{}, ideaID uuid.UUID) ([]*commentdomain.Comment, error) {
+	context := ctx.(context.Context)
+
+	query := `SELECT ` + commentSelectColumns + ` FROM comments WHERE idea_id = $1 ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.pool.Query(context, query, ideaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*commentdomain.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// Update updates the content of an existing comment.
+// AI-hint: Only content and updated_at are mutable; thread placement never changes.
+func (r *CommentRepository) Update(ctx interface{}, comment *commentdomain.Comment) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE comments
+		SET content = $1, updated_at = $2
+		WHERE id = $3
+	`, comment.Content, comment.UpdatedAt, comment.ID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return commentdomain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a comment and, through ON DELETE CASCADE, all of its replies.
+// AI-hint: Returns ErrCommentNotFound when no row matches.
+func (r *CommentRepository) Delete(ctx interface{}, id uuid.UUID) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `DELETE FROM comments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return commentdomain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// foreignKeyCommentError maps a comment foreign key violation to a domain error.
+func foreignKeyCommentError(err error) error {
+	switch constraintName(err) {
+	case "comments_parent_id_fkey":
+		return commentdomain.ErrParentNotFound
+	case "comments_author_user_id_fkey":
+		return commentdomain.ErrAuthorNotFound
+	default:
+		return commentdomain.ErrIdeaNotFound
+	}
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EmailVerificationRepository implements the user.EmailVerificationRepository interface using PostgreSQL.
+// AI-hint: Tokens are looked up by their unique hash. Deleting a user cascades to their tokens.
+type EmailVerificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository instance.
+// AI-hint: Factory method for email verification repository with dependency injection of DB pool.
+func NewEmailVerificationRepository(pool *pgxpool.Pool) *EmailVerificationRepository {
+	return &EmailVerificationRepository{
+		pool: pool,
+	}
+}
+
+// Create inserts a new email verification token.
+func (r *EmailVerificationRepository) Create(ctx interface{}, token *userdomain.EmailVerificationToken) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO email_verification_tokens (id, user_id, email, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, token.ID, token.UserID, token.Email, token.TokenHash, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the token with the given hash, including used and expired tokens.
+func (r *EmailVerificationRepository) GetByHash(ctx interface{}, tokenHash string) (*userdomain.EmailVerificationToken, error) {
+	context := ctx.(context.Context)
+
+	var token userdomain.EmailVerificationToken
+	err := r.pool.QueryRow(context, `
+		SELECT id, user_id, email, token_hash, created_at, expires_at, used_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Email,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrEmailVerificationTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// MarkUsed consumes a token.
+// AI-hint: Only an unused token is updated; otherwise ErrInvalidEmailVerificationToken is returned.
+func (r *EmailVerificationRepository) MarkUsed(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvalidEmailVerificationToken
+	}
+
+	return nil
+}
+
+// InvalidateForUser consumes every unused token of a user.
+func (r *EmailVerificationRepository) InvalidateForUser(ctx interface{}, userID string) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL
+	`, userID)
+	return err
+}
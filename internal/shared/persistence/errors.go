@@ -20,6 +20,37 @@ func isForeignKeyViolation(err error) bool {
 	return containsErrorCode(err, "23503")
 }
 
+// isUndefinedColumn checks if the error is caused by a missing column.
+// AI-hint: Used to detect optional schema features (e.g. search_vector) that were not created.
+func isUndefinedColumn(err error) bool {
+	// PostgreSQL undefined column error code is 42703
+	return containsErrorCode(err, "42703")
+}
+
+// isUndefinedObject checks if the error is caused by a missing database object.
+// AI-hint: Raised when a text search configuration or similar object does not exist.
+func isUndefinedObject(err error) bool {
+	// PostgreSQL undefined object error code is 42704
+	return containsErrorCode(err, "42704")
+}
+
+// isUndefinedFunction checks if the error is caused by a missing function or operator.
+// AI-hint: Raised when an optional extension such as pg_trgm is not installed.
+func isUndefinedFunction(err error) bool {
+	// PostgreSQL undefined function error code is 42883
+	return containsErrorCode(err, "42883")
+}
+
+// constraintName returns the name of the constraint that caused a PostgreSQL error.
+// AI-hint: Lets repositories map violations of different foreign keys to distinct domain errors.
+func constraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
+
 // containsErrorCode checks if the error contains a specific PostgreSQL error code.
 // AI-hint: Generic error code checker for PostgreSQL error handling.
 func containsErrorCode(err error, code string) bool {
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExternalIdentityRepository implements the user.ExternalIdentityRepository interface using PostgreSQL.
+// AI-hint: Links identity provider accounts to users; rows cascade with their user.
+type ExternalIdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExternalIdentityRepository creates a new ExternalIdentityRepository instance.
+// AI-hint: Factory method for external identity repository with dependency injection of DB pool.
+func NewExternalIdentityRepository(pool *pgxpool.Pool) *ExternalIdentityRepository {
+	return &ExternalIdentityRepository{
+		pool: pool,
+	}
+}
+
+// GetByProviderSubject retrieves the identity for a provider account.
+func (r *ExternalIdentityRepository) GetByProviderSubject(ctx interface{}, provider, subject string) (*userdomain.ExternalIdentity, error) {
+	context := ctx.(context.Context)
+
+	var identity userdomain.ExternalIdentity
+	err := r.pool.QueryRow(context, `
+		SELECT provider, subject, user_id, email, created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`, provider, subject).Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrExternalIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// Create links a provider account to a user.
+func (r *ExternalIdentityRepository) Create(ctx interface{}, identity *userdomain.ExternalIdentity) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO user_identities (provider, subject, user_id, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, identity.Provider, identity.Subject, identity.UserID, identity.Email, identity.CreatedAt, identity.LastLoginAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return userdomain.ErrExternalIdentityExists
+		}
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RecordLogin stores the time and email address of a login through the provider account.
+func (r *ExternalIdentityRepository) RecordLogin(ctx interface{}, provider, subject, email string, at time.Time) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE user_identities SET email = $3, last_login_at = $4 WHERE provider = $1 AND subject = $2
+	`, provider, subject, email, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrExternalIdentityNotFound
+	}
+
+	return nil
+}
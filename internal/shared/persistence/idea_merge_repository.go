@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdeaMergeRepository implements the idea.MergeRepository interface using PostgreSQL.
+// AI-hint: Merges touch ideas, idea_votes, comments, and idea_merges in one transaction.
+// The idea_merges row doubles as the redirect from the merged duplicate.
+type IdeaMergeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdeaMergeRepository creates a new IdeaMergeRepository instance.
+// AI-hint: Factory method for merge repository with dependency injection of DB pool.
+func NewIdeaMergeRepository(pool *pgxpool.Pool) *IdeaMergeRepository {
+	return &IdeaMergeRepository{
+		pool: pool,
+	}
+}
+
+// Merge folds the source idea into the target idea.
+// AI-hint: Votes move with ON CONFLICT DO NOTHING so users who voted for both ideas
+// keep a single vote; the vote_count triggers update both ideas. Redirects that
+// pointed at the source are re-pointed at the target so chains never form.
+func (r *IdeaMergeRepository) Merge(ctx interface{}, merge *ideadomain.IdeaMerge) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	// Lock both ideas so concurrent merges, votes, or deletes wait for this merge
+	var lockedCount int
+	err = tx.QueryRow(context, `
+		SELECT COUNT(*) FROM (
+			SELECT id FROM ideas WHERE id IN ($1, $2) AND deleted_at IS NULL ORDER BY id FOR UPDATE
+		) locked
+	`, merge.SourceIdeaID, merge.TargetIdeaID).Scan(&lockedCount)
+	if err != nil {
+		return err
+	}
+	if lockedCount != 2 {
+		return ideadomain.ErrIdeaNotFound
+	}
+
+	err = tx.QueryRow(context, `
+		WITH moved AS (
+			INSERT INTO idea_votes (idea_id, user_id, created_at)
+			SELECT $2, user_id, created_at FROM idea_votes WHERE idea_id = $1
+			ON CONFLICT (idea_id, user_id) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM moved
+	`, merge.SourceIdeaID, merge.TargetIdeaID).Scan(&merge.MovedVotes)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `DELETE FROM idea_votes WHERE idea_id = $1`, merge.SourceIdeaID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(context, `UPDATE comments SET idea_id = $2 WHERE idea_id = $1`, merge.SourceIdeaID, merge.TargetIdeaID)
+	if err != nil {
+		return err
+	}
+	merge.MovedComments = int(result.RowsAffected())
+
+	if _, err := tx.Exec(context, `
+		UPDATE idea_merges SET target_idea_id = $2 WHERE target_idea_id = $1
+	`, merge.SourceIdeaID, merge.TargetIdeaID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `
+		INSERT INTO idea_merges (source_idea_id, target_idea_id, merged_by_user_id, merged_at)
+		VALUES ($1, $2, $3, $4)
+	`, merge.SourceIdeaID, merge.TargetIdeaID, merge.MergedByUserID, merge.MergedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `
+		UPDATE ideas SET deleted_at = $2 WHERE id = $1
+	`, merge.SourceIdeaID, merge.MergedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// FindBySourceID retrieves the merge record of a merged duplicate.
+// AI-hint: Returns ErrMergeNotFound when the idea was never merged.
+func (r *IdeaMergeRepository) FindBySourceID(ctx interface{}, sourceIdeaID uuid.UUID) (*ideadomain.IdeaMerge, error) {
+	context := ctx.(context.Context)
+
+	var merge ideadomain.IdeaMerge
+	err := r.pool.QueryRow(context, `
+		SELECT source_idea_id, target_idea_id, merged_by_user_id, merged_at
+		FROM idea_merges
+		WHERE source_idea_id = $1
+	`, sourceIdeaID).Scan(&merge.SourceIdeaID, &merge.TargetIdeaID, &merge.MergedByUserID, &merge.MergedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ideadomain.ErrMergeNotFound
+		}
+		return nil, err
+	}
+
+	return &merge, nil
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	ideadomain "feedback_hub_2/internal/idea/domain"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -25,24 +29,140 @@ func NewIdeaRepository(pool *pgxpool.Pool) *IdeaRepository {
 	}
 }
 
+// ideaSelectColumns is the column list scanned by scanIdea.
+// AI-hint: Keep in sync with scanIdea; every query returning ideas should select these
+// columns first and append any query-specific columns afterwards.
+const ideaSelectColumns = `id, title, content, creator_user_id, status, status_reason, vote_count, archived_at, deleted_at, created_at, updated_at, visibility, visible_to_role_ids, visible_to_user_ids`
+
+// rowScanner is satisfied by both pgx.Row and pgx.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanIdea scans ideaSelectColumns (plus any extra destinations) into an idea entity.
+// AI-hint: Single mapping point from database row to domain entity. A NULL
+// creator_user_id (anonymized idea) maps to uuid.Nil.
+func scanIdea(row rowScanner, extra ...interface{}) (*ideadomain.Idea, error) {
+	var ideaEntity ideadomain.Idea
+	var creatorUserID *uuid.UUID
+	dest := []interface{}{
+		&ideaEntity.ID,
+		&ideaEntity.Title,
+		&ideaEntity.Content,
+		&creatorUserID,
+		&ideaEntity.Status,
+		&ideaEntity.StatusReason,
+		&ideaEntity.VoteCount,
+		&ideaEntity.ArchivedAt,
+		&ideaEntity.DeletedAt,
+		&ideaEntity.CreatedAt,
+		&ideaEntity.UpdatedAt,
+		&ideaEntity.Visibility,
+		&ideaEntity.VisibleToRoleIDs,
+		&ideaEntity.VisibleToUserIDs,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if creatorUserID != nil {
+		ideaEntity.CreatorUserID = *creatorUserID
+	}
+	return &ideaEntity, nil
+}
+
+// argAppender returns a function that appends a query argument and returns its placeholder.
+// AI-hint: Lets conditions built by helpers such as visibilityCondition number their
+// placeholders after the arguments the query already uses.
+func argAppender(args *[]interface{}) func(value interface{}) string {
+	return func(value interface{}) string {
+		*args = append(*args, value)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+}
+
+// visibilityCondition returns the SQL condition matching the ideas the viewer may see.
+// AI-hint: SQL form of Idea.IsVisibleTo; keep the two in sync. Every query returning
+// ideas to a user must include it.
+func visibilityCondition(viewer ideadomain.Viewer, addArg func(value interface{}) string) string {
+	if viewer.SeesAll {
+		return "TRUE"
+	}
+
+	clauses := []string{"visibility = 'public'"}
+	if viewer.UserID != uuid.Nil {
+		userID := addArg(viewer.UserID)
+		clauses = append(clauses,
+			"creator_user_id = "+userID,
+			fmt.Sprintf("(visibility = 'private' AND %s = ANY(visible_to_user_ids))", userID))
+	}
+	if viewer.RoleID != uuid.Nil {
+		clauses = append(clauses, fmt.Sprintf("(visibility = 'roles' AND %s = ANY(visible_to_role_ids))", addArg(viewer.RoleID)))
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+// collectIdeas scans all rows into idea entities and closes the result set.
+func collectIdeas(rows pgx.Rows) ([]*ideadomain.Idea, error) {
+	defer rows.Close()
+
+	var ideas []*ideadomain.Idea
+	for rows.Next() {
+		ideaEntity, err := scanIdea(rows)
+		if err != nil {
+			return nil, err
+		}
+		ideas = append(ideas, ideaEntity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ideas, nil
+}
+
+// collectIdeasWithTags scans all rows into idea entities and loads their tags.
+func (r *IdeaRepository) collectIdeasWithTags(ctx context.Context, rows pgx.Rows) ([]*ideadomain.Idea, error) {
+	ideas, err := collectIdeas(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, ideas); err != nil {
+		return nil, err
+	}
+
+	return ideas, nil
+}
+
 // Save inserts a new idea or updates an existing one in the database.
 // AI-hint: Upsert operation that handles both creation and updates.
-// Uses ON CONFLICT to handle duplicate ID scenarios gracefully.
+// Uses ON CONFLICT to handle duplicate ID scenarios gracefully. The idea's tags are
+// written in the same transaction. The audience is only written on insert; use
+// UpdateAudience to change it.
 func (r *IdeaRepository) Save(ctx interface{}, ideaEntity *ideadomain.Idea) error {
 	context := ctx.(context.Context)
 
 	query := `
-		INSERT INTO ideas (id, title, content, creator_user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO ideas (id, title, content, creator_user_id, status, status_reason, created_at, updated_at,
+			visibility, visible_to_role_ids, visible_to_user_ids)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (id) DO UPDATE SET
 			title = EXCLUDED.title,
 			content = EXCLUDED.content,
 			updated_at = EXCLUDED.updated_at
 	`
 
-	_, err := r.pool.Exec(context, query,
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	_, err = tx.Exec(context, query,
 		ideaEntity.ID, ideaEntity.Title, ideaEntity.Content, ideaEntity.CreatorUserID,
-		ideaEntity.CreatedAt, ideaEntity.UpdatedAt,
+		ideaEntity.Status, ideaEntity.StatusReason, ideaEntity.CreatedAt, ideaEntity.UpdatedAt,
+		ideaEntity.Visibility, ideaEntity.VisibleToRoleIDs, ideaEntity.VisibleToUserIDs,
 	)
 	if err != nil {
 		// Check for foreign key constraint violation (invalid creator_user_id)
@@ -52,17 +172,22 @@ func (r *IdeaRepository) Save(ctx interface{}, ideaEntity *ideadomain.Idea) erro
 		return err
 	}
 
-	return nil
+	if err := replaceIdeaTags(context, tx, ideaEntity.ID, ideaEntity.Tags); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
 }
 
 // Update updates an existing idea in the database.
 // AI-hint: Update operation that modifies existing idea records.
 // Ensures the idea exists before attempting to update and handles validation errors.
-func (r *IdeaRepository) Update(ctx interface{}, ideaEntity *ideadomain.Idea) error {
+// Content, tags, and the optional revision are written in the same transaction.
+func (r *IdeaRepository) Update(ctx interface{}, ideaEntity *ideadomain.Idea, revision *ideadomain.Revision) error {
 	context := ctx.(context.Context)
 
 	// First check if the idea exists
-	_, err := r.FindByID(ctx, ideaEntity.ID)
+	_, err := r.FindByID(ctx, ideaEntity.ID, ideadomain.SystemViewer())
 	if err != nil {
 		return err
 	}
@@ -73,7 +198,13 @@ func (r *IdeaRepository) Update(ctx interface{}, ideaEntity *ideadomain.Idea) er
 		WHERE id = $4
 	`
 
-	result, err := r.pool.Exec(context, query,
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, query,
 		ideaEntity.Title, ideaEntity.Content, ideaEntity.UpdatedAt, ideaEntity.ID,
 	)
 	if err != nil {
@@ -84,30 +215,142 @@ func (r *IdeaRepository) Update(ctx interface{}, ideaEntity *ideadomain.Idea) er
 		return ideadomain.ErrIdeaNotFound
 	}
 
+	if err := replaceIdeaTags(context, tx, ideaEntity.ID, ideaEntity.Tags); err != nil {
+		return err
+	}
+
+	if revision != nil {
+		if err := insertRevision(context, tx, revision); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context)
+}
+
+// insertRevision stores a revision with the next revision number for its idea.
+// AI-hint: Must run after the idea row was updated in the same transaction; the row
+// lock serializes concurrent edits so MAX(number)+1 cannot collide.
+func insertRevision(ctx context.Context, tx pgx.Tx, revision *ideadomain.Revision) error {
+	return tx.QueryRow(ctx, `
+		INSERT INTO idea_revisions (id, idea_id, number, title, content, editor_user_id, created_at)
+		SELECT $1, $2, COALESCE(MAX(number), 0) + 1, $3, $4, $5, $6
+		FROM idea_revisions WHERE idea_id = $2
+		RETURNING number
+	`, revision.ID, revision.IdeaID, revision.Title, revision.Content, revision.EditorUserID, revision.CreatedAt).Scan(&revision.Number)
+}
+
+// replaceIdeaTags replaces the tags attached to an idea within a transaction.
+// AI-hint: Tags are matched by name case-insensitively; names without a matching tag
+// are ignored, so callers must validate tag names beforehand.
+func replaceIdeaTags(ctx context.Context, tx pgx.Tx, ideaID uuid.UUID, tagNames []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM idea_tags WHERE idea_id = $1`, ideaID); err != nil {
+		return err
+	}
+
+	if len(tagNames) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO idea_tags (idea_id, tag_id)
+		SELECT $1, id FROM tags
+		WHERE lower(name) IN (SELECT lower(n) FROM unnest($2::text[]) AS n)
+		ON CONFLICT (idea_id, tag_id) DO NOTHING
+	`, ideaID, tagNames)
+	return err
+}
+
+// attachTags loads the tag names of the given ideas with a single query.
+// AI-hint: Every idea ends up with a non-nil Tags slice, sorted by name.
+func (r *IdeaRepository) attachTags(ctx context.Context, ideas []*ideadomain.Idea) error {
+	if len(ideas) == 0 {
+		return nil
+	}
+
+	ideasByID := make(map[uuid.UUID]*ideadomain.Idea, len(ideas))
+	ids := make([]uuid.UUID, 0, len(ideas))
+	for _, ideaEntity := range ideas {
+		ideaEntity.Tags = []string{}
+		ideasByID[ideaEntity.ID] = ideaEntity
+		ids = append(ids, ideaEntity.ID)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT it.idea_id, t.name
+		FROM idea_tags it
+		JOIN tags t ON t.id = it.tag_id
+		WHERE it.idea_id = ANY($1)
+		ORDER BY lower(t.name)
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ideaID uuid.UUID
+		var name string
+		if err := rows.Scan(&ideaID, &name); err != nil {
+			return err
+		}
+		if ideaEntity, ok := ideasByID[ideaID]; ok {
+			ideaEntity.Tags = append(ideaEntity.Tags, name)
+		}
+	}
+
+	return rows.Err()
+}
+
+// UpdateStatus persists a status transition of an existing idea.
+// AI-hint: Compare-and-set on the previous status so two concurrent transitions cannot
+// both succeed; a lost race is reported as ErrInvalidStatusTransition.
+func (r *IdeaRepository) UpdateStatus(ctx interface{}, ideaEntity *ideadomain.Idea, previous ideadomain.Status) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE ideas
+		SET status = $1, status_reason = $2, updated_at = $3
+		WHERE id = $4 AND status = $5 AND deleted_at IS NULL
+	`, ideaEntity.Status, ideaEntity.StatusReason, ideaEntity.UpdatedAt, ideaEntity.ID, previous)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		// Distinguish a deleted idea from a concurrent status change
+		if _, err := r.FindByID(ctx, ideaEntity.ID, ideadomain.SystemViewer()); err != nil {
+			return err
+		}
+		return ideadomain.ErrInvalidStatusTransition
+	}
+
 	return nil
 }
 
 // FindByID retrieves an idea by its ID.
 // AI-hint: Single idea retrieval with proper error handling for not found cases.
-func (r *IdeaRepository) FindByID(ctx interface{}, id uuid.UUID) (*ideadomain.Idea, error) {
-	context := ctx.(context.Context)
+// Archived ideas are returned; soft-deleted ideas and ideas hidden from the viewer are
+// reported as ErrIdeaNotFound.
+func (r *IdeaRepository) FindByID(ctx interface{}, id uuid.UUID, viewer ideadomain.Viewer) (*ideadomain.Idea, error) {
+	args := []interface{}{id}
+	return r.findOne(ctx.(context.Context), `id = $1 AND deleted_at IS NULL AND `+visibilityCondition(viewer, argAppender(&args)), args...)
+}
 
-	query := `
-		SELECT id, title, content, creator_user_id, created_at, updated_at
-		FROM ideas
-		WHERE id = $1
-	`
+// FindDeletedByID retrieves a soft-deleted idea by its ID.
+// AI-hint: Used to authorize restores; live ideas are reported as ErrIdeaNotFound.
+func (r *IdeaRepository) FindDeletedByID(ctx interface{}, id uuid.UUID, viewer ideadomain.Viewer) (*ideadomain.Idea, error) {
+	args := []interface{}{id}
+	return r.findOne(ctx.(context.Context), `id = $1 AND deleted_at IS NOT NULL AND `+visibilityCondition(viewer, argAppender(&args)), args...)
+}
 
-	var ideaEntity ideadomain.Idea
-	err := r.pool.QueryRow(context, query, id).Scan(
-		&ideaEntity.ID,
-		&ideaEntity.Title,
-		&ideaEntity.Content,
-		&ideaEntity.CreatorUserID,
-		&ideaEntity.CreatedAt,
-		&ideaEntity.UpdatedAt,
-	)
+// findOne retrieves a single idea matching the condition, with its tags.
+func (r *IdeaRepository) findOne(ctx context.Context, condition string, args ...interface{}) (*ideadomain.Idea, error) {
+	query := `SELECT ` + ideaSelectColumns + `
+		FROM ideas
+		WHERE ` + condition
 
+	ideaEntity, err := scanIdea(r.pool.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ideadomain.ErrIdeaNotFound
@@ -115,98 +358,414 @@ func (r *IdeaRepository) FindByID(ctx interface{}, id uuid.UUID) (*ideadomain.Id
 		return nil, err
 	}
 
-	return &ideaEntity, nil
+	if err := r.attachTags(ctx, []*ideadomain.Idea{ideaEntity}); err != nil {
+		return nil, err
+	}
+
+	return ideaEntity, nil
 }
 
 // FindByCreatorUserID retrieves all ideas created by a specific user.
 // AI-hint: Collection retrieval filtered by creator with proper error handling.
-func (r *IdeaRepository) FindByCreatorUserID(ctx interface{}, creatorUserID uuid.UUID) ([]*ideadomain.Idea, error) {
+func (r *IdeaRepository) FindByCreatorUserID(ctx interface{}, creatorUserID uuid.UUID, viewer ideadomain.Viewer) ([]*ideadomain.Idea, error) {
 	context := ctx.(context.Context)
 
-	query := `
-		SELECT id, title, content, creator_user_id, created_at, updated_at
+	args := []interface{}{creatorUserID}
+	query := `SELECT ` + ideaSelectColumns + `
+		FROM ideas
+		WHERE creator_user_id = $1 AND deleted_at IS NULL AND ` + visibilityCondition(viewer, argAppender(&args)) + `
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(context, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collectIdeasWithTags(context, rows)
+}
+
+// FindAll retrieves all ideas from the database.
+// AI-hint: Collection retrieval with proper error handling and ordering.
+func (r *IdeaRepository) FindAll(ctx interface{}, viewer ideadomain.Viewer) ([]*ideadomain.Idea, error) {
+	context := ctx.(context.Context)
+
+	var args []interface{}
+	query := `SELECT ` + ideaSelectColumns + `
 		FROM ideas
-		WHERE creator_user_id = $1
+		WHERE deleted_at IS NULL AND ` + visibilityCondition(viewer, argAppender(&args)) + `
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.pool.Query(context, query, creatorUserID)
+	rows, err := r.pool.Query(context, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collectIdeasWithTags(context, rows)
+}
+
+// ideaSortColumns maps domain sort fields onto ideas table columns.
+// AI-hint: Whitelist that keeps client-controlled sort input out of the SQL text.
+var ideaSortColumns = map[ideadomain.SortField]string{
+	ideadomain.SortByCreatedAt: "created_at",
+	ideadomain.SortByUpdatedAt: "updated_at",
+	ideadomain.SortByVoteCount: "vote_count",
+}
+
+// List retrieves a single page of ideas matching the query using keyset pagination.
+// AI-hint: Fetches limit+1 rows to detect whether another page exists, and orders by
+// (sort column, id) so the cursor comparison is a stable row-value comparison.
+func (r *IdeaRepository) List(ctx interface{}, query ideadomain.ListQuery) (*ideadomain.ListResult, error) {
+	context := ctx.(context.Context)
+
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	sortColumn, ok := ideaSortColumns[query.SortBy]
+	if !ok {
+		return nil, ideadomain.ErrInvalidQuery
+	}
+
+	conditions := []string{"deleted_at IS NULL"}
+	if !query.IncludeArchived {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+	var args []interface{}
+	addArg := argAppender(&args)
+	conditions = append(conditions, visibilityCondition(query.Viewer, addArg))
+
+	if query.CreatorUserID != nil {
+		conditions = append(conditions, "creator_user_id = "+addArg(*query.CreatorUserID))
+	}
+	if query.Status != nil {
+		conditions = append(conditions, "status = "+addArg(*query.Status))
+	}
+	if len(query.Tags) > 0 {
+		tagCondition := fmt.Sprintf(`id IN (
+			SELECT it.idea_id FROM idea_tags it JOIN tags t ON t.id = it.tag_id
+			WHERE lower(t.name) IN (SELECT lower(n) FROM unnest(%s::text[]) AS n)`, addArg(query.Tags))
+		if query.TagMatch == ideadomain.TagMatchAll {
+			tagCondition += " GROUP BY it.idea_id HAVING COUNT(DISTINCT it.tag_id) = " + addArg(len(query.Tags))
+		}
+		conditions = append(conditions, tagCondition+")")
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+addArg(*query.CreatedAfter))
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+addArg(*query.CreatedBefore))
+	}
+
+	if query.Cursor != "" {
+		cursor, err := ideadomain.DecodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		sortValue, err := cursorSortValue(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		comparison := ">"
+		if query.SortDirection == ideadomain.SortDescending {
+			comparison = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			sortColumn, comparison, addArg(sortValue), addArg(cursor.ID)))
+	}
+
+	sqlQuery := `SELECT ` + ideaSelectColumns + `
+		FROM ideas
+		WHERE ` + strings.Join(conditions, " AND ")
+	direction := strings.ToUpper(string(query.SortDirection))
+	sqlQuery += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT %s", sortColumn, direction, direction, addArg(query.Limit+1))
+
+	rows, err := r.pool.Query(context, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ideas, err := collectIdeas(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ideadomain.ListResult{Ideas: ideas}
+	if err := r.attachTags(context, ideas); err != nil {
+		return nil, err
+	}
+	if len(ideas) > query.Limit {
+		result.Ideas = ideas[:query.Limit]
+		nextCursor, err := ideadomain.EncodeCursor(ideadomain.CursorFor(result.Ideas[query.Limit-1], query.SortBy))
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = nextCursor
+	}
+
+	return result, nil
+}
+
+// cursorSortValue converts the cursor's serialized sort key back into a typed SQL value.
+// AI-hint: Each sortable field needs a matching case here so comparisons use the column type.
+func cursorSortValue(cursor *ideadomain.Cursor) (interface{}, error) {
+	switch cursor.SortBy {
+	case ideadomain.SortByCreatedAt, ideadomain.SortByUpdatedAt:
+		value, err := time.Parse(time.RFC3339Nano, cursor.SortValue)
+		if err != nil {
+			return nil, ideadomain.ErrInvalidCursor
+		}
+		return value, nil
+	case ideadomain.SortByVoteCount:
+		value, err := strconv.Atoi(cursor.SortValue)
+		if err != nil {
+			return nil, ideadomain.ErrInvalidCursor
+		}
+		return value, nil
+	default:
+		return nil, ideadomain.ErrInvalidCursor
+	}
+}
+
+// Search performs a ranked full-text search over idea titles and content.
+// AI-hint: Uses the generated search_vector column and GIN index. If the column or the
+// 'english' text search configuration is unavailable, falls back to ILIKE matching.
+// Archived and soft-deleted ideas are never returned.
+func (r *IdeaRepository) Search(ctx interface{}, query ideadomain.SearchQuery) (*ideadomain.SearchResult, error) {
+	context := ctx.(context.Context)
+
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	mode := ideadomain.SearchModeFullText
+	hits, err := r.searchFullText(context, query)
+	if err != nil {
+		if !isUndefinedColumn(err) && !isUndefinedObject(err) {
+			return nil, err
+		}
+		mode = ideadomain.SearchModeILike
+		hits, err = r.searchILike(context, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ideas := make([]*ideadomain.Idea, 0, len(hits))
+	for _, hit := range hits {
+		ideas = append(ideas, hit.Idea)
+	}
+	if err := r.attachTags(context, ideas); err != nil {
+		return nil, err
+	}
+
+	return &ideadomain.SearchResult{Hits: hits, Mode: mode}, nil
+}
+
+// searchFullText runs the tsvector-backed search with ts_rank_cd ranking and ts_headline snippets.
+// AI-hint: websearch_to_tsquery accepts user-friendly syntax (quotes, OR, -term) without raising syntax errors.
+func (r *IdeaRepository) searchFullText(ctx context.Context, query ideadomain.SearchQuery) ([]*ideadomain.SearchHit, error) {
+	headlineOptions := fmt.Sprintf("StartSel=%s, StopSel=%s", ideadomain.HighlightStart, ideadomain.HighlightStop)
+	args := []interface{}{query.Text, headlineOptions, query.Limit, query.Offset}
+	visibility := visibilityCondition(query.Viewer, argAppender(&args))
+
+	sqlQuery := `
+		SELECT ` + ideaSelectColumns + `,
+			ts_rank_cd(search_vector, q) AS rank,
+			ts_headline('english', title, q, $2 || ', HighlightAll=true') AS title_snippet,
+			ts_headline('english', content, q, $2 || ', MaxFragments=2, MaxWords=30, MinWords=10') AS content_snippet
+		FROM ideas, websearch_to_tsquery('english', $1) AS q
+		WHERE search_vector @@ q AND deleted_at IS NULL AND archived_at IS NULL AND ` + visibility + `
+		ORDER BY rank DESC, created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var ideas []*ideadomain.Idea
+	var hits []*ideadomain.SearchHit
 	for rows.Next() {
-		var ideaEntity ideadomain.Idea
-		err := rows.Scan(
-			&ideaEntity.ID,
-			&ideaEntity.Title,
-			&ideaEntity.Content,
-			&ideaEntity.CreatorUserID,
-			&ideaEntity.CreatedAt,
-			&ideaEntity.UpdatedAt,
-		)
+		hit := &ideadomain.SearchHit{}
+		var rank float32
+		ideaEntity, err := scanIdea(rows, &rank, &hit.TitleSnippet, &hit.ContentSnippet)
 		if err != nil {
 			return nil, err
 		}
-		ideas = append(ideas, &ideaEntity)
+		hit.Idea = ideaEntity
+		hit.Rank = float64(rank)
+		hits = append(hits, hit)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return ideas, nil
+	return hits, nil
 }
 
-// FindAll retrieves all ideas from the database.
-// AI-hint: Collection retrieval with proper error handling and ordering.
-func (r *IdeaRepository) FindAll(ctx interface{}) ([]*ideadomain.Idea, error) {
-	context := ctx.(context.Context)
-
-	query := `
-		SELECT id, title, content, creator_user_id, created_at, updated_at
+// searchILike runs a substring search used when full-text search is unavailable.
+// AI-hint: Title matches rank above content-only matches; snippets are built in Go
+// with ideadomain.HighlightSnippet. LIKE wildcards in the term are escaped.
+func (r *IdeaRepository) searchILike(ctx context.Context, query ideadomain.SearchQuery) ([]*ideadomain.SearchHit, error) {
+	pattern := "%" + escapeLikePattern(query.Text) + "%"
+	args := []interface{}{pattern, query.Limit, query.Offset}
+	visibility := visibilityCondition(query.Viewer, argAppender(&args))
+
+	sqlQuery := `
+		SELECT ` + ideaSelectColumns + `,
+			CASE WHEN title ILIKE $1 THEN 1.0 ELSE 0.5 END AS rank
 		FROM ideas
-		ORDER BY created_at DESC
+		WHERE (title ILIKE $1 OR content ILIKE $1) AND deleted_at IS NULL AND archived_at IS NULL AND ` + visibility + `
+		ORDER BY rank DESC, created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(context, query)
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var ideas []*ideadomain.Idea
+	var hits []*ideadomain.SearchHit
 	for rows.Next() {
-		var ideaEntity ideadomain.Idea
-		err := rows.Scan(
-			&ideaEntity.ID,
-			&ideaEntity.Title,
-			&ideaEntity.Content,
-			&ideaEntity.CreatorUserID,
-			&ideaEntity.CreatedAt,
-			&ideaEntity.UpdatedAt,
-		)
+		hit := &ideadomain.SearchHit{}
+		ideaEntity, err := scanIdea(rows, &hit.Rank)
 		if err != nil {
 			return nil, err
 		}
-		ideas = append(ideas, &ideaEntity)
+		hit.Idea = ideaEntity
+		hit.TitleSnippet = ideadomain.HighlightSnippet(ideaEntity.Title, query.Text, len(strings.Fields(ideaEntity.Title)))
+		hit.ContentSnippet = ideadomain.HighlightSnippet(ideaEntity.Content, query.Text, ideadomain.DefaultSnippetWords)
+		hits = append(hits, hit)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return ideas, nil
+	return hits, nil
 }
 
-// Delete removes an idea from the database by its ID.
-// AI-hint: Soft or hard delete operation with proper error handling.
+// FindSimilar returns live ideas whose title or content is trigram-similar to the query.
+// AI-hint: The % operators use the pg_trgm GIN indexes as a prefilter (at the extension's
+// similarity_threshold, 0.3 by default); the query threshold is applied on top. Returns
+// no candidates when pg_trgm is not installed.
+func (r *IdeaRepository) FindSimilar(ctx interface{}, query ideadomain.DuplicateQuery) ([]*ideadomain.DuplicateCandidate, error) {
+	context := ctx.(context.Context)
+
+	query.Normalize()
+
+	args := []interface{}{query.Title, query.Content, query.ExcludeIdeaID, query.Threshold, query.Limit}
+	visibility := visibilityCondition(query.Viewer, argAppender(&args))
+	sqlQuery := `
+		SELECT ` + ideaSelectColumns + `, score
+		FROM (
+			SELECT *, GREATEST(similarity(title, $1), similarity(content, $2)) AS score
+			FROM ideas
+			WHERE (title % $1 OR content % $2) AND id <> $3 AND deleted_at IS NULL AND ` + visibility + `
+		) candidates
+		WHERE score >= $4
+		ORDER BY score DESC, created_at DESC, id DESC
+		LIMIT $5
+	`
+
+	rows, err := r.pool.Query(context, sqlQuery, args...)
+	if err != nil {
+		if isUndefinedFunction(err) {
+			return []*ideadomain.DuplicateCandidate{}, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []*ideadomain.DuplicateCandidate{}
+	ideas := []*ideadomain.Idea{}
+	for rows.Next() {
+		candidate := &ideadomain.DuplicateCandidate{}
+		var score float32
+		ideaEntity, err := scanIdea(rows, &score)
+		if err != nil {
+			return nil, err
+		}
+		candidate.Idea = ideaEntity
+		candidate.Similarity = float64(score)
+		candidates = append(candidates, candidate)
+		ideas = append(ideas, ideaEntity)
+	}
+
+	if err := rows.Err(); err != nil {
+		if isUndefinedFunction(err) {
+			return []*ideadomain.DuplicateCandidate{}, nil
+		}
+		return nil, err
+	}
+
+	if err := r.attachTags(context, ideas); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// escapeLikePattern escapes LIKE/ILIKE wildcard characters in user input.
+// AI-hint: PostgreSQL's default LIKE escape character is backslash.
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+// UpdateAudience persists who can see the idea.
+// AI-hint: Soft-deleted ideas cannot change their audience.
+func (r *IdeaRepository) UpdateAudience(ctx interface{}, ideaEntity *ideadomain.Idea) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE ideas SET visibility = $1, visible_to_role_ids = $2, visible_to_user_ids = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
+	`, ideaEntity.Visibility, ideaEntity.VisibleToRoleIDs, ideaEntity.VisibleToUserIDs, ideaEntity.UpdatedAt, ideaEntity.ID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ideadomain.ErrIdeaNotFound
+	}
+
+	return nil
+}
+
+// UpdateArchived persists the idea's archived state.
+// AI-hint: Soft-deleted ideas cannot be archived or unarchived.
+func (r *IdeaRepository) UpdateArchived(ctx interface{}, ideaEntity *ideadomain.Idea) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE ideas SET archived_at = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`, ideaEntity.ArchivedAt, ideaEntity.UpdatedAt, ideaEntity.ID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ideadomain.ErrIdeaNotFound
+	}
+
+	return nil
+}
+
+// Delete soft-deletes an idea by setting deleted_at.
+// AI-hint: The row, its votes, comments, tags, and revisions are kept until
+// PurgeDeleted removes them, so Restore brings the idea back unchanged.
 func (r *IdeaRepository) Delete(ctx interface{}, id uuid.UUID) error {
 	context := ctx.(context.Context)
 
-	query := `DELETE FROM ideas WHERE id = $1`
+	query := `UPDATE ideas SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.pool.Exec(context, query, id)
 	if err != nil {
@@ -219,3 +778,52 @@ func (r *IdeaRepository) Delete(ctx interface{}, id uuid.UUID) error {
 
 	return nil
 }
+
+// Restore clears deleted_at on a soft-deleted idea.
+// AI-hint: Returns ErrIdeaNotDeleted for live ideas, ErrIdeaMerged for duplicates that
+// were merged into another idea, and ErrIdeaNotFound for unknown or purged ones.
+func (r *IdeaRepository) Restore(ctx interface{}, id uuid.UUID) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE ideas SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM idea_merges m WHERE m.source_idea_id = ideas.id)
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		if _, err := r.FindByID(ctx, id, ideadomain.SystemViewer()); err == nil {
+			return ideadomain.ErrIdeaNotDeleted
+		} else if err != ideadomain.ErrIdeaNotFound {
+			return err
+		}
+
+		var merged bool
+		if err := r.pool.QueryRow(context, `SELECT EXISTS (SELECT 1 FROM idea_merges WHERE source_idea_id = $1)`, id).Scan(&merged); err != nil {
+			return err
+		}
+		if merged {
+			return ideadomain.ErrIdeaMerged
+		}
+		return ideadomain.ErrIdeaNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes ideas soft-deleted before the given time.
+// AI-hint: ON DELETE CASCADE removes their votes, comments, tags, and revisions.
+// Returns the number of purged ideas.
+func (r *IdeaRepository) PurgeDeleted(ctx interface{}, deletedBefore time.Time) (int64, error) {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `DELETE FROM ideas WHERE deleted_at < $1`, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
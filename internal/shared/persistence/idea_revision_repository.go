@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdeaRevisionRepository implements the idea.RevisionRepository interface using PostgreSQL.
+// AI-hint: Read-only; revisions are inserted by IdeaRepository.Update in the same
+// transaction as the edit they record.
+type IdeaRevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdeaRevisionRepository creates a new IdeaRevisionRepository instance.
+// AI-hint: Factory method for revision repository with dependency injection of DB pool.
+func NewIdeaRevisionRepository(pool *pgxpool.Pool) *IdeaRevisionRepository {
+	return &IdeaRevisionRepository{
+		pool: pool,
+	}
+}
+
+// revisionSelectColumns lists the idea_revisions columns in scanRevision order.
+const revisionSelectColumns = `id, idea_id, number, title, content, editor_user_id, created_at`
+
+// scanRevision scans revisionSelectColumns into a revision entity.
+func scanRevision(row rowScanner) (*ideadomain.Revision, error) {
+	var revision ideadomain.Revision
+	if err := row.Scan(
+		&revision.ID,
+		&revision.IdeaID,
+		&revision.Number,
+		&revision.Title,
+		&revision.Content,
+		&revision.EditorUserID,
+		&revision.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// FindByIdeaID retrieves all revisions of an idea, oldest first.
+func (r *IdeaRevisionRepository) FindByIdeaID(ctx interface{}, ideaID uuid.UUID) ([]*ideadomain.Revision, error) {
+	context := ctx.(context.Context)
+
+	rows, err := r.pool.Query(context, `
+		SELECT `+revisionSelectColumns+`
+		FROM idea_revisions
+		WHERE idea_id = $1
+		ORDER BY number
+	`, ideaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*ideadomain.Revision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// FindByNumber retrieves a single revision of an idea.
+// AI-hint: Returns ErrRevisionNotFound when no row matches.
+func (r *IdeaRevisionRepository) FindByNumber(ctx interface{}, ideaID uuid.UUID, number int) (*ideadomain.Revision, error) {
+	context := ctx.(context.Context)
+
+	revision, err := scanRevision(r.pool.QueryRow(context, `
+		SELECT `+revisionSelectColumns+`
+		FROM idea_revisions
+		WHERE idea_id = $1 AND number = $2
+	`, ideaID, number))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ideadomain.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
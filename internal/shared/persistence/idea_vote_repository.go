@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdeaVoteRepository implements the idea.VoteRepository interface using PostgreSQL.
+// AI-hint: Persists one row per (idea, user) in idea_votes. The denormalized
+// ideas.vote_count column is maintained by database triggers on idea_votes.
+type IdeaVoteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdeaVoteRepository creates a new IdeaVoteRepository instance.
+// AI-hint: Factory method for vote repository with dependency injection of DB pool.
+func NewIdeaVoteRepository(pool *pgxpool.Pool) *IdeaVoteRepository {
+	return &IdeaVoteRepository{
+		pool: pool,
+	}
+}
+
+// AddVote records a user's vote and returns the idea's updated vote count.
+// AI-hint: Insert and count read run in one transaction so the returned count
+// includes this vote. Duplicate votes are reported as ErrAlreadyVoted.
+func (r *IdeaVoteRepository) AddVote(ctx interface{}, vote *ideadomain.Vote) (int, error) {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, `
+		INSERT INTO idea_votes (idea_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (idea_id, user_id) DO NOTHING
+	`, vote.IdeaID, vote.UserID, vote.CreatedAt)
+	if err != nil {
+		// Check for foreign key constraint violation (unknown idea or user)
+		if isForeignKeyViolation(err) {
+			return 0, ideadomain.ErrIdeaNotFound
+		}
+		return 0, err
+	}
+
+	if result.RowsAffected() == 0 {
+		return 0, ideadomain.ErrAlreadyVoted
+	}
+
+	voteCount, err := r.readVoteCount(context, tx, vote.IdeaID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(context); err != nil {
+		return 0, err
+	}
+
+	return voteCount, nil
+}
+
+// RemoveVote deletes a user's vote and returns the idea's updated vote count.
+// AI-hint: Returns ErrVoteNotFound when the user had not voted for the idea.
+func (r *IdeaVoteRepository) RemoveVote(ctx interface{}, ideaID, userID uuid.UUID) (int, error) {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, `DELETE FROM idea_votes WHERE idea_id = $1 AND user_id = $2`, ideaID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if result.RowsAffected() == 0 {
+		return 0, ideadomain.ErrVoteNotFound
+	}
+
+	voteCount, err := r.readVoteCount(context, tx, ideaID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(context); err != nil {
+		return 0, err
+	}
+
+	return voteCount, nil
+}
+
+// HasVoted checks whether a user has voted for an idea.
+// AI-hint: Lightweight existence check backed by the unique (idea_id, user_id) index.
+func (r *IdeaVoteRepository) HasVoted(ctx interface{}, ideaID, userID uuid.UUID) (bool, error) {
+	context := ctx.(context.Context)
+
+	var exists bool
+	err := r.pool.QueryRow(context, `
+		SELECT EXISTS(SELECT 1 FROM idea_votes WHERE idea_id = $1 AND user_id = $2)
+	`, ideaID, userID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// readVoteCount reads the denormalized vote count within the given transaction.
+func (r *IdeaVoteRepository) readVoteCount(ctx context.Context, tx pgx.Tx, ideaID uuid.UUID) (int, error) {
+	var voteCount int
+	err := tx.QueryRow(ctx, `SELECT vote_count FROM ideas WHERE id = $1`, ideaID).Scan(&voteCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ideadomain.ErrIdeaNotFound
+		}
+		return 0, err
+	}
+	return voteCount, nil
+}
@@ -0,0 +1,190 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// InvitationRepository implements the user.InvitationRepository interface using PostgreSQL.
+// AI-hint: Invitations are looked up by ID or by their unique token hash. Deleting the
+// invitee cascades to their invitations; deleting the inviter only clears invited_by_user_id.
+type InvitationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewInvitationRepository creates a new InvitationRepository instance.
+// AI-hint: Factory method for invitation repository with dependency injection of DB pool.
+func NewInvitationRepository(pool *pgxpool.Pool) *InvitationRepository {
+	return &InvitationRepository{
+		pool: pool,
+	}
+}
+
+const invitationColumns = `id, user_id, email, invited_by_user_id, token_hash, created_at, expires_at, accepted_at, revoked_at`
+
+// Create inserts a new invitation.
+func (r *InvitationRepository) Create(ctx interface{}, invitation *userdomain.Invitation) error {
+	context := ctx.(context.Context)
+
+	var invitedBy sql.NullString
+	if invitation.InvitedByUserID != "" {
+		invitedBy = sql.NullString{String: invitation.InvitedByUserID, Valid: true}
+	}
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO user_invitations (id, user_id, email, invited_by_user_id, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, invitation.ID, invitation.UserID, invitation.Email, invitedBy, invitation.TokenHash, invitation.CreatedAt, invitation.ExpiresAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByID retrieves an invitation by its ID.
+func (r *InvitationRepository) GetByID(ctx interface{}, id string) (*userdomain.Invitation, error) {
+	context := ctx.(context.Context)
+
+	row := r.pool.QueryRow(context, `SELECT `+invitationColumns+` FROM user_invitations WHERE id = $1`, id)
+	return scanInvitation(row)
+}
+
+// GetByHash retrieves the invitation with the given token hash, including accepted and revoked ones.
+func (r *InvitationRepository) GetByHash(ctx interface{}, tokenHash string) (*userdomain.Invitation, error) {
+	context := ctx.(context.Context)
+
+	row := r.pool.QueryRow(context, `SELECT `+invitationColumns+` FROM user_invitations WHERE token_hash = $1`, tokenHash)
+	return scanInvitation(row)
+}
+
+// ListPending retrieves all invitations that were neither accepted nor revoked, oldest first.
+// AI-hint: Expired invitations are included so administrators can resend them.
+func (r *InvitationRepository) ListPending(ctx interface{}) ([]*userdomain.Invitation, error) {
+	context := ctx.(context.Context)
+
+	rows, err := r.pool.Query(context, `
+		SELECT `+invitationColumns+`
+		FROM user_invitations
+		WHERE accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*userdomain.Invitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// Renew stores the invitation's new token hash and expiry.
+// AI-hint: Only a pending invitation is updated; otherwise ErrInvitationNotPending is returned.
+func (r *InvitationRepository) Renew(ctx interface{}, invitation *userdomain.Invitation) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE user_invitations SET token_hash = $2, expires_at = $3
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, invitation.ID, invitation.TokenHash, invitation.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvitationNotPending
+	}
+
+	return nil
+}
+
+// Accept consumes a pending invitation and stores the invited user in one transaction.
+// AI-hint: The conditional update makes accepting single-use even under concurrent requests;
+// if storing the user fails, the invitation stays pending and the link keeps working.
+func (r *InvitationRepository) Accept(ctx interface{}, id string, user *userdomain.User) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, `
+		UPDATE user_invitations SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvitationNotPending
+	}
+
+	if err := updateUser(context, tx, user); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// Revoke cancels a pending invitation.
+func (r *InvitationRepository) Revoke(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE user_invitations SET revoked_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvitationNotPending
+	}
+
+	return nil
+}
+
+// scanInvitation reads an invitation from a row selected with invitationColumns.
+func scanInvitation(row rowScanner) (*userdomain.Invitation, error) {
+	var invitation userdomain.Invitation
+	var invitedBy sql.NullString
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.UserID,
+		&invitation.Email,
+		&invitedBy,
+		&invitation.TokenHash,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	invitation.InvitedByUserID = invitedBy.String
+
+	return &invitation, nil
+}
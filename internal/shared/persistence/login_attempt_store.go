@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoginAttemptStore implements the user.LoginAttemptStore interface using PostgreSQL.
+// AI-hint: Shared by all server instances, so throttling holds behind a load balancer.
+// Rows are keyed by "account:<email>" or "ip:<address>" and removed on reset.
+type LoginAttemptStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoginAttemptStore creates a new LoginAttemptStore instance.
+// AI-hint: Factory method for the login attempt store with dependency injection of DB pool.
+func NewLoginAttemptStore(pool *pgxpool.Pool) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		pool: pool,
+	}
+}
+
+// Get retrieves the failed login record for a key, or an empty record if there is none.
+func (s *LoginAttemptStore) Get(ctx interface{}, key string) (*userdomain.LoginAttempts, error) {
+	context := ctx.(context.Context)
+
+	attempts := userdomain.LoginAttempts{Key: key}
+	err := s.pool.QueryRow(context, `
+		SELECT failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1
+	`, key).Scan(&attempts.Failures, &attempts.LastFailureAt, &attempts.LockedUntil)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return &attempts, nil
+}
+
+// RecordFailure counts a failed login for the key and returns the updated record.
+// AI-hint: A single upsert, so concurrent failures are all counted. The count restarts
+// when the previous failure is older than retention; an active lock is kept.
+func (s *LoginAttemptStore) RecordFailure(ctx interface{}, key string, at time.Time, retention time.Duration) (*userdomain.LoginAttempts, error) {
+	context := ctx.(context.Context)
+
+	attempts := userdomain.LoginAttempts{Key: key}
+	err := s.pool.QueryRow(context, `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE
+				WHEN login_attempts.last_failure_at < $2 - make_interval(secs => $3) THEN 1
+				ELSE login_attempts.failures + 1
+			END,
+			last_failure_at = $2
+		RETURNING failures, last_failure_at, locked_until
+	`, key, at, retention.Seconds()).Scan(&attempts.Failures, &attempts.LastFailureAt, &attempts.LockedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempts, nil
+}
+
+// Lock locks the key until the given time and clears its failure count.
+func (s *LoginAttemptStore) Lock(ctx interface{}, key string, until time.Time) error {
+	context := ctx.(context.Context)
+
+	_, err := s.pool.Exec(context, `UPDATE login_attempts SET failures = 0, locked_until = $2 WHERE key = $1`, key, until)
+	return err
+}
+
+// Reset forgets all failures and any lock for the key.
+func (s *LoginAttemptStore) Reset(ctx interface{}, key string) error {
+	context := ctx.(context.Context)
+
+	_, err := s.pool.Exec(context, `DELETE FROM login_attempts WHERE key = $1`, key)
+	return err
+}
@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	userdomain "feedback_hub_2/internal/user/domain"
+)
+
+// InMemoryLoginAttemptStore implements the user.LoginAttemptStore interface in process memory.
+// AI-hint: For single-instance deployments and development. Counts are per process and
+// lost on restart, so with several instances an attacker gets each instance's limit.
+type InMemoryLoginAttemptStore struct {
+	mu         sync.Mutex
+	attempts   map[string]userdomain.LoginAttempts
+	lastPruned time.Time
+}
+
+// NewInMemoryLoginAttemptStore creates a new InMemoryLoginAttemptStore instance.
+func NewInMemoryLoginAttemptStore() *InMemoryLoginAttemptStore {
+	return &InMemoryLoginAttemptStore{
+		attempts: make(map[string]userdomain.LoginAttempts),
+	}
+}
+
+// Get retrieves the failed login record for a key, or an empty record if there is none.
+func (s *InMemoryLoginAttemptStore) Get(ctx interface{}, key string) (*userdomain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, ok := s.attempts[key]
+	if !ok {
+		attempts = userdomain.LoginAttempts{Key: key}
+	}
+	return &attempts, nil
+}
+
+// RecordFailure counts a failed login for the key and returns the updated record.
+// AI-hint: Also drops records whose failures have expired so the map does not grow
+// without bound under a spraying attack.
+func (s *InMemoryLoginAttemptStore) RecordFailure(ctx interface{}, key string, at time.Time, retention time.Duration) (*userdomain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(at, retention)
+
+	attempts, ok := s.attempts[key]
+	if !ok || attempts.LastFailureAt.Before(at.Add(-retention)) {
+		lockedUntil := attempts.LockedUntil
+		attempts = userdomain.LoginAttempts{Key: key, LockedUntil: lockedUntil}
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = at
+	s.attempts[key] = attempts
+
+	return &attempts, nil
+}
+
+// Lock locks the key until the given time and clears its failure count.
+func (s *InMemoryLoginAttemptStore) Lock(ctx interface{}, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempts, ok := s.attempts[key]; ok {
+		attempts.Failures = 0
+		attempts.LockedUntil = &until
+		s.attempts[key] = attempts
+	}
+	return nil
+}
+
+// Reset forgets all failures and any lock for the key.
+func (s *InMemoryLoginAttemptStore) Reset(ctx interface{}, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// pruneLocked removes records with expired failures and no active lock; callers hold mu.
+// AI-hint: Runs at most once a minute so a burst of failures stays cheap.
+func (s *InMemoryLoginAttemptStore) pruneLocked(now time.Time, retention time.Duration) {
+	if now.Sub(s.lastPruned) < time.Minute {
+		return
+	}
+	s.lastPruned = now
+
+	for key, attempts := range s.attempts {
+		locked := attempts.LockedUntil != nil && now.Before(*attempts.LockedUntil)
+		if !locked && attempts.LastFailureAt.Before(now.Add(-retention)) {
+			delete(s.attempts, key)
+		}
+	}
+}
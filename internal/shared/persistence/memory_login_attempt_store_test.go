@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryLoginAttemptStore_FreshAttemptsAfterLockout(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryLoginAttemptStore()
+	policy := userdomain.DefaultAccountLoginPolicy()
+	key := userdomain.AccountLoginKey("user@example.com")
+	now := time.Now()
+
+	// recordFailures records n failures at the given time, as LoginThrottleService does
+	recordFailures := func(n int, at time.Time) *userdomain.LoginAttempts {
+		var attempts *userdomain.LoginAttempts
+		for i := 0; i < n; i++ {
+			var err error
+			attempts, err = store.RecordFailure(ctx, key, at, policy.FailureRetention)
+			require.NoError(t, err)
+		}
+		return attempts
+	}
+
+	attempts := recordFailures(policy.MaxFailures, now)
+	require.True(t, policy.ShouldLock(attempts, now))
+	require.NoError(t, store.Lock(ctx, key, now.Add(policy.LockoutDuration)))
+
+	locked, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, locked.IsLocked(now))
+	assert.Zero(t, locked.Failures, "the failures that caused the lockout are spent")
+
+	// The lockout expired, but the earlier failures are still within the retention window
+	afterLockout := now.Add(policy.LockoutDuration + time.Minute)
+	require.Less(t, afterLockout.Sub(now), policy.FailureRetention)
+
+	attempts = recordFailures(1, afterLockout)
+	assert.Equal(t, 1, attempts.Failures)
+	assert.False(t, policy.ShouldLock(attempts, afterLockout), "one wrong password must not lock the account again")
+	assert.Equal(t, policy.BackoffBase, policy.RetryAfter(attempts, afterLockout))
+
+	attempts = recordFailures(policy.MaxFailures-1, afterLockout)
+	assert.True(t, policy.ShouldLock(attempts, afterLockout), "a fresh set of failures locks it again")
+}
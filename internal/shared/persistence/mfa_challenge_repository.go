@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MFAChallengeRepository implements the user.MFAChallengeRepository interface using PostgreSQL.
+// AI-hint: Challenges are short-lived and deleted once completed or exhausted.
+type MFAChallengeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMFAChallengeRepository creates a new MFAChallengeRepository instance.
+// AI-hint: Factory method for MFA challenge repository with dependency injection of DB pool.
+func NewMFAChallengeRepository(pool *pgxpool.Pool) *MFAChallengeRepository {
+	return &MFAChallengeRepository{
+		pool: pool,
+	}
+}
+
+// Create inserts a new MFA challenge.
+func (r *MFAChallengeRepository) Create(ctx interface{}, challenge *userdomain.MFAChallenge) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO mfa_challenges (id, user_id, token_hash, attempts, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, challenge.ID, challenge.UserID, challenge.TokenHash, challenge.Attempts, challenge.CreatedAt, challenge.ExpiresAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByID retrieves an MFA challenge by its ID, including expired challenges.
+func (r *MFAChallengeRepository) GetByID(ctx interface{}, id string) (*userdomain.MFAChallenge, error) {
+	context := ctx.(context.Context)
+
+	var challenge userdomain.MFAChallenge
+	err := r.pool.QueryRow(context, `
+		SELECT id, user_id, token_hash, attempts, created_at, expires_at
+		FROM mfa_challenges
+		WHERE id = $1
+	`, id).Scan(
+		&challenge.ID,
+		&challenge.UserID,
+		&challenge.TokenHash,
+		&challenge.Attempts,
+		&challenge.CreatedAt,
+		&challenge.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrMFAChallengeNotFound
+		}
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+// RecordAttempt counts a code attempt against the challenge and returns the new total.
+// AI-hint: The increment happens in the database so parallel guesses are all counted.
+func (r *MFAChallengeRepository) RecordAttempt(ctx interface{}, id string) (int, error) {
+	context := ctx.(context.Context)
+
+	var attempts int
+	err := r.pool.QueryRow(context, `
+		UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts
+	`, id).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, userdomain.ErrMFAChallengeNotFound
+		}
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// Delete removes an MFA challenge; deleting a missing challenge is not an error.
+func (r *MFAChallengeRepository) Delete(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `DELETE FROM mfa_challenges WHERE id = $1`, id)
+	return err
+}
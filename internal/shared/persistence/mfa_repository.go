@@ -0,0 +1,181 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MFARepository implements the user.MFARepository interface using PostgreSQL.
+// AI-hint: One TOTP credential per user (user_totp_credentials) plus their recovery
+// codes (mfa_recovery_codes). Code acceptance is done with conditional updates so each
+// code works exactly once. Deleting a user cascades to both tables.
+type MFARepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMFARepository creates a new MFARepository instance.
+// AI-hint: Factory method for MFA repository with dependency injection of DB pool.
+func NewMFARepository(pool *pgxpool.Pool) *MFARepository {
+	return &MFARepository{
+		pool: pool,
+	}
+}
+
+// GetTOTPCredential retrieves the user's TOTP credential, confirmed or not.
+// AI-hint: Returns ErrTOTPNotEnrolled when the user never started enrollment.
+func (r *MFARepository) GetTOTPCredential(ctx interface{}, userID string) (*userdomain.TOTPCredential, error) {
+	context := ctx.(context.Context)
+
+	var credential userdomain.TOTPCredential
+	err := r.pool.QueryRow(context, `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at
+		FROM user_totp_credentials
+		WHERE user_id = $1
+	`, userID).Scan(
+		&credential.UserID,
+		&credential.Secret,
+		&credential.ConfirmedAt,
+		&credential.LastUsedStep,
+		&credential.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrTOTPNotEnrolled
+		}
+		return nil, err
+	}
+
+	return &credential, nil
+}
+
+// SaveTOTPCredential inserts or replaces the user's TOTP credential.
+// AI-hint: Restarting enrollment overwrites an unconfirmed secret.
+func (r *MFARepository) SaveTOTPCredential(ctx interface{}, credential *userdomain.TOTPCredential) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO user_totp_credentials (user_id, secret, confirmed_at, last_used_step, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = EXCLUDED.confirmed_at,
+			last_used_step = EXCLUDED.last_used_step, created_at = EXCLUDED.created_at
+	`, credential.UserID, credential.Secret, credential.ConfirmedAt, credential.LastUsedStep, credential.CreatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RecordTOTPStep marks a TOTP time step as used.
+// AI-hint: Only moves last_used_step forward; a step at or before the stored one means
+// the code was already used and ErrInvalidMFACode is returned.
+func (r *MFARepository) RecordTOTPStep(ctx interface{}, userID string, step int64) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE user_totp_credentials SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// DeleteTOTPCredential removes the user's TOTP credential and recovery codes.
+func (r *MFARepository) DeleteTOTPCredential(ctx interface{}, userID string) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if _, err := tx.Exec(context, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(context, `DELETE FROM user_totp_credentials WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrTOTPNotEnrolled
+	}
+
+	return tx.Commit(context)
+}
+
+// ReplaceRecoveryCodes swaps the user's recovery codes for a new set.
+// AI-hint: Old codes, used or not, stop working in the same transaction.
+func (r *MFARepository) ReplaceRecoveryCodes(ctx interface{}, userID string, codeHashes []string) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if _, err := tx.Exec(context, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.Exec(context, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)
+		`, userID, codeHash); err != nil {
+			if isForeignKeyViolation(err) {
+				return userdomain.ErrUserNotFound
+			}
+			return err
+		}
+	}
+
+	return tx.Commit(context)
+}
+
+// UseRecoveryCode consumes one of the user's recovery codes.
+// AI-hint: Returns ErrInvalidMFACode for unknown or already used codes.
+func (r *MFARepository) UseRecoveryCode(ctx interface{}, userID, codeHash string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes the user has left.
+func (r *MFARepository) CountRecoveryCodes(ctx interface{}, userID string) (int, error) {
+	context := ctx.(context.Context)
+
+	var count int
+	err := r.pool.QueryRow(context, `
+		SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OIDCLoginRepository implements the user.OIDCLoginRepository interface using PostgreSQL.
+// AI-hint: Pending single sign-on logins are short-lived; each is deleted when its
+// callback arrives, and abandoned ones are swept when new logins start.
+type OIDCLoginRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOIDCLoginRepository creates a new OIDCLoginRepository instance.
+// AI-hint: Factory method for OIDC login repository with dependency injection of DB pool.
+func NewOIDCLoginRepository(pool *pgxpool.Pool) *OIDCLoginRepository {
+	return &OIDCLoginRepository{
+		pool: pool,
+	}
+}
+
+// Create stores a pending login and removes expired ones.
+func (r *OIDCLoginRepository) Create(ctx interface{}, login *userdomain.OIDCLogin) error {
+	context := ctx.(context.Context)
+
+	if _, err := r.pool.Exec(context, `DELETE FROM oidc_logins WHERE expires_at < NOW()`); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO oidc_logins (state_hash, provider, code_verifier, nonce, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, login.StateHash, login.Provider, login.CodeVerifier, login.Nonce, login.CreatedAt, login.ExpiresAt)
+	return err
+}
+
+// Consume deletes a pending login and returns it, including expired logins.
+// AI-hint: DELETE ... RETURNING makes the lookup and removal one step, so a replayed
+// callback finds nothing.
+func (r *OIDCLoginRepository) Consume(ctx interface{}, stateHash string) (*userdomain.OIDCLogin, error) {
+	context := ctx.(context.Context)
+
+	var login userdomain.OIDCLogin
+	err := r.pool.QueryRow(context, `
+		DELETE FROM oidc_logins
+		WHERE state_hash = $1
+		RETURNING state_hash, provider, code_verifier, nonce, created_at, expires_at
+	`, stateHash).Scan(
+		&login.StateHash,
+		&login.Provider,
+		&login.CodeVerifier,
+		&login.Nonce,
+		&login.CreatedAt,
+		&login.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrOIDCLoginNotFound
+		}
+		return nil, err
+	}
+
+	return &login, nil
+}
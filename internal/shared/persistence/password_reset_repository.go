@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PasswordResetRepository implements the user.PasswordResetRepository interface using PostgreSQL.
+// AI-hint: Tokens are looked up by their unique hash. Deleting a user cascades to their tokens.
+type PasswordResetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository instance.
+// AI-hint: Factory method for password reset repository with dependency injection of DB pool.
+func NewPasswordResetRepository(pool *pgxpool.Pool) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		pool: pool,
+	}
+}
+
+// Create inserts a new password reset token.
+func (r *PasswordResetRepository) Create(ctx interface{}, token *userdomain.PasswordResetToken) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, token.ID, token.UserID, token.TokenHash, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the token with the given hash, including used and expired tokens.
+func (r *PasswordResetRepository) GetByHash(ctx interface{}, tokenHash string) (*userdomain.PasswordResetToken, error) {
+	context := ctx.(context.Context)
+
+	var token userdomain.PasswordResetToken
+	err := r.pool.QueryRow(context, `
+		SELECT id, user_id, token_hash, created_at, expires_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrPasswordResetTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// MarkUsed consumes a token.
+// AI-hint: Only an unused token is updated, so of two concurrent resets with the same
+// link exactly one wins; the other gets ErrInvalidPasswordResetToken.
+func (r *PasswordResetRepository) MarkUsed(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrInvalidPasswordResetToken
+	}
+
+	return nil
+}
+
+// InvalidateForUser consumes every unused token of a user.
+func (r *PasswordResetRepository) InvalidateForUser(ctx interface{}, userID string) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL
+	`, userID)
+	return err
+}
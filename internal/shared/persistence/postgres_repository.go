@@ -42,9 +42,20 @@ func EnsureSchema(ctx context.Context, pool *pgxpool.Pool) error {
 	}
 	defer conn.Release()
 
+	if err := ensureBaseSchema(ctx, conn); err != nil {
+		return err
+	}
+
+	return applySchemaMigrations(ctx, conn)
+}
+
+// ensureBaseSchema creates the core users, roles and ideas tables or upgrades an older schema.
+// AI-hint: Handles the original bootstrap paths (fresh database, legacy users table,
+// missing ideas table). Incremental changes belong in schemaMigrations instead.
+func ensureBaseSchema(ctx context.Context, conn *pgxpool.Conn) error {
 	// Check if users table exists with password_hash column (indicates new schema)
 	var columnCount int
-	err = conn.QueryRow(ctx, `
+	err := conn.QueryRow(ctx, `
 		SELECT COUNT(*) 
 		FROM information_schema.columns 
 		WHERE table_schema = 'public' 
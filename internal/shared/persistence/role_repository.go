@@ -17,6 +17,18 @@ type RoleRepository struct {
 	pool *pgxpool.Pool
 }
 
+// roleSelect selects roles with their permissions aggregated into a sorted array.
+// AI-hint: Callers append a WHERE clause (on r.) before roleGroupBy.
+const roleSelect = `
+	SELECT r.id, r.name,
+		COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}'),
+		r.created_at, r.updated_at
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role_id = r.id
+`
+
+const roleGroupBy = ` GROUP BY r.id`
+
 // NewRoleRepository creates a new RoleRepository instance.
 // AI-hint: Factory method for role repository with dependency injection of DB pool.
 func NewRoleRepository(pool *pgxpool.Pool) *RoleRepository {
@@ -25,17 +37,23 @@ func NewRoleRepository(pool *pgxpool.Pool) *RoleRepository {
 	}
 }
 
-// Create inserts a new role into the database.
+// Create inserts a new role and its permissions into the database.
 // AI-hint: Implements role creation with proper error handling and constraint validation.
 func (r *RoleRepository) Create(ctx interface{}, roleEntity *roledomain.Role) error {
 	context := ctx.(context.Context)
 
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
 	query := `
 		INSERT INTO roles (id, name, created_at, updated_at)
 		VALUES ($1, $2, $3, $4)
 	`
 
-	_, err := r.pool.Exec(context, query, roleEntity.ID, roleEntity.Name, roleEntity.CreatedAt, roleEntity.UpdatedAt)
+	_, err = tx.Exec(context, query, roleEntity.ID, roleEntity.Name, roleEntity.CreatedAt, roleEntity.UpdatedAt)
 	if err != nil {
 		// Check for unique constraint violation
 		if isUniqueViolation(err) {
@@ -44,7 +62,15 @@ func (r *RoleRepository) Create(ctx interface{}, roleEntity *roledomain.Role) er
 		return err
 	}
 
-	return nil
+	for _, permission := range roleEntity.Permissions {
+		if _, err := tx.Exec(context, `
+			INSERT INTO role_permissions (role_id, permission) VALUES ($1, $2)
+		`, roleEntity.ID, permission); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context)
 }
 
 // GetByID retrieves a role by its ID.
@@ -52,16 +78,13 @@ func (r *RoleRepository) Create(ctx interface{}, roleEntity *roledomain.Role) er
 func (r *RoleRepository) GetByID(ctx interface{}, id string) (*roledomain.Role, error) {
 	context := ctx.(context.Context)
 
-	query := `
-		SELECT id, name, created_at, updated_at
-		FROM roles
-		WHERE id = $1
-	`
+	query := roleSelect + `WHERE r.id = $1` + roleGroupBy
 
 	var roleEntity roledomain.Role
 	err := r.pool.QueryRow(context, query, id).Scan(
 		&roleEntity.ID,
 		&roleEntity.Name,
+		&roleEntity.Permissions,
 		&roleEntity.CreatedAt,
 		&roleEntity.UpdatedAt,
 	)
@@ -81,16 +104,13 @@ func (r *RoleRepository) GetByID(ctx interface{}, id string) (*roledomain.Role,
 func (r *RoleRepository) GetByName(ctx interface{}, name string) (*roledomain.Role, error) {
 	context := ctx.(context.Context)
 
-	query := `
-		SELECT id, name, created_at, updated_at
-		FROM roles
-		WHERE name = $1
-	`
+	query := roleSelect + `WHERE r.name = $1` + roleGroupBy
 
 	var roleEntity roledomain.Role
 	err := r.pool.QueryRow(context, query, name).Scan(
 		&roleEntity.ID,
 		&roleEntity.Name,
+		&roleEntity.Permissions,
 		&roleEntity.CreatedAt,
 		&roleEntity.UpdatedAt,
 	)
@@ -159,11 +179,7 @@ func (r *RoleRepository) Delete(ctx interface{}, id string) error {
 func (r *RoleRepository) List(ctx interface{}) ([]*roledomain.Role, error) {
 	context := ctx.(context.Context)
 
-	query := `
-		SELECT id, name, created_at, updated_at
-		FROM roles
-		ORDER BY name
-	`
+	query := roleSelect + roleGroupBy + ` ORDER BY r.name`
 
 	rows, err := r.pool.Query(context, query)
 	if err != nil {
@@ -177,6 +193,7 @@ func (r *RoleRepository) List(ctx interface{}) ([]*roledomain.Role, error) {
 		err := rows.Scan(
 			&roleEntity.ID,
 			&roleEntity.Name,
+			&roleEntity.Permissions,
 			&roleEntity.CreatedAt,
 			&roleEntity.UpdatedAt,
 		)
@@ -212,6 +229,103 @@ func (r *RoleRepository) Exists(ctx interface{}, name string) (bool, error) {
 	return true, nil
 }
 
+// GrantPermission adds a permission to a role; granting it twice is not an error.
+func (r *RoleRepository) GrantPermission(ctx interface{}, roleID, permission string) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if _, err := tx.Exec(context, `
+		INSERT INTO role_permissions (role_id, permission) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, roleID, permission); err != nil {
+		if isForeignKeyViolation(err) {
+			return roledomain.ErrRoleNotFound
+		}
+		return err
+	}
+	if _, err := tx.Exec(context, `
+		DELETE FROM role_permission_revocations WHERE role_id = $1 AND permission = $2
+	`, roleID, permission); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// RevokePermission removes a permission from a role; revoking a missing one is not an error.
+// AI-hint: The revocation is recorded as well, so the schema migrations that re-grant the
+// old default permissions on every start remove it again.
+func (r *RoleRepository) RevokePermission(ctx interface{}, roleID, permission string) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if _, err := tx.Exec(context, `
+		DELETE FROM role_permissions WHERE role_id = $1 AND permission = $2
+	`, roleID, permission); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context, `
+		INSERT INTO role_permission_revocations (role_id, permission) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, roleID, permission); err != nil {
+		if isForeignKeyViolation(err) {
+			return roledomain.ErrRoleNotFound
+		}
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// rolePermissionsSeededMarker is the system_markers row written by SeedPermissions.
+const rolePermissionsSeededMarker = "role permissions seeded"
+
+// SeedPermissions grants default permissions to the roles with the given names, once.
+// AI-hint: The marker row is inserted in the same transaction, so concurrent instances
+// seed at most once and later restarts never re-grant revoked permissions.
+func (r *RoleRepository) SeedPermissions(ctx interface{}, permissionsByRoleName map[string][]string) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, `
+		INSERT INTO system_markers (name) VALUES ($1) ON CONFLICT DO NOTHING
+	`, rolePermissionsSeededMarker)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	for roleName, permissions := range permissionsByRoleName {
+		if _, err := tx.Exec(context, `
+			INSERT INTO role_permissions (role_id, permission)
+			SELECT r.id, p FROM roles r, unnest($2::text[]) AS p
+			WHERE r.name = $1
+			ON CONFLICT DO NOTHING
+		`, roleName, permissions); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context)
+}
+
 // EnsurePredefinedRoles creates the predefined roles if they don't exist.
 // AI-hint: System initialization method to ensure required roles exist on startup.
 func (r *RoleRepository) EnsurePredefinedRoles(ctx interface{}) error {
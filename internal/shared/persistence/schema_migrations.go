@@ -0,0 +1,489 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// schemaMigration is a named group of additive schema changes.
+// AI-hint: Every statement must be idempotent (IF NOT EXISTS, CREATE OR REPLACE, ...)
+// because migrations are re-applied on every startup instead of being versioned.
+type schemaMigration struct {
+	name       string
+	statements []string
+}
+
+// schemaMigrations lists incremental schema changes in the order they must be applied.
+// AI-hint: Append new groups at the end; never edit or reorder existing entries.
+var schemaMigrations = []schemaMigration{
+	{
+		name: "idea listing keyset indexes",
+		statements: []string{
+			`CREATE INDEX IF NOT EXISTS idx_ideas_created_at_id ON ideas(created_at, id)`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_updated_at_id ON ideas(updated_at, id)`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_creator_created_at_id ON ideas(creator_user_id, created_at, id)`,
+		},
+	},
+	{
+		// Skipped when the 'english' text search configuration is missing;
+		// IdeaRepository.Search then falls back to ILIKE matching.
+		name: "idea full-text search vector",
+		statements: []string{
+			`DO $$
+			BEGIN
+				IF EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'english') THEN
+					ALTER TABLE ideas ADD COLUMN IF NOT EXISTS search_vector tsvector
+						GENERATED ALWAYS AS (
+							setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+							setweight(to_tsvector('english'::regconfig, coalesce(content, '')), 'B')
+						) STORED;
+					CREATE INDEX IF NOT EXISTS idx_ideas_search_vector ON ideas USING GIN (search_vector);
+				END IF;
+			END
+			$$`,
+		},
+	},
+	{
+		// vote_count is maintained by triggers on idea_votes so it also stays correct
+		// when votes disappear through ON DELETE CASCADE.
+		name: "idea votes",
+		statements: []string{
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS vote_count INTEGER NOT NULL DEFAULT 0`,
+			`CREATE TABLE IF NOT EXISTS idea_votes (
+				id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+				idea_id UUID NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				CONSTRAINT uq_idea_votes_idea_user UNIQUE (idea_id, user_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_idea_votes_user_id ON idea_votes(user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_vote_count_id ON ideas(vote_count, id)`,
+			`CREATE OR REPLACE FUNCTION update_idea_vote_count()
+			RETURNS TRIGGER AS $$
+			BEGIN
+				IF TG_OP = 'INSERT' THEN
+					UPDATE ideas SET vote_count = vote_count + 1 WHERE id = NEW.idea_id;
+				ELSIF TG_OP = 'DELETE' THEN
+					UPDATE ideas SET vote_count = GREATEST(vote_count - 1, 0) WHERE id = OLD.idea_id;
+				END IF;
+				RETURN NULL;
+			END;
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS trigger_update_idea_vote_count ON idea_votes`,
+			`CREATE TRIGGER trigger_update_idea_vote_count
+				AFTER INSERT OR DELETE ON idea_votes
+				FOR EACH ROW
+				EXECUTE FUNCTION update_idea_vote_count()`,
+			// Counter maintenance must not bump updated_at, otherwise voting would
+			// reorder "recently updated" listings.
+			`CREATE OR REPLACE FUNCTION update_ideas_updated_at()
+			RETURNS TRIGGER AS $$
+			BEGIN
+				IF (to_jsonb(NEW) - ARRAY['vote_count', 'updated_at', 'search_vector']) =
+				   (to_jsonb(OLD) - ARRAY['vote_count', 'updated_at', 'search_vector']) THEN
+					NEW.updated_at = OLD.updated_at;
+				ELSE
+					NEW.updated_at = NOW();
+				END IF;
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql`,
+		},
+	},
+	{
+		// Replies reference their parent comment; deleting a comment removes its replies.
+		name: "idea comments",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS comments (
+				id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+				idea_id UUID NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+				parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+				author_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				content TEXT NOT NULL,
+				depth INTEGER NOT NULL DEFAULT 0 CHECK (depth >= 0),
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_idea_created_at ON comments(idea_id, created_at, id)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_author_user_id ON comments(author_user_id)`,
+		},
+	},
+	{
+		// Allowed transitions are enforced in the idea domain; the CHECK only guards values.
+		name: "idea status workflow",
+		statements: []string{
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'new'`,
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT ''`,
+			`DO $$
+			BEGIN
+				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_ideas_status') THEN
+					ALTER TABLE ideas ADD CONSTRAINT chk_ideas_status
+						CHECK (status IN ('new', 'under_review', 'planned', 'in_progress', 'done', 'declined'));
+				END IF;
+			END
+			$$`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_status_created_at_id ON ideas(status, created_at, id)`,
+		},
+	},
+	{
+		name: "idea tags",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS tags (
+				id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+				name VARCHAR(50) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS uq_tags_lower_name ON tags(lower(name))`,
+			`CREATE TABLE IF NOT EXISTS idea_tags (
+				idea_id UUID NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+				tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+				PRIMARY KEY (idea_id, tag_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_idea_tags_tag_id ON idea_tags(tag_id)`,
+		},
+	},
+	{
+		// Each row holds the title/content as they were before edit number N.
+		name: "idea revisions",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS idea_revisions (
+				id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+				idea_id UUID NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+				number INTEGER NOT NULL CHECK (number > 0),
+				title VARCHAR(255) NOT NULL,
+				content TEXT NOT NULL,
+				editor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				CONSTRAINT uq_idea_revisions_idea_number UNIQUE (idea_id, number)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_idea_revisions_editor_user_id ON idea_revisions(editor_user_id)`,
+		},
+	},
+	{
+		// Ideas are soft-deleted and purged later; deleting a user anonymizes their
+		// ideas (creator_user_id becomes NULL) instead of cascading.
+		name: "idea soft delete and archive",
+		statements: []string{
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE`,
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_deleted_at ON ideas(deleted_at) WHERE deleted_at IS NOT NULL`,
+			`ALTER TABLE ideas ALTER COLUMN creator_user_id DROP NOT NULL`,
+			`DO $$
+			DECLARE
+				fk RECORD;
+			BEGIN
+				FOR fk IN
+					SELECT c.conname FROM pg_constraint c
+					JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY (c.conkey)
+					WHERE c.conrelid = 'ideas'::regclass AND c.contype = 'f'
+						AND a.attname = 'creator_user_id' AND c.confdeltype <> 'n'
+				LOOP
+					EXECUTE format('ALTER TABLE ideas DROP CONSTRAINT %I', fk.conname);
+				END LOOP;
+				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_ideas_creator_user_id') THEN
+					ALTER TABLE ideas ADD CONSTRAINT fk_ideas_creator_user_id
+						FOREIGN KEY (creator_user_id) REFERENCES users(id) ON DELETE SET NULL;
+				END IF;
+			END
+			$$`,
+		},
+	},
+	{
+		// Trigram indexes are skipped when pg_trgm is unavailable or cannot be installed;
+		// IdeaRepository.FindSimilar then returns no duplicate suggestions. Merge records
+		// outlive the purged source idea, so source_idea_id has no foreign key.
+		name: "idea duplicate detection and merging",
+		statements: []string{
+			`DO $$
+			BEGIN
+				IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'pg_trgm') THEN
+					CREATE EXTENSION IF NOT EXISTS pg_trgm;
+					CREATE INDEX IF NOT EXISTS idx_ideas_title_trgm ON ideas USING GIN (title gin_trgm_ops);
+					CREATE INDEX IF NOT EXISTS idx_ideas_content_trgm ON ideas USING GIN (content gin_trgm_ops);
+				END IF;
+			EXCEPTION WHEN insufficient_privilege THEN
+				RAISE NOTICE 'pg_trgm could not be installed; duplicate detection is disabled';
+			END
+			$$`,
+			`CREATE TABLE IF NOT EXISTS idea_merges (
+				source_idea_id UUID PRIMARY KEY,
+				target_idea_id UUID NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+				merged_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+				merged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_idea_merges_target_idea_id ON idea_merges(target_idea_id)`,
+		},
+	},
+	{
+		// Only the SHA-256 hash of the current refresh token is stored per session.
+		name: "auth sessions",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS sessions (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				refresh_token_hash VARCHAR(64) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				revoked_at TIMESTAMP WITH TIME ZONE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id)`,
+		},
+	},
+	{
+		// Scopes are auth.Permission names; only the SHA-256 hash of each token is stored.
+		name: "personal access tokens",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS personal_access_tokens (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				name VARCHAR(100) NOT NULL,
+				display_prefix VARCHAR(32) NOT NULL,
+				token_hash VARCHAR(64) NOT NULL UNIQUE,
+				scopes TEXT[] NOT NULL,
+				expires_at TIMESTAMP WITH TIME ZONE,
+				last_used_at TIMESTAMP WITH TIME ZONE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens(user_id)`,
+		},
+	},
+	{
+		// Reset tokens are single-use; used_at is also set when a newer reset is requested.
+		name: "password reset tokens",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS password_reset_tokens (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				token_hash VARCHAR(64) NOT NULL UNIQUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				used_at TIMESTAMP WITH TIME ZONE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id)`,
+		},
+	},
+	{
+		// Accounts that existed before verification was introduced count as verified;
+		// the backfill only runs together with adding the column.
+		name: "email verification",
+		statements: []string{
+			`DO $$
+			BEGIN
+				IF NOT EXISTS (
+					SELECT 1 FROM information_schema.columns
+					WHERE table_name = 'users' AND column_name = 'email_verified_at'
+				) THEN
+					ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+					UPDATE users SET email_verified_at = created_at;
+				END IF;
+			END
+			$$`,
+			`CREATE TABLE IF NOT EXISTS email_verification_tokens (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				email VARCHAR(255) NOT NULL,
+				token_hash VARCHAR(64) NOT NULL UNIQUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				used_at TIMESTAMP WITH TIME ZONE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id)`,
+		},
+	},
+	{
+		// TOTP secrets must be readable to compute codes; recovery codes and
+		// challenge tokens are stored as SHA-256 hashes.
+		name: "two-factor authentication",
+		statements: []string{
+			`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS mfa_verified BOOLEAN NOT NULL DEFAULT FALSE`,
+			`CREATE TABLE IF NOT EXISTS user_totp_credentials (
+				user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+				secret VARCHAR(64) NOT NULL,
+				confirmed_at TIMESTAMP WITH TIME ZONE,
+				last_used_step BIGINT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+				id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				code_hash VARCHAR(64) NOT NULL,
+				used_at TIMESTAMP WITH TIME ZONE,
+				UNIQUE (user_id, code_hash)
+			)`,
+			`CREATE TABLE IF NOT EXISTS mfa_challenges (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				token_hash VARCHAR(64) NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_mfa_challenges_user_id ON mfa_challenges(user_id)`,
+		},
+	},
+	{
+		name: "login attempts",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS login_attempts (
+				key VARCHAR(320) PRIMARY KEY,
+				failures INTEGER NOT NULL DEFAULT 0,
+				last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				locked_until TIMESTAMP WITH TIME ZONE
+			)`,
+		},
+	},
+	{
+		// Pending logins are keyed by the SHA-256 hash of their state parameter.
+		name: "openid connect single sign-on",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS user_identities (
+				provider VARCHAR(64) NOT NULL,
+				subject VARCHAR(255) NOT NULL,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				email VARCHAR(255) NOT NULL DEFAULT '',
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				last_login_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				PRIMARY KEY (provider, subject)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id)`,
+			`CREATE TABLE IF NOT EXISTS oidc_logins (
+				state_hash VARCHAR(64) PRIMARY KEY,
+				provider VARCHAR(64) NOT NULL,
+				code_verifier VARCHAR(128) NOT NULL,
+				nonce VARCHAR(128) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_oidc_logins_expires_at ON oidc_logins(expires_at)`,
+		},
+	},
+	{
+		// Invitation tokens are stored as SHA-256 hashes; resending replaces the hash.
+		name: "user invitations",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS user_invitations (
+				id UUID PRIMARY KEY,
+				user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				email VARCHAR(255) NOT NULL,
+				invited_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+				token_hash VARCHAR(64) NOT NULL UNIQUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				accepted_at TIMESTAMP WITH TIME ZONE,
+				revoked_at TIMESTAMP WITH TIME ZONE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_user_invitations_user_id ON user_invitations(user_id)`,
+		},
+	},
+	{
+		// Holds the hash of the one-time token logged for first-run Super User setup.
+		name: "first-run setup token",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS setup_tokens (
+				token_hash VARCHAR(64) PRIMARY KEY,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+		},
+	},
+	{
+		// Permissions granted to each role. Predefined roles that already exist get the
+		// permissions they had when these were hard-coded; new ones are seeded at bootstrap.
+		name: "role permissions",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS role_permissions (
+				role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+				permission VARCHAR(64) NOT NULL,
+				PRIMARY KEY (role_id, permission)
+			)`,
+			`INSERT INTO role_permissions (role_id, permission)
+			SELECT r.id, p.permission FROM roles r
+			CROSS JOIN (VALUES
+				('role:read'), ('user:read'), ('user:create'), ('user:update'), ('user:delete'),
+				('user:create_contributor'), ('idea:update_any'), ('idea:delete_any'),
+				('idea:change_status'), ('idea:merge'), ('tag:create'), ('tag:update'), ('tag:delete'),
+				('comment:update_any'), ('comment:delete_any'), ('api:read'), ('api:write')
+			) AS p(permission)
+			WHERE r.name = 'Product Owner'
+			ON CONFLICT DO NOTHING`,
+			`INSERT INTO role_permissions (role_id, permission)
+			SELECT r.id, p.permission FROM roles r
+			CROSS JOIN (VALUES ('role:read'), ('user:read'), ('api:read'), ('api:write')) AS p(permission)
+			WHERE r.name = 'Contributor'
+			ON CONFLICT DO NOTHING`,
+		},
+	},
+	{
+		// Who can see each idea. Existing ideas stay public.
+		name: "idea visibility",
+		statements: []string{
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'public'`,
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS visible_to_role_ids UUID[] NOT NULL DEFAULT '{}'`,
+			`ALTER TABLE ideas ADD COLUMN IF NOT EXISTS visible_to_user_ids UUID[] NOT NULL DEFAULT '{}'`,
+			`CREATE INDEX IF NOT EXISTS idx_ideas_visibility ON ideas(visibility)`,
+		},
+	},
+	{
+		// Bookkeeping for one-time steps. The default role permissions are seeded once at
+		// bootstrap (RoleRepository.SeedPermissions); databases that already hold role
+		// permissions were seeded by "role permissions". That migration re-grants the old
+		// defaults on every start, so permissions revoked since are removed again here.
+		name: "system markers and permission revocations",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS system_markers (
+				name VARCHAR(64) PRIMARY KEY,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)`,
+			`INSERT INTO system_markers (name)
+			SELECT 'role permissions seeded' WHERE EXISTS (SELECT 1 FROM role_permissions)
+			ON CONFLICT DO NOTHING`,
+			`CREATE TABLE IF NOT EXISTS role_permission_revocations (
+				role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+				permission VARCHAR(64) NOT NULL,
+				PRIMARY KEY (role_id, permission)
+			)`,
+			`DELETE FROM role_permissions rp
+			USING role_permission_revocations rv
+			WHERE rp.role_id = rv.role_id AND rp.permission = rv.permission`,
+		},
+	},
+	{
+		// First-run setup is complete once it is marked so. Installations where a Super User
+		// already has a password finished setup before the marker existed.
+		name: "setup completed marker",
+		statements: []string{
+			`INSERT INTO system_markers (name)
+			SELECT 'setup completed' WHERE EXISTS (
+				SELECT 1 FROM users u JOIN roles r ON r.id = u.role_id
+				WHERE r.name = 'Super User' AND u.password_hash IS NOT NULL AND u.password_hash <> ''
+			)
+			ON CONFLICT DO NOTHING`,
+			`DELETE FROM setup_tokens WHERE EXISTS (SELECT 1 FROM system_markers WHERE name = 'setup completed')`,
+		},
+	},
+	{
+		// Whether the login that created a personal access token completed two-factor
+		// authentication. Existing tokens cannot prove it and stay unverified.
+		name: "access token two-factor flag",
+		statements: []string{
+			`ALTER TABLE personal_access_tokens ADD COLUMN IF NOT EXISTS mfa_verified BOOLEAN NOT NULL DEFAULT FALSE`,
+		},
+	},
+}
+
+// applySchemaMigrations runs all incremental schema migrations.
+// AI-hint: Runs after ensureBaseSchema so every migration can rely on the core tables.
+func applySchemaMigrations(ctx context.Context, conn *pgxpool.Conn) error {
+	for _, migration := range schemaMigrations {
+		for _, statement := range migration.statements {
+			if _, err := conn.Exec(ctx, statement); err != nil {
+				return fmt.Errorf("failed to apply schema migration %q: %w", migration.name, err)
+			}
+		}
+	}
+	return nil
+}
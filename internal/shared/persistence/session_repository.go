@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SessionRepository implements the user.SessionRepository interface using PostgreSQL.
+// AI-hint: Sessions are looked up by ID on every authenticated request, so reads stay
+// on the primary key. Deleting a user cascades to their sessions.
+type SessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSessionRepository creates a new SessionRepository instance.
+// AI-hint: Factory method for session repository with dependency injection of DB pool.
+func NewSessionRepository(pool *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{
+		pool: pool,
+	}
+}
+
+// Create inserts a new session.
+// AI-hint: Returns ErrUserNotFound when the user was deleted in the meantime.
+func (r *SessionRepository) Create(ctx interface{}, session *userdomain.Session) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO sessions (id, user_id, refresh_token_hash, mfa_verified, created_at, refreshed_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, session.ID, session.UserID, session.RefreshTokenHash, session.MFAVerified, session.CreatedAt, session.RefreshedAt, session.ExpiresAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return userdomain.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByID retrieves a session by its ID, including revoked and expired sessions.
+func (r *SessionRepository) GetByID(ctx interface{}, id string) (*userdomain.Session, error) {
+	context := ctx.(context.Context)
+
+	var session userdomain.Session
+	err := r.pool.QueryRow(context, `
+		SELECT id, user_id, refresh_token_hash, mfa_verified, created_at, refreshed_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1
+	`, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&session.MFAVerified,
+		&session.CreatedAt,
+		&session.RefreshedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Rotate stores the session's new refresh token hash and expiry.
+// AI-hint: The update only applies while previousHash is still current, so of two
+// concurrent refreshes with the same token exactly one wins; the loser gets
+// ErrRefreshTokenReused and the caller revokes the session.
+func (r *SessionRepository) Rotate(ctx interface{}, session *userdomain.Session, previousHash string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE sessions
+		SET refresh_token_hash = $3, refreshed_at = $4, expires_at = $5
+		WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL
+	`, session.ID, previousHash, session.RefreshTokenHash, session.RefreshedAt, session.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+// Revoke marks a session as revoked.
+// AI-hint: Idempotent - revoking an already revoked session keeps its original timestamp.
+func (r *SessionRepository) Revoke(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE sessions SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every active session of a user.
+// AI-hint: Used after credential changes such as a password reset; a user without
+// active sessions is not an error.
+func (r *SessionRepository) RevokeAllForUser(ctx interface{}, userID string) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		UPDATE sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+	return err
+}
+
+// MarkMFAVerified records that a session completed two-factor authentication.
+// AI-hint: Used when a user confirms TOTP enrollment, which proves possession of the authenticator.
+func (r *SessionRepository) MarkMFAVerified(ctx interface{}, id string) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `
+		UPDATE sessions SET mfa_verified = TRUE WHERE id = $1 AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrSessionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	userdomain "feedback_hub_2/internal/user/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SetupTokenRepository implements the user.SetupTokenRepository interface using PostgreSQL.
+// AI-hint: The setup_tokens table holds at most one row, the most recently issued token.
+type SetupTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSetupTokenRepository creates a new SetupTokenRepository instance.
+// AI-hint: Factory method for setup token repository with dependency injection of DB pool.
+func NewSetupTokenRepository(pool *pgxpool.Pool) *SetupTokenRepository {
+	return &SetupTokenRepository{
+		pool: pool,
+	}
+}
+
+// Replace stores the token and removes every other one.
+func (r *SetupTokenRepository) Replace(ctx interface{}, token *userdomain.SetupToken) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if _, err := tx.Exec(context, `DELETE FROM setup_tokens`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context, `
+		INSERT INTO setup_tokens (token_hash, created_at) VALUES ($1, $2)
+	`, token.TokenHash, token.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// setupCompletedMarker is the system_markers row written by Complete.
+const setupCompletedMarker = "setup completed"
+
+// Complete stores the first Super User and marks setup complete in one transaction.
+// AI-hint: The marker is never removed, so deleting or demoting Super Users later does
+// not reopen setup. If any step fails, the token stays valid and setup stays open.
+func (r *SetupTokenRepository) Complete(ctx interface{}, tokenHash string, superUser *userdomain.User, isNew bool) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	if tokenHash != "" {
+		result, err := tx.Exec(context, `DELETE FROM setup_tokens WHERE token_hash = $1`, tokenHash)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return userdomain.ErrInvalidSetupToken
+		}
+	}
+
+	result, err := tx.Exec(context, `
+		INSERT INTO system_markers (name) VALUES ($1) ON CONFLICT DO NOTHING
+	`, setupCompletedMarker)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrSetupCompleted
+	}
+
+	if isNew {
+		err = insertUser(context, tx, superUser)
+	} else {
+		err = updateUser(context, tx, superUser)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `DELETE FROM setup_tokens`); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// IsCompleted reports whether Complete ever succeeded.
+func (r *SetupTokenRepository) IsCompleted(ctx interface{}) (bool, error) {
+	context := ctx.(context.Context)
+
+	var completed bool
+	err := r.pool.QueryRow(context, `
+		SELECT EXISTS (SELECT 1 FROM system_markers WHERE name = $1)
+	`, setupCompletedMarker).Scan(&completed)
+	return completed, err
+}
@@ -0,0 +1,225 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TagRepository implements the idea.TagRepository interface using PostgreSQL.
+// AI-hint: Tags live in the tags table and are attached to ideas through idea_tags.
+// Name uniqueness is case-insensitive via a unique index on lower(name).
+type TagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTagRepository creates a new TagRepository instance.
+// AI-hint: Factory method for tag repository with dependency injection of DB pool.
+func NewTagRepository(pool *pgxpool.Pool) *TagRepository {
+	return &TagRepository{
+		pool: pool,
+	}
+}
+
+// tagSelectColumns selects a tag together with the number of live ideas carrying it.
+const tagSelectColumns = `t.id, t.name,
+	(SELECT COUNT(*) FROM idea_tags it JOIN ideas i ON i.id = it.idea_id
+		WHERE it.tag_id = t.id AND i.deleted_at IS NULL) AS idea_count,
+	t.created_at, t.updated_at`
+
+// scanTag scans tagSelectColumns into a tag entity.
+func scanTag(row rowScanner) (*ideadomain.Tag, error) {
+	var tag ideadomain.Tag
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.IdeaCount, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// collectTags scans all rows into tag entities and closes the result set.
+func collectTags(rows pgx.Rows) ([]*ideadomain.Tag, error) {
+	defer rows.Close()
+
+	tags := []*ideadomain.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Save inserts a new tag into the database.
+// AI-hint: Returns ErrTagAlreadyExists when a tag with the same name (any case) exists.
+func (r *TagRepository) Save(ctx interface{}, tag *ideadomain.Tag) error {
+	context := ctx.(context.Context)
+
+	_, err := r.pool.Exec(context, `
+		INSERT INTO tags (id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+	`, tag.ID, tag.Name, tag.CreatedAt, tag.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ideadomain.ErrTagAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindByID retrieves a tag by its ID.
+// AI-hint: Returns ErrTagNotFound when no row matches.
+func (r *TagRepository) FindByID(ctx interface{}, id uuid.UUID) (*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	tag, err := scanTag(r.pool.QueryRow(context, `SELECT `+tagSelectColumns+` FROM tags t WHERE t.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ideadomain.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// FindByNames retrieves the tags matching the given names case-insensitively.
+// AI-hint: Unknown names are simply absent from the result; callers compare lengths
+// to detect them.
+func (r *TagRepository) FindByNames(ctx interface{}, names []string) ([]*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	if len(names) == 0 {
+		return []*ideadomain.Tag{}, nil
+	}
+
+	rows, err := r.pool.Query(context, `
+		SELECT `+tagSelectColumns+`
+		FROM tags t
+		WHERE lower(t.name) IN (SELECT lower(n) FROM unnest($1::text[]) AS n)
+		ORDER BY t.name
+	`, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTags(rows)
+}
+
+// FindAll retrieves all tags ordered by name.
+func (r *TagRepository) FindAll(ctx interface{}) ([]*ideadomain.Tag, error) {
+	context := ctx.(context.Context)
+
+	rows, err := r.pool.Query(context, `SELECT `+tagSelectColumns+` FROM tags t ORDER BY lower(t.name)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTags(rows)
+}
+
+// Rename updates a tag's name.
+// AI-hint: Ideas reference tags by ID, so the single-row update inside this transaction
+// renames the tag on every tagged idea at once. Name clashes return ErrTagAlreadyExists;
+// use Merge to combine two tags instead.
+func (r *TagRepository) Rename(ctx interface{}, tag *ideadomain.Tag) error {
+	context := ctx.(context.Context)
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	result, err := tx.Exec(context, `
+		UPDATE tags SET name = $1, updated_at = $2 WHERE id = $3
+	`, tag.Name, tag.UpdatedAt, tag.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ideadomain.ErrTagAlreadyExists
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ideadomain.ErrTagNotFound
+	}
+
+	return tx.Commit(context)
+}
+
+// Merge moves every idea tagged with the source tag onto the target tag and deletes the source.
+// AI-hint: Runs in one transaction. Ideas that already carry both tags keep a single
+// target tag thanks to ON CONFLICT DO NOTHING; deleting the source cascades its idea_tags.
+func (r *TagRepository) Merge(ctx interface{}, sourceID, targetID uuid.UUID) error {
+	context := ctx.(context.Context)
+
+	if sourceID == targetID {
+		return ideadomain.ErrInvalidTagMerge
+	}
+
+	tx, err := r.pool.Begin(context)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context)
+
+	// Lock both tags so concurrent renames, merges, or deletes wait for this merge
+	var lockedCount int
+	err = tx.QueryRow(context, `
+		SELECT COUNT(*) FROM (SELECT id FROM tags WHERE id IN ($1, $2) ORDER BY id FOR UPDATE) locked
+	`, sourceID, targetID).Scan(&lockedCount)
+	if err != nil {
+		return err
+	}
+	if lockedCount != 2 {
+		return ideadomain.ErrTagNotFound
+	}
+
+	if _, err := tx.Exec(context, `
+		INSERT INTO idea_tags (idea_id, tag_id)
+		SELECT idea_id, $2 FROM idea_tags WHERE tag_id = $1
+		ON CONFLICT (idea_id, tag_id) DO NOTHING
+	`, sourceID, targetID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `DELETE FROM tags WHERE id = $1`, sourceID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(context, `UPDATE tags SET updated_at = NOW() WHERE id = $1`, targetID); err != nil {
+		return err
+	}
+
+	return tx.Commit(context)
+}
+
+// Delete removes a tag and, through ON DELETE CASCADE, detaches it from all ideas.
+// AI-hint: Returns ErrTagNotFound when no row matches.
+func (r *TagRepository) Delete(ctx interface{}, id uuid.UUID) error {
+	context := ctx.(context.Context)
+
+	result, err := r.pool.Exec(context, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ideadomain.ErrTagNotFound
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	userdomain "feedback_hub_2/internal/user/domain"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -30,35 +31,7 @@ func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
 func (r *UserRepository) Create(ctx interface{}, userEntity *userdomain.User) error {
 	context := ctx.(context.Context)
 
-	query := `
-		INSERT INTO users (id, email, name, password_hash, role_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
-
-	var passwordHash interface{}
-	if userEntity.PasswordHash == "" {
-		passwordHash = nil
-	} else {
-		passwordHash = userEntity.PasswordHash
-	}
-
-	_, err := r.pool.Exec(context, query,
-		userEntity.ID, userEntity.Email, userEntity.Name, passwordHash, userEntity.RoleID,
-		userEntity.CreatedAt, userEntity.UpdatedAt,
-	)
-	if err != nil {
-		// Check for unique constraint violation (duplicate email)
-		if isUniqueViolation(err) {
-			return userdomain.ErrEmailAlreadyExists
-		}
-		// Check for foreign key constraint violation (invalid role_id)
-		if isForeignKeyViolation(err) {
-			return errors.New("invalid role ID")
-		}
-		return err
-	}
-
-	return nil
+	return insertUser(context, r.pool, userEntity)
 }
 
 // GetByID retrieves a user by their ID.
@@ -67,7 +40,7 @@ func (r *UserRepository) GetByID(ctx interface{}, id string) (*userdomain.User,
 	context := ctx.(context.Context)
 
 	query := `
-		SELECT id, email, name, password_hash, role_id, created_at, updated_at
+		SELECT id, email, name, password_hash, role_id, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -80,6 +53,7 @@ func (r *UserRepository) GetByID(ctx interface{}, id string) (*userdomain.User,
 		&userEntity.Name,
 		&passwordHash,
 		&userEntity.RoleID,
+		&userEntity.EmailVerifiedAt,
 		&userEntity.CreatedAt,
 		&userEntity.UpdatedAt,
 	)
@@ -103,7 +77,7 @@ func (r *UserRepository) GetByEmail(ctx interface{}, email string) (*userdomain.
 	context := ctx.(context.Context)
 
 	query := `
-		SELECT id, email, name, password_hash, role_id, created_at, updated_at
+		SELECT id, email, name, password_hash, role_id, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -116,6 +90,7 @@ func (r *UserRepository) GetByEmail(ctx interface{}, email string) (*userdomain.
 		&userEntity.Name,
 		&passwordHash,
 		&userEntity.RoleID,
+		&userEntity.EmailVerifiedAt,
 		&userEntity.CreatedAt,
 		&userEntity.UpdatedAt,
 	)
@@ -138,47 +113,35 @@ func (r *UserRepository) GetByEmail(ctx interface{}, email string) (*userdomain.
 func (r *UserRepository) Update(ctx interface{}, userEntity *userdomain.User) error {
 	context := ctx.(context.Context)
 
-	query := `
-		UPDATE users
-		SET email = $2, name = $3, password_hash = $4, role_id = $5, updated_at = $6
-		WHERE id = $1
-	`
+	return updateUser(context, r.pool, userEntity)
+}
 
-	var passwordHash interface{}
-	if userEntity.PasswordHash == "" {
-		passwordHash = nil
-	} else {
-		passwordHash = userEntity.PasswordHash
-	}
+// Delete removes a user from the database.
+// AI-hint: User deletion with proper error handling for not found cases. Ideas are
+// customer feedback and outlive the account: with a reassignment target they move to
+// that user in the same transaction, otherwise ON DELETE SET NULL anonymizes them.
+func (r *UserRepository) Delete(ctx interface{}, id string, reassignToUserID string) error {
+	context := ctx.(context.Context)
 
-	result, err := r.pool.Exec(context, query,
-		userEntity.ID, userEntity.Email, userEntity.Name, passwordHash, userEntity.RoleID, userEntity.UpdatedAt,
-	)
+	tx, err := r.pool.Begin(context)
 	if err != nil {
-		if isUniqueViolation(err) {
-			return userdomain.ErrEmailAlreadyExists
-		}
-		if isForeignKeyViolation(err) {
-			return errors.New("invalid role ID")
-		}
 		return err
 	}
+	defer tx.Rollback(context)
 
-	if result.RowsAffected() == 0 {
-		return userdomain.ErrUserNotFound
+	if reassignToUserID != "" {
+		_, err := tx.Exec(context, `UPDATE ideas SET creator_user_id = $1 WHERE creator_user_id = $2`, reassignToUserID, id)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return userdomain.ErrInvalidReassignment
+			}
+			return err
+		}
 	}
 
-	return nil
-}
-
-// Delete removes a user from the database.
-// AI-hint: User deletion with proper error handling for not found cases.
-func (r *UserRepository) Delete(ctx interface{}, id string) error {
-	context := ctx.(context.Context)
-
 	query := `DELETE FROM users WHERE id = $1`
 
-	result, err := r.pool.Exec(context, query, id)
+	result, err := tx.Exec(context, query, id)
 	if err != nil {
 		return err
 	}
@@ -187,7 +150,7 @@ func (r *UserRepository) Delete(ctx interface{}, id string) error {
 		return userdomain.ErrUserNotFound
 	}
 
-	return nil
+	return tx.Commit(context)
 }
 
 // List retrieves all users from the database.
@@ -196,7 +159,7 @@ func (r *UserRepository) List(ctx interface{}) ([]*userdomain.User, error) {
 	context := ctx.(context.Context)
 
 	query := `
-		SELECT id, email, name, password_hash, role_id, created_at, updated_at
+		SELECT id, email, name, password_hash, role_id, email_verified_at, created_at, updated_at
 		FROM users
 		ORDER BY email
 	`
@@ -217,6 +180,7 @@ func (r *UserRepository) List(ctx interface{}) ([]*userdomain.User, error) {
 			&userEntity.Name,
 			&passwordHash,
 			&userEntity.RoleID,
+			&userEntity.EmailVerifiedAt,
 			&userEntity.CreatedAt,
 			&userEntity.UpdatedAt,
 		)
@@ -242,7 +206,7 @@ func (r *UserRepository) GetByRoleID(ctx interface{}, roleID string) ([]*userdom
 	context := ctx.(context.Context)
 
 	query := `
-		SELECT id, email, name, password_hash, role_id, created_at, updated_at
+		SELECT id, email, name, password_hash, role_id, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE role_id = $1
 		ORDER BY email
@@ -264,6 +228,7 @@ func (r *UserRepository) GetByRoleID(ctx interface{}, roleID string) ([]*userdom
 			&userEntity.Name,
 			&passwordHash,
 			&userEntity.RoleID,
+			&userEntity.EmailVerifiedAt,
 			&userEntity.CreatedAt,
 			&userEntity.UpdatedAt,
 		)
@@ -282,3 +247,80 @@ func (r *UserRepository) GetByRoleID(ctx interface{}, roleID string) ([]*userdom
 
 	return users, nil
 }
+
+// execer runs a statement on the pool or inside a transaction.
+// AI-hint: Lets other repositories write users in their own transactions, e.g. when an
+// invitation is accepted or first-run setup completes.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// insertUser inserts a new user.
+// AI-hint: Maps duplicate emails to ErrEmailAlreadyExists.
+func insertUser(ctx context.Context, db execer, userEntity *userdomain.User) error {
+	query := `
+		INSERT INTO users (id, email, name, password_hash, role_id, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	var passwordHash interface{}
+	if userEntity.PasswordHash == "" {
+		passwordHash = nil
+	} else {
+		passwordHash = userEntity.PasswordHash
+	}
+
+	_, err := db.Exec(ctx, query,
+		userEntity.ID, userEntity.Email, userEntity.Name, passwordHash, userEntity.RoleID,
+		userEntity.EmailVerifiedAt, userEntity.CreatedAt, userEntity.UpdatedAt,
+	)
+	if err != nil {
+		// Check for unique constraint violation (duplicate email)
+		if isUniqueViolation(err) {
+			return userdomain.ErrEmailAlreadyExists
+		}
+		// Check for foreign key constraint violation (invalid role_id)
+		if isForeignKeyViolation(err) {
+			return errors.New("invalid role ID")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// updateUser stores every field of an existing user.
+// AI-hint: Returns ErrUserNotFound when the user no longer exists.
+func updateUser(ctx context.Context, db execer, userEntity *userdomain.User) error {
+	query := `
+		UPDATE users
+		SET email = $2, name = $3, password_hash = $4, role_id = $5, email_verified_at = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	var passwordHash interface{}
+	if userEntity.PasswordHash == "" {
+		passwordHash = nil
+	} else {
+		passwordHash = userEntity.PasswordHash
+	}
+
+	result, err := db.Exec(ctx, query,
+		userEntity.ID, userEntity.Email, userEntity.Name, passwordHash, userEntity.RoleID, userEntity.EmailVerifiedAt, userEntity.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return userdomain.ErrEmailAlreadyExists
+		}
+		if isForeignKeyViolation(err) {
+			return errors.New("invalid role ID")
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return userdomain.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/auth"
+)
+
+// IdeaQueries provides read-only access to idea information
+// AI-hint: Shared query interface that allows domains (e.g. comments) to access idea
+// data without creating direct dependencies on the idea domain.
+type IdeaQueries interface {
+	// GetIdeaByID retrieves an idea by its ID
+	GetIdeaByID(ctx context.Context, ideaID string) (*IdeaInfo, error)
+
+	// IdeaExists checks if an idea with the given ID exists
+	IdeaExists(ctx context.Context, ideaID string) (bool, error)
+
+	// CanViewIdea checks if an idea with the given ID exists and the user may see it
+	CanViewIdea(ctx context.Context, ideaID string, viewer *auth.UserContext) (bool, error)
+}
+
+// IdeaInfo represents idea information for cross-domain queries
+// AI-hint: DTO that provides idea data without exposing internal idea domain structures.
+type IdeaInfo struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	CreatorUserID string `json:"creator_user_id"`
+}
+
+// NewIdeaInfo creates a new IdeaInfo instance
+func NewIdeaInfo(id, title, creatorUserID string) *IdeaInfo {
+	return &IdeaInfo{
+		ID:            id,
+		Title:         title,
+		CreatorUserID: creatorUserID,
+	}
+}
@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	"feedback_hub_2/internal/shared/auth"
+
+	"github.com/google/uuid"
+)
+
+// IdeaQueryService implements IdeaQueries using the idea domain
+// AI-hint: Implementation of the shared idea query interface that provides
+// access to idea data without creating cross-domain dependencies.
+type IdeaQueryService struct {
+	ideaRepo    ideadomain.Repository
+	authService *auth.AuthorizationService
+}
+
+// NewIdeaQueryService creates a new IdeaQueryService instance
+func NewIdeaQueryService(ideaRepo ideadomain.Repository, authService *auth.AuthorizationService) *IdeaQueryService {
+	return &IdeaQueryService{
+		ideaRepo:    ideaRepo,
+		authService: authService,
+	}
+}
+
+// GetIdeaByID retrieves an idea by its ID
+// AI-hint: Internal lookup that ignores idea visibility; use CanViewIdea before
+// exposing anything about the idea to a user.
+func (s *IdeaQueryService) GetIdeaByID(ctx context.Context, ideaID string) (*IdeaInfo, error) {
+	return s.findIdea(ctx, ideaID, ideadomain.SystemViewer())
+}
+
+// CanViewIdea checks if an idea with the given ID exists and the user may see it
+// AI-hint: Applies the idea domain's visibility rule; users holding idea:read_any see every idea.
+func (s *IdeaQueryService) CanViewIdea(ctx context.Context, ideaID string, viewer *auth.UserContext) (bool, error) {
+	if viewer == nil {
+		return false, auth.ErrInvalidContext
+	}
+
+	_, err := s.findIdea(ctx, ideaID, ideadomain.NewViewer(viewer.UserID, viewer.RoleID, s.authService.CanPerform(viewer, auth.PermissionReadAnyIdea)))
+	if err != nil {
+		if err == ideadomain.ErrIdeaNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// findIdea retrieves an idea visible to the viewer
+func (s *IdeaQueryService) findIdea(ctx context.Context, ideaID string, viewer ideadomain.Viewer) (*IdeaInfo, error) {
+	id, err := uuid.Parse(ideaID)
+	if err != nil {
+		return nil, ideadomain.ErrIdeaNotFound
+	}
+
+	idea, err := s.ideaRepo.FindByID(ctx, id, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIdeaInfo(idea.ID.String(), idea.Title, idea.CreatorUserID.String()), nil
+}
+
+// IdeaExists checks if an idea with the given ID exists
+func (s *IdeaQueryService) IdeaExists(ctx context.Context, ideaID string) (bool, error) {
+	_, err := s.GetIdeaByID(ctx, ideaID)
+	if err != nil {
+		if err == ideadomain.ErrIdeaNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
@@ -16,6 +16,9 @@ type RoleQueries interface {
 
 	// RoleExists checks if a role with the given name exists
 	RoleExists(ctx context.Context, name string) (bool, error)
+
+	// GetRolePermissions retrieves the permission names granted to a role, empty if it does not exist
+	GetRolePermissions(ctx context.Context, roleName string) ([]string, error)
 }
 
 // RoleInfo represents role information for cross-domain queries
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"errors"
 	roledomain "feedback_hub_2/internal/role/domain"
 )
 
@@ -43,3 +44,18 @@ func (s *RoleQueryService) GetRoleByName(ctx context.Context, name string) (*Rol
 func (s *RoleQueryService) RoleExists(ctx context.Context, name string) (bool, error) {
 	return s.roleRepo.Exists(ctx, name)
 }
+
+// GetRolePermissions retrieves the permission names granted to a role
+// AI-hint: Unknown roles have no permissions rather than an error, so a deleted role
+// simply loses access. Satisfies auth.RolePermissionSource.
+func (s *RoleQueryService) GetRolePermissions(ctx context.Context, roleName string) ([]string, error) {
+	role, err := s.roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		if errors.Is(err, roledomain.ErrRoleNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	return role.Permissions, nil
+}
@@ -21,18 +21,20 @@ type UserQueries interface {
 // UserInfo represents user information for cross-domain queries
 // AI-hint: DTO that provides user data without exposing internal user domain structures.
 type UserInfo struct {
-	ID     string `json:"id"`
-	Email  string `json:"email"`
-	Name   string `json:"name"`
-	RoleID string `json:"role_id"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	RoleID        string `json:"role_id"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 // NewUserInfo creates a new UserInfo instance
-func NewUserInfo(id, email, name, roleID string) *UserInfo {
+func NewUserInfo(id, email, name, roleID string, emailVerified bool) *UserInfo {
 	return &UserInfo{
-		ID:     id,
-		Email:  email,
-		Name:   name,
-		RoleID: roleID,
+		ID:            id,
+		Email:         email,
+		Name:          name,
+		RoleID:        roleID,
+		EmailVerified: emailVerified,
 	}
 }
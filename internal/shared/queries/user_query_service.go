@@ -26,7 +26,7 @@ func (s *UserQueryService) GetUserByID(ctx context.Context, userID string) (*Use
 		return nil, err
 	}
 
-	return NewUserInfo(user.ID, user.Email, user.Name, user.RoleID), nil
+	return NewUserInfo(user.ID, user.Email, user.Name, user.RoleID, user.IsEmailVerified()), nil
 }
 
 // GetUsersByRoleID retrieves all users assigned to a specific role
@@ -38,7 +38,7 @@ func (s *UserQueryService) GetUsersByRoleID(ctx context.Context, roleID string)
 
 	var userInfos []*UserInfo
 	for _, user := range users {
-		userInfos = append(userInfos, NewUserInfo(user.ID, user.Email, user.Name, user.RoleID))
+		userInfos = append(userInfos, NewUserInfo(user.ID, user.Email, user.Name, user.RoleID, user.IsEmailVerified()))
 	}
 
 	return userInfos, nil
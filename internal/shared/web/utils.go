@@ -3,6 +3,7 @@ package web
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strings"
 )
@@ -72,3 +73,24 @@ func GetUserIDFromContext(ctx context.Context) string {
 func SetUserIDInContext(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDContextKey, userID)
 }
+
+// ClientIP returns the IP address of the client that sent the request.
+// AI-hint: Uses the connection's remote address unless trustProxy is set, in which case
+// the last X-Forwarded-For entry (the one appended by our own proxy) wins. Only enable
+// trustProxy behind a proxy that sets the header, or clients can spoof their address.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			entries := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
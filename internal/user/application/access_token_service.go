@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/auth"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"fmt"
+	"log"
+	"time"
+)
+
+// AccessTokenService manages personal access tokens for scripts and integrations.
+// AI-hint: Application service behind /users/me/tokens and token authentication in the
+// auth middleware. A token can only carry permissions its owner's role grants, and the
+// role is re-checked on every use, so demoting a user also narrows their tokens.
+type AccessTokenService struct {
+	tokenRepo   domain.AccessTokenRepository
+	userRepo    domain.Repository
+	roleQueries queries.RoleQueries
+	authService *auth.AuthorizationService
+}
+
+// NewAccessTokenService creates a new AccessTokenService instance.
+// AI-hint: Factory method for access token service with dependency injection of repositories and auth service.
+func NewAccessTokenService(tokenRepo domain.AccessTokenRepository, userRepo domain.Repository, roleQueries queries.RoleQueries, authService *auth.AuthorizationService) *AccessTokenService {
+	return &AccessTokenService{
+		tokenRepo:   tokenRepo,
+		userRepo:    userRepo,
+		roleQueries: roleQueries,
+		authService: authService,
+	}
+}
+
+// CreateToken issues a personal access token for the user and returns its plaintext value.
+// AI-hint: Business rules - tokens must be created from a session login (a token cannot
+// mint further tokens), and every scope must be a known permission the user's role grants.
+// The token is only two-factor verified if the creating session was.
+func (s *AccessTokenService) CreateToken(ctx interface{}, userID, name string, scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return nil, "", domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	role, err := s.roleQueries.GetRoleByID(context, user.RoleID)
+	if err != nil {
+		return nil, "", err
+	}
+	userCtx := &auth.UserContext{UserID: user.ID, RoleName: role.Name, MFAVerified: auth.MFAVerifiedFromContext(context)}
+
+	for _, scope := range scopes {
+		permission, err := auth.ParsePermission(scope)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidTokenScope, scope)
+		}
+		if !s.authService.CanPerform(userCtx, permission) {
+			return nil, "", fmt.Errorf("%w: your role does not grant %q", domain.ErrInvalidTokenScope, scope)
+		}
+	}
+
+	token, plaintext, err := domain.NewPersonalAccessToken(user.ID, name, scopes, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	token.MFAVerified = userCtx.MFAVerified
+
+	if err := s.tokenRepo.Create(context, token); err != nil {
+		return nil, "", err
+	}
+
+	return token, plaintext, nil
+}
+
+// ListTokens returns the user's personal access tokens without their secrets.
+func (s *AccessTokenService) ListTokens(ctx interface{}, userID string) ([]*domain.PersonalAccessToken, error) {
+	context := ctx.(context.Context)
+	return s.tokenRepo.ListByUserID(context, userID)
+}
+
+// RevokeToken deletes one of the user's personal access tokens.
+// AI-hint: Like creation, revocation requires a session login.
+func (s *AccessTokenService) RevokeToken(ctx interface{}, userID, tokenID string) error {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return domain.ErrUnauthorized
+	}
+
+	return s.tokenRepo.Delete(context, tokenID, userID)
+}
+
+// Authenticate resolves a presented personal access token.
+// AI-hint: Returns ErrInvalidAccessToken for unknown tokens and ErrAccessTokenExpired
+// for expired ones; successful lookups update last-used tracking.
+func (s *AccessTokenService) Authenticate(ctx interface{}, plaintext string) (*domain.PersonalAccessToken, error) {
+	context := ctx.(context.Context)
+
+	token, err := s.tokenRepo.GetByHash(context, domain.HashAccessToken(plaintext))
+	if err != nil {
+		if err == domain.ErrAccessTokenNotFound {
+			return nil, domain.ErrInvalidAccessToken
+		}
+		return nil, err
+	}
+
+	if token.IsExpired() {
+		return nil, domain.ErrAccessTokenExpired
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(context, token.ID); err != nil {
+		log.Printf("Warning: failed to record use of access token %s: %v", token.ID, err)
+	}
+
+	return token, nil
+}
+
+// TokenPermissions converts a token's scopes into permissions for authorization checks.
+// AI-hint: Scopes naming permissions that no longer exist are dropped, never widened.
+func TokenPermissions(token *domain.PersonalAccessToken) []auth.Permission {
+	permissions := make([]auth.Permission, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		if permission, err := auth.ParsePermission(scope); err == nil {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
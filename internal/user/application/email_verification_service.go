@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/mail"
+	"feedback_hub_2/internal/user/domain"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// EmailVerificationService proves that users own the email address they registered with.
+// AI-hint: Application service behind registration mails, /auth/verify-email, and
+// /auth/verify-email/resend. Whether unverified users are restricted is decided by the
+// idea and comment services (REQUIRE_EMAIL_VERIFICATION), not here.
+type EmailVerificationService struct {
+	verificationRepo domain.EmailVerificationRepository
+	userRepo         domain.Repository
+	mailer           mail.Mailer
+	verifyURL        string
+	verifyTTL        time.Duration
+}
+
+// NewEmailVerificationService creates a new EmailVerificationService instance.
+// AI-hint: verifyURL is the page the mailed link opens; a non-positive verifyTTL falls
+// back to domain.DefaultEmailVerificationTTL.
+func NewEmailVerificationService(verificationRepo domain.EmailVerificationRepository, userRepo domain.Repository, mailer mail.Mailer, verifyURL string, verifyTTL time.Duration) *EmailVerificationService {
+	if verifyTTL <= 0 {
+		verifyTTL = domain.DefaultEmailVerificationTTL
+	}
+	return &EmailVerificationService{
+		verificationRepo: verificationRepo,
+		userRepo:         userRepo,
+		mailer:           mailer,
+		verifyURL:        verifyURL,
+		verifyTTL:        verifyTTL,
+	}
+}
+
+// SendVerification mails a verification link to the user's current address.
+// AI-hint: Returns ErrEmailAlreadyVerified for verified users. Only the newest link is valid.
+func (s *EmailVerificationService) SendVerification(ctx interface{}, userID string) error {
+	context := ctx.(context.Context)
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	if err := s.verificationRepo.InvalidateForUser(context, user.ID); err != nil {
+		return err
+	}
+
+	token, plaintext, err := domain.NewEmailVerificationToken(user.ID, user.Email, s.verifyTTL)
+	if err != nil {
+		return err
+	}
+	if err := s.verificationRepo.Create(context, token); err != nil {
+		return err
+	}
+
+	link, err := url.Parse(s.verifyURL)
+	if err != nil {
+		return fmt.Errorf("invalid email verification URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", plaintext)
+	link.RawQuery = query.Encode()
+
+	return s.mailer.Send(context, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your Feedback Hub email address",
+		Body: fmt.Sprintf("Hi %s,\n\nplease confirm that this is your email address by opening this link:\n\n%s\n\n"+
+			"The link expires in %s. If you did not create a Feedback Hub account, you can ignore this email.\n",
+			user.Name, link.String(), s.verifyTTL),
+	})
+}
+
+// VerifyEmail marks the user's email address as verified using a verification token.
+// AI-hint: Fails with ErrInvalidEmailVerificationToken when the token is unknown, used,
+// expired, or was sent to an address the user no longer has.
+func (s *EmailVerificationService) VerifyEmail(ctx interface{}, plaintext string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	token, err := s.verificationRepo.GetByHash(context, domain.HashEmailVerificationToken(plaintext))
+	if err != nil {
+		if err == domain.ErrEmailVerificationTokenNotFound {
+			return nil, domain.ErrInvalidEmailVerificationToken
+		}
+		return nil, err
+	}
+	if !token.IsUsable() {
+		return nil, domain.ErrInvalidEmailVerificationToken
+	}
+
+	user, err := s.userRepo.GetByID(context, token.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, domain.ErrInvalidEmailVerificationToken
+		}
+		return nil, err
+	}
+	if !token.MatchesEmail(user.Email) {
+		return nil, domain.ErrInvalidEmailVerificationToken
+	}
+
+	if err := s.verificationRepo.MarkUsed(context, token.ID); err != nil {
+		return nil, err
+	}
+
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(context, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
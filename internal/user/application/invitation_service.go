@@ -0,0 +1,310 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/auth"
+	"feedback_hub_2/internal/shared/mail"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// InvitationService lets users created by an administrator choose their password.
+// AI-hint: Application service behind the invitation mail, /auth/invitations/{token}, and
+// /invitations. Administrators only see and manage invitations for users whose role they
+// could have created themselves (see auth.AuthorizationService.CanCreateUserWithRole).
+type InvitationService struct {
+	invitationRepo domain.InvitationRepository
+	userRepo       domain.Repository
+	roleQueries    queries.RoleQueries
+	authService    *auth.AuthorizationService
+	mailer         mail.Mailer
+	acceptURL      string
+	inviteTTL      time.Duration
+}
+
+// NewInvitationService creates a new InvitationService instance.
+// AI-hint: acceptURL is the page the mailed link opens; a non-positive inviteTTL falls
+// back to domain.DefaultInvitationTTL.
+func NewInvitationService(invitationRepo domain.InvitationRepository, userRepo domain.Repository, roleQueries queries.RoleQueries, authService *auth.AuthorizationService, mailer mail.Mailer, acceptURL string, inviteTTL time.Duration) *InvitationService {
+	if inviteTTL <= 0 {
+		inviteTTL = domain.DefaultInvitationTTL
+	}
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		roleQueries:    roleQueries,
+		authService:    authService,
+		mailer:         mailer,
+		acceptURL:      acceptURL,
+		inviteTTL:      inviteTTL,
+	}
+}
+
+// InvitationDetails is a pending invitation together with the invited user.
+type InvitationDetails struct {
+	Invitation *domain.Invitation
+	User       *domain.User
+	RoleName   string
+}
+
+// Invite creates an invitation for a user without a password and mails it to them.
+// AI-hint: Called right after UserService.CreateUser. The invitation is stored before the
+// mail is sent, so a delivery failure can be fixed with Resend.
+func (s *InvitationService) Invite(ctx interface{}, userID, invitedByUserID string) (*domain.Invitation, error) {
+	context := ctx.(context.Context)
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(context, user, invitedByUserID); err != nil {
+		return nil, err
+	}
+	if user.PasswordHash != "" {
+		return nil, domain.ErrInvalidInvitation
+	}
+
+	invitation, plaintext, err := domain.NewInvitation(user.ID, user.Email, invitedByUserID, s.inviteTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.Create(context, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, s.send(context, user, plaintext)
+}
+
+// ListPending returns the invitations the requesting user may manage, oldest first.
+// AI-hint: Includes expired invitations so they can be resent.
+func (s *InvitationService) ListPending(ctx interface{}, requestedByUserID string) ([]*InvitationDetails, error) {
+	context := ctx.(context.Context)
+
+	userCtx, err := s.getUserContext(context, requestedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.authService.CanPerform(userCtx, auth.PermissionCreateUser) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	invitations, err := s.invitationRepo.ListPending(context)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*InvitationDetails, 0, len(invitations))
+	for _, invitation := range invitations {
+		user, err := s.userRepo.GetByID(context, invitation.UserID)
+		if err != nil {
+			if err == domain.ErrUserNotFound {
+				continue
+			}
+			return nil, err
+		}
+		role, err := s.roleQueries.GetRoleByID(context, user.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		if !s.authService.CanCreateUserWithRole(userCtx, role.Name) {
+			continue
+		}
+		details = append(details, &InvitationDetails{Invitation: invitation, User: user, RoleName: role.Name})
+	}
+
+	return details, nil
+}
+
+// Resend mails a pending invitation again with a new token and expiry.
+// AI-hint: The previously mailed link stops working.
+func (s *InvitationService) Resend(ctx interface{}, invitationID, requestedByUserID string) (*domain.Invitation, error) {
+	context := ctx.(context.Context)
+
+	invitation, user, err := s.getManaged(context, invitationID, requestedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !invitation.IsPending() {
+		return nil, domain.ErrInvitationNotPending
+	}
+
+	plaintext, err := invitation.Renew(s.inviteTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.Renew(context, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, s.send(context, user, plaintext)
+}
+
+// Revoke cancels a pending invitation so its link no longer works.
+// AI-hint: The user is kept; delete them through UserService if they should not get access.
+func (s *InvitationService) Revoke(ctx interface{}, invitationID, requestedByUserID string) error {
+	context := ctx.(context.Context)
+
+	if _, _, err := s.getManaged(context, invitationID, requestedByUserID); err != nil {
+		return err
+	}
+	return s.invitationRepo.Revoke(context, invitationID)
+}
+
+// UserForToken returns the user a usable invitation belongs to without accepting it.
+// AI-hint: Lets the accept page greet the invitee and lets the caller check the password
+// against the policy, which needs the user's email and name, before calling Accept.
+func (s *InvitationService) UserForToken(ctx interface{}, plaintext string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	_, user, err := s.lookupToken(context, plaintext)
+	return user, err
+}
+
+// Accept sets the invitee's first password and verifies their email address.
+// AI-hint: The invitation is consumed together with the password change, so a link can
+// never be used twice and a failed save leaves it usable. Receiving the mail proves the
+// invitee owns the address.
+func (s *InvitationService) Accept(ctx interface{}, plaintext, passwordHash string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	invitation, user, err := s.lookupToken(context, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.UpdatePasswordHash(passwordHash); err != nil {
+		return nil, err
+	}
+	user.MarkEmailVerified()
+
+	if err := s.invitationRepo.Accept(context, invitation.ID, user); err != nil {
+		if err == domain.ErrInvitationNotPending {
+			return nil, domain.ErrInvalidInvitation
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// lookupToken resolves a plaintext invitation token to the invitation and its user.
+// AI-hint: Unknown, accepted, revoked, and expired invitations, deleted users, users who
+// already have a password, and users whose email changed all yield ErrInvalidInvitation.
+func (s *InvitationService) lookupToken(ctx context.Context, plaintext string) (*domain.Invitation, *domain.User, error) {
+	invitation, err := s.invitationRepo.GetByHash(ctx, domain.HashInvitationToken(plaintext))
+	if err != nil {
+		if err == domain.ErrInvitationNotFound {
+			return nil, nil, domain.ErrInvalidInvitation
+		}
+		return nil, nil, err
+	}
+	if !invitation.IsUsable() {
+		return nil, nil, domain.ErrInvalidInvitation
+	}
+
+	user, err := s.userRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, nil, domain.ErrInvalidInvitation
+		}
+		return nil, nil, err
+	}
+	if user.PasswordHash != "" || !invitation.MatchesEmail(user.Email) {
+		return nil, nil, domain.ErrInvalidInvitation
+	}
+
+	return invitation, user, nil
+}
+
+// getManaged loads an invitation and its user, checking that the requesting user may manage it.
+func (s *InvitationService) getManaged(ctx context.Context, invitationID, requestedByUserID string) (*domain.Invitation, *domain.User, error) {
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, nil, domain.ErrInvitationNotFound
+		}
+		return nil, nil, err
+	}
+	if err := s.authorize(ctx, user, requestedByUserID); err != nil {
+		return nil, nil, err
+	}
+
+	return invitation, user, nil
+}
+
+// authorize checks that the requesting user could have created the invited user.
+func (s *InvitationService) authorize(ctx context.Context, invitee *domain.User, requestedByUserID string) error {
+	userCtx, err := s.getUserContext(ctx, requestedByUserID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.roleQueries.GetRoleByID(ctx, invitee.RoleID)
+	if err != nil {
+		return err
+	}
+	if !s.authService.CanCreateUserWithRole(userCtx, role.Name) {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// send mails the invitation link to the invitee.
+func (s *InvitationService) send(ctx context.Context, user *domain.User, plaintext string) error {
+	link, err := url.Parse(s.acceptURL)
+	if err != nil {
+		return fmt.Errorf("invalid invitation URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", plaintext)
+	link.RawQuery = query.Encode()
+
+	return s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "You have been invited to Feedback Hub",
+		Body: fmt.Sprintf("Hi %s,\n\nan account has been created for you on Feedback Hub. "+
+			"Open this link to choose your password:\n\n%s\n\n"+
+			"The link expires in %s. If you were not expecting this invitation, you can ignore this email.\n",
+			user.Name, link.String(), s.inviteTTL),
+	})
+}
+
+// getUserContext retrieves the user context for authorization.
+// AI-hint: Helper method to build authorization context from user ID.
+func (s *InvitationService) getUserContext(ctx context.Context, userID string) (*auth.UserContext, error) {
+	if userID == "" {
+		return nil, auth.ErrInvalidContext
+	}
+
+	// Reuse the caller resolved by the auth middleware
+	if userCtx, ok := auth.UserContextFor(ctx, userID); ok {
+		return userCtx, nil
+	}
+
+	userEntity, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRole, err := s.roleQueries.GetRoleByID(ctx, userEntity.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UserContext{
+		UserID:      userID,
+		RoleID:      userRole.ID,
+		RoleName:    userRole.Name,
+		Scopes:      auth.ScopesFromContext(ctx),
+		MFAVerified: auth.MFAVerifiedFromContext(ctx),
+	}, nil
+}
@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/auth"
+	events "feedback_hub_2/internal/shared/bus"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"time"
+)
+
+// LoginThrottleService slows down and locks out repeated failed logins.
+// AI-hint: Application service used by AuthHandler.Login. Failures are counted per
+// account (login email) and per client IP with separate policies; each failure adds an
+// exponential backoff and reaching the limit locks the key temporarily. Lockouts and
+// admin unlocks are published as domain events.
+type LoginThrottleService struct {
+	store          domain.LoginAttemptStore
+	userRepo       domain.Repository
+	roleQueries    queries.RoleQueries
+	authService    *auth.AuthorizationService
+	eventPublisher events.EventPublisher
+	accountPolicy  domain.LoginThrottlePolicy
+	ipPolicy       domain.LoginThrottlePolicy
+}
+
+// NewLoginThrottleService creates a new LoginThrottleService instance.
+// AI-hint: A policy with MaxFailures 0 disables throttling for that kind of key.
+func NewLoginThrottleService(store domain.LoginAttemptStore, userRepo domain.Repository, roleQueries queries.RoleQueries, authService *auth.AuthorizationService, eventPublisher events.EventPublisher, accountPolicy, ipPolicy domain.LoginThrottlePolicy) *LoginThrottleService {
+	return &LoginThrottleService{
+		store:          store,
+		userRepo:       userRepo,
+		roleQueries:    roleQueries,
+		authService:    authService,
+		eventPublisher: eventPublisher,
+		accountPolicy:  accountPolicy,
+		ipPolicy:       ipPolicy,
+	}
+}
+
+// CheckLogin reports whether a login attempt for email from ip may proceed.
+// AI-hint: Returns how long the client must wait together with ErrAccountLocked or
+// ErrTooManyLoginAttempts; call before checking the password so locked accounts cannot
+// be probed. An empty email only checks the IP.
+func (s *LoginThrottleService) CheckLogin(ctx interface{}, email, ip string) (time.Duration, error) {
+	context := ctx.(context.Context)
+	now := time.Now()
+
+	if s.ipPolicy.Enabled() && ip != "" {
+		attempts, err := s.store.Get(context, domain.IPLoginKey(ip))
+		if err != nil {
+			return 0, err
+		}
+		if wait := s.ipPolicy.RetryAfter(attempts, now); wait > 0 {
+			return wait, domain.ErrTooManyLoginAttempts
+		}
+	}
+
+	if s.accountPolicy.Enabled() && email != "" {
+		attempts, err := s.store.Get(context, domain.AccountLoginKey(email))
+		if err != nil {
+			return 0, err
+		}
+		if wait := s.accountPolicy.RetryAfter(attempts, now); wait > 0 {
+			if attempts.IsLocked(now) {
+				return wait, domain.ErrAccountLocked
+			}
+			return wait, domain.ErrTooManyLoginAttempts
+		}
+	}
+
+	return 0, nil
+}
+
+// RecordFailure counts a failed login for email from ip and locks keys that reach their limit.
+// AI-hint: Errors are logged rather than returned so a store outage never turns a wrong
+// password into a different response. An empty email only counts against the IP.
+func (s *LoginThrottleService) RecordFailure(ctx interface{}, email, ip string) {
+	context := ctx.(context.Context)
+	now := time.Now()
+
+	if s.ipPolicy.Enabled() && ip != "" {
+		if locked, attempts := s.recordFailure(context, s.ipPolicy, domain.IPLoginKey(ip), now); locked {
+			log.Printf("Warning: locking out IP %s after %d failed logins", ip, attempts.Failures)
+			event := events.NewLoginIPLockedOutEvent(ip, attempts.Failures, *attempts.LockedUntil)
+			if err := s.eventPublisher.PublishEvent(context, event); err != nil {
+				log.Printf("Warning: failed to publish IP locked out event: %v", err)
+			}
+		}
+	}
+
+	if s.accountPolicy.Enabled() && email != "" {
+		if locked, attempts := s.recordFailure(context, s.accountPolicy, domain.AccountLoginKey(email), now); locked {
+			// Unknown emails are locked as well, but only real accounts get an event
+			user, err := s.userRepo.GetByEmail(context, email)
+			if err != nil {
+				return
+			}
+			log.Printf("Warning: locking out user %s after %d failed logins", user.ID, attempts.Failures)
+			event := events.NewUserLockedOutEvent(user.ID, user.Email, attempts.Failures, *attempts.LockedUntil)
+			if err := s.eventPublisher.PublishEvent(context, event); err != nil {
+				log.Printf("Warning: failed to publish user locked out event: %v", err)
+			}
+		}
+	}
+}
+
+// RecordSuccess clears the account's failed logins after a successful login.
+// AI-hint: For accounts with two-factor authentication, call only once the second step
+// succeeded, otherwise the password alone would reset the limit on code guesses. The IP
+// count is kept, otherwise one valid account would let an attacker reset the per-IP
+// limit while spraying other accounts.
+func (s *LoginThrottleService) RecordSuccess(ctx interface{}, email string) {
+	context := ctx.(context.Context)
+
+	if err := s.store.Reset(context, domain.AccountLoginKey(email)); err != nil {
+		log.Printf("Warning: failed to reset failed logins for %s: %v", email, err)
+	}
+}
+
+// UnlockUser lifts a lockout and forgets the failed logins of a user's account.
+// AI-hint: Requires the user:unlock permission (Super Users). Unlocking an account that
+// is not locked is not an error.
+func (s *LoginThrottleService) UnlockUser(ctx interface{}, userID, unlockedByUserID string) error {
+	context := ctx.(context.Context)
+
+	userCtx, err := s.getUserContext(context, unlockedByUserID)
+	if err != nil {
+		return err
+	}
+	if !s.authService.CanPerform(userCtx, auth.PermissionUnlockUser) {
+		return domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Reset(context, domain.AccountLoginKey(user.Email)); err != nil {
+		return err
+	}
+
+	event := events.NewUserUnlockedEvent(user.ID, unlockedByUserID)
+	if err := s.eventPublisher.PublishEvent(context, event); err != nil {
+		log.Printf("Warning: failed to publish user unlocked event: %v", err)
+	}
+
+	return nil
+}
+
+// recordFailure counts one failure for the key and locks it when the policy says so.
+// AI-hint: Returns true only for the failure that caused the lock.
+func (s *LoginThrottleService) recordFailure(ctx context.Context, policy domain.LoginThrottlePolicy, key string, now time.Time) (bool, *domain.LoginAttempts) {
+	attempts, err := s.store.RecordFailure(ctx, key, now, policy.FailureRetention)
+	if err != nil {
+		log.Printf("Warning: failed to record failed login for %s: %v", key, err)
+		return false, nil
+	}
+	if !policy.ShouldLock(attempts, now) {
+		return false, attempts
+	}
+
+	until := now.Add(policy.LockoutDuration)
+	if err := s.store.Lock(ctx, key, until); err != nil {
+		log.Printf("Warning: failed to lock %s: %v", key, err)
+		return false, attempts
+	}
+	attempts.LockedUntil = &until
+	return true, attempts
+}
+
+// getUserContext retrieves the user context for authorization.
+// AI-hint: Helper method to build authorization context from user ID.
+func (s *LoginThrottleService) getUserContext(ctx context.Context, userID string) (*auth.UserContext, error) {
+	if userID == "" {
+		return nil, auth.ErrInvalidContext
+	}
+
+	// Reuse the caller resolved by the auth middleware
+	if userCtx, ok := auth.UserContextFor(ctx, userID); ok {
+		return userCtx, nil
+	}
+
+	userEntity, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRole, err := s.roleQueries.GetRoleByID(ctx, userEntity.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UserContext{
+		UserID:      userID,
+		RoleID:      userRole.ID,
+		RoleName:    userRole.Name,
+		Scopes:      auth.ScopesFromContext(ctx),
+		MFAVerified: auth.MFAVerifiedFromContext(ctx),
+	}, nil
+}
@@ -0,0 +1,355 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/auth"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"time"
+)
+
+// MFAService manages TOTP two-factor authentication and the second login step.
+// AI-hint: Application service behind /auth/mfa/* and /auth/login/mfa. Whether a role
+// must use two-factor authentication is decided by the AuthorizationService policy;
+// this service only refuses to let such users switch it off.
+type MFAService struct {
+	mfaRepo       domain.MFARepository
+	challengeRepo domain.MFAChallengeRepository
+	userRepo      domain.Repository
+	sessionRepo   domain.SessionRepository
+	roleQueries   queries.RoleQueries
+	authService   *auth.AuthorizationService
+	issuer        string
+}
+
+// MFAStatus summarizes a user's two-factor authentication setup.
+type MFAStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// NewMFAService creates a new MFAService instance.
+// AI-hint: issuer is the account label shown in authenticator apps (MFA_ISSUER).
+func NewMFAService(mfaRepo domain.MFARepository, challengeRepo domain.MFAChallengeRepository, userRepo domain.Repository, sessionRepo domain.SessionRepository, roleQueries queries.RoleQueries, authService *auth.AuthorizationService, issuer string) *MFAService {
+	return &MFAService{
+		mfaRepo:       mfaRepo,
+		challengeRepo: challengeRepo,
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		roleQueries:   roleQueries,
+		authService:   authService,
+		issuer:        issuer,
+	}
+}
+
+// Status returns whether the user has two-factor authentication enabled and must have it.
+func (s *MFAService) Status(ctx interface{}, userID string) (*MFAStatus, error) {
+	context := ctx.(context.Context)
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MFAStatus{}
+	if status.Required, err = s.RoleRequiresMFA(context, user); err != nil {
+		return nil, err
+	}
+	if status.Enabled, err = s.IsEnabled(context, user.ID); err != nil {
+		return nil, err
+	}
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = s.mfaRepo.CountRecoveryCodes(context, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment generates a new TOTP secret for the user.
+// AI-hint: Returns the credential and its otpauth:// provisioning URI for a QR code.
+// The credential stays inactive until ConfirmEnrollment; calling this again replaces an
+// unconfirmed secret. Requires a session login.
+func (s *MFAService) BeginEnrollment(ctx interface{}, userID string) (*domain.TOTPCredential, string, error) {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return nil, "", domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	enabled, err := s.IsEnabled(context, user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if enabled {
+		return nil, "", domain.ErrTOTPAlreadyEnabled
+	}
+
+	credential, err := domain.NewTOTPCredential(user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.mfaRepo.SaveTOTPCredential(context, credential); err != nil {
+		return nil, "", err
+	}
+
+	return credential, credential.ProvisioningURI(s.issuer, user.Email), nil
+}
+
+// ConfirmEnrollment activates the user's TOTP credential and returns their recovery codes.
+// AI-hint: The code proves the authenticator app works. The current session (sessionID,
+// may be empty) counts as two-factor verified from then on, so a user required to enroll
+// does not have to log in again.
+func (s *MFAService) ConfirmEnrollment(ctx interface{}, userID, sessionID, code string) ([]string, error) {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	credential, err := s.mfaRepo.GetTOTPCredential(context, userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential.IsConfirmed() {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+
+	step, ok := credential.MatchCode(code, time.Now())
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+	credential.LastUsedStep = step
+	credential.Confirm()
+	if err := s.mfaRepo.SaveTOTPCredential(context, credential); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(context, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessionID != "" {
+		if err := s.sessionRepo.MarkMFAVerified(context, sessionID); err != nil {
+			log.Printf("Warning: failed to mark session %s as two-factor verified: %v", sessionID, err)
+		}
+	}
+
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication after checking a current code.
+// AI-hint: Business rule - refused with ErrMFARequiredByPolicy when the user's role
+// must use two-factor authentication. Requires a session login.
+func (s *MFAService) Disable(ctx interface{}, userID, code string) error {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(context, userID)
+	if err != nil {
+		return err
+	}
+	required, err := s.RoleRequiresMFA(context, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.ErrMFARequiredByPolicy
+	}
+
+	if err := s.verifyCode(context, user.ID, code); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.DeleteTOTPCredential(context, user.ID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a current code.
+// AI-hint: All previous codes stop working. Requires a session login.
+func (s *MFAService) RegenerateRecoveryCodes(ctx interface{}, userID, code string) ([]string, error) {
+	context := ctx.(context.Context)
+
+	if auth.ScopesFromContext(context) != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := s.verifyCode(context, userID, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(context, userID)
+}
+
+// IsEnabled reports whether the user has a confirmed TOTP credential.
+func (s *MFAService) IsEnabled(ctx interface{}, userID string) (bool, error) {
+	context := ctx.(context.Context)
+
+	credential, err := s.mfaRepo.GetTOTPCredential(context, userID)
+	if err != nil {
+		if err == domain.ErrTOTPNotEnrolled {
+			return false, nil
+		}
+		return false, err
+	}
+	return credential.IsConfirmed(), nil
+}
+
+// RoleRequiresMFA reports whether the user's role must use two-factor authentication.
+func (s *MFAService) RoleRequiresMFA(ctx interface{}, user *domain.User) (bool, error) {
+	context := ctx.(context.Context)
+
+	role, err := s.roleQueries.GetRoleByID(context, user.RoleID)
+	if err != nil {
+		return false, err
+	}
+	return s.authService.RequiresMFA(role.Name), nil
+}
+
+// StartChallenge issues the token for the second login step.
+// AI-hint: Called by login after the password check for users with two-factor
+// authentication enabled; no session exists until CompleteChallenge succeeds.
+func (s *MFAService) StartChallenge(ctx interface{}, userID string) (string, time.Time, error) {
+	context := ctx.(context.Context)
+
+	challenge, token, err := domain.NewMFAChallenge(userID, domain.DefaultMFAChallengeTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := s.challengeRepo.Create(context, challenge); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, challenge.ExpiresAt, nil
+}
+
+// CompleteChallenge checks a TOTP or recovery code against a login challenge.
+// AI-hint: Returns the user on success and deletes the challenge. Wrong codes return
+// ErrInvalidMFACode and may be retried until MaxMFAChallengeAttempts is exceeded, after
+// which the challenge is deleted and the user must log in with their password again.
+func (s *MFAService) CompleteChallenge(ctx interface{}, token, code string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	challengeID, err := domain.ParseMFAChallengeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.challengeRepo.GetByID(context, challengeID)
+	if err != nil {
+		if err == domain.ErrMFAChallengeNotFound {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+	if !challenge.MatchesToken(token) {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+	if challenge.IsExpired() {
+		s.deleteChallenge(context, challenge.ID)
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	attempts, err := s.challengeRepo.RecordAttempt(context, challenge.ID)
+	if err != nil {
+		if err == domain.ErrMFAChallengeNotFound {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+	if attempts > domain.MaxMFAChallengeAttempts {
+		s.deleteChallenge(context, challenge.ID)
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	if err := s.verifyCode(context, challenge.UserID, code); err != nil {
+		if err == domain.ErrTOTPNotEnrolled {
+			// Two-factor authentication was disabled after the challenge was issued
+			s.deleteChallenge(context, challenge.ID)
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+
+	s.deleteChallenge(context, challenge.ID)
+
+	return s.userRepo.GetByID(context, challenge.UserID)
+}
+
+// ChallengeUser returns the user a pending login challenge was issued to.
+// AI-hint: Does not count as an attempt; used to apply the account's login throttle
+// before the code is checked. Unknown, mismatched, and expired tokens return
+// ErrInvalidMFAChallenge.
+func (s *MFAService) ChallengeUser(ctx interface{}, token string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	challengeID, err := domain.ParseMFAChallengeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.challengeRepo.GetByID(context, challengeID)
+	if err != nil {
+		if err == domain.ErrMFAChallengeNotFound {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+	if !challenge.MatchesToken(token) || challenge.IsExpired() {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	return s.userRepo.GetByID(context, challenge.UserID)
+}
+
+// verifyCode accepts a current TOTP code or an unused recovery code for the user.
+// AI-hint: Either kind of code is consumed, so it cannot be used a second time.
+func (s *MFAService) verifyCode(ctx context.Context, userID, code string) error {
+	credential, err := s.mfaRepo.GetTOTPCredential(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !credential.IsConfirmed() {
+		return domain.ErrTOTPNotEnrolled
+	}
+
+	if domain.IsRecoveryCode(code) {
+		return s.mfaRepo.UseRecoveryCode(ctx, userID, domain.HashRecoveryCode(code))
+	}
+
+	step, ok := credential.MatchCode(code, time.Now())
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+	return s.mfaRepo.RecordTOTPStep(ctx, userID, step)
+}
+
+// replaceRecoveryCodes generates and stores a new set of recovery codes.
+func (s *MFAService) replaceRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, hashes, err := domain.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// deleteChallenge removes a challenge that can no longer be used.
+func (s *MFAService) deleteChallenge(ctx context.Context, challengeID string) {
+	if err := s.challengeRepo.Delete(ctx, challengeID); err != nil {
+		log.Printf("Warning: failed to delete MFA challenge %s: %v", challengeID, err)
+	}
+}
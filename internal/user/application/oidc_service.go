@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	events "feedback_hub_2/internal/shared/bus"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCService handles single sign-on through OpenID Connect providers.
+// AI-hint: Application service for the authorization code flow with PKCE. A provider
+// account is matched to a user by its linked identity first, then by verified email
+// (which links it), and otherwise a new user is provisioned with the default role.
+type OIDCService struct {
+	providers       map[string]domain.IdentityProvider
+	loginRepo       domain.OIDCLoginRepository
+	identityRepo    domain.ExternalIdentityRepository
+	userRepo        domain.Repository
+	sessionRepo     domain.SessionRepository
+	roleQueries     queries.RoleQueries
+	eventPublisher  events.EventPublisher
+	defaultRoleName string
+	autoProvision   bool
+}
+
+// NewOIDCService creates a new OIDCService instance.
+// AI-hint: providers maps the name used in /auth/oidc/{provider}/... URLs to its client.
+// With autoProvision false, only existing users can log in through a provider.
+func NewOIDCService(providers map[string]domain.IdentityProvider, loginRepo domain.OIDCLoginRepository, identityRepo domain.ExternalIdentityRepository, userRepo domain.Repository, sessionRepo domain.SessionRepository, roleQueries queries.RoleQueries, eventPublisher events.EventPublisher, defaultRoleName string, autoProvision bool) *OIDCService {
+	return &OIDCService{
+		providers:       providers,
+		loginRepo:       loginRepo,
+		identityRepo:    identityRepo,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		roleQueries:     roleQueries,
+		eventPublisher:  eventPublisher,
+		defaultRoleName: defaultRoleName,
+		autoProvision:   autoProvision,
+	}
+}
+
+// ProviderNames returns the configured provider names in alphabetical order.
+func (s *OIDCService) ProviderNames() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartLogin begins a login with the named provider.
+// AI-hint: Returns the provider URL to redirect the browser to and the state, which the
+// caller must also bind to the browser (e.g. in a cookie) to prevent login CSRF.
+func (s *OIDCService) StartLogin(ctx interface{}, providerName string) (string, string, error) {
+	context := ctx.(context.Context)
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", domain.ErrUnknownIdentityProvider
+	}
+
+	login, state, err := domain.NewOIDCLogin(providerName, domain.DefaultOIDCLoginTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthorizationURL(context, state, login.Nonce, login.CodeChallenge())
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.loginRepo.Create(context, login); err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// CompleteLogin finishes a login from the provider's callback and returns the user.
+// AI-hint: The state is consumed even when the login fails, so every callback URL works
+// at most once. Also returns the provider identity so callers can check UsedMFA.
+func (s *OIDCService) CompleteLogin(ctx interface{}, providerName, state, code string) (*domain.User, *domain.OIDCIdentity, error) {
+	context := ctx.(context.Context)
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, nil, domain.ErrUnknownIdentityProvider
+	}
+
+	login, err := s.loginRepo.Consume(context, domain.HashOIDCState(state))
+	if err != nil {
+		if err == domain.ErrOIDCLoginNotFound {
+			return nil, nil, domain.ErrInvalidOIDCLogin
+		}
+		return nil, nil, err
+	}
+	if login.IsExpired() || login.Provider != providerName {
+		return nil, nil, domain.ErrInvalidOIDCLogin
+	}
+
+	identity, err := provider.Exchange(context, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("login with %s failed: %w", providerName, err)
+	}
+
+	user, err := s.resolveUser(context, providerName, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, identity, nil
+}
+
+// resolveUser finds, links, or provisions the user for a provider identity.
+// AI-hint: Linking and provisioning require the provider to have verified the email. An
+// existing account whose own email was never verified loses its password and sessions
+// when linked, because whoever registered it did not prove they own the address.
+func (s *OIDCService) resolveUser(ctx context.Context, providerName string, identity *domain.OIDCIdentity) (*domain.User, error) {
+	now := time.Now()
+
+	linked, err := s.identityRepo.GetByProviderSubject(ctx, providerName, identity.Subject)
+	if err == nil {
+		if err := s.identityRepo.RecordLogin(ctx, providerName, identity.Subject, identity.Email, now); err != nil {
+			log.Printf("Warning: failed to record %s login for user %s: %v", providerName, linked.UserID, err)
+		}
+		return s.userRepo.GetByID(ctx, linked.UserID)
+	}
+	if err != domain.ErrExternalIdentityNotFound {
+		return nil, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		if !user.IsEmailVerified() {
+			user.PasswordHash = ""
+			user.MarkEmailVerified()
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+			if err := s.sessionRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+				return nil, err
+			}
+			log.Printf("Linked unverified user %s to %s; removed their password and sessions", user.ID, providerName)
+		}
+	case err == domain.ErrUserNotFound:
+		if !s.autoProvision {
+			return nil, domain.ErrOIDCProvisioningDisabled
+		}
+		if user, err = s.provisionUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, &domain.ExternalIdentity{
+		Provider:    providerName,
+		Subject:     identity.Subject,
+		UserID:      user.ID,
+		Email:       identity.Email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUser creates a passwordless user with the default role for a new identity.
+func (s *OIDCService) provisionUser(ctx context.Context, identity *domain.OIDCIdentity) (*domain.User, error) {
+	role, err := s.roleQueries.GetRoleByName(ctx, s.defaultRoleName)
+	if err != nil {
+		return nil, fmt.Errorf("default role %q for single sign-on users: %w", s.defaultRoleName, err)
+	}
+
+	newUser, err := domain.NewUser(uuid.New().String(), identity.Email, identity.DisplayName(), role.ID)
+	if err != nil {
+		return nil, err
+	}
+	newUser.MarkEmailVerified()
+
+	if err := s.userRepo.Create(ctx, newUser); err != nil {
+		return nil, err
+	}
+
+	userCreatedEvent := events.NewUserCreatedEvent(newUser.ID, newUser.Email, newUser.Name, newUser.RoleID, role.Name)
+	if err := s.eventPublisher.PublishEvent(ctx, userCreatedEvent); err != nil {
+		log.Printf("Warning: failed to publish user created event: %v", err)
+	}
+
+	return newUser, nil
+}
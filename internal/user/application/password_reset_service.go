@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/shared/mail"
+	"feedback_hub_2/internal/user/domain"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+)
+
+// PasswordResetService runs the forgot-password flow.
+// AI-hint: Application service behind /auth/password/forgot and /auth/password/reset.
+// Password hashing stays in the interfaces layer (as for registration); this service
+// issues and consumes reset tokens, stores the new hash, and ends existing sessions.
+type PasswordResetService struct {
+	resetRepo   domain.PasswordResetRepository
+	userRepo    domain.Repository
+	sessionRepo domain.SessionRepository
+	mailer      mail.Mailer
+	resetURL    string
+	resetTTL    time.Duration
+}
+
+// NewPasswordResetService creates a new PasswordResetService instance.
+// AI-hint: resetURL is the page the mailed link opens; a non-positive resetTTL falls
+// back to domain.DefaultPasswordResetTTL.
+func NewPasswordResetService(resetRepo domain.PasswordResetRepository, userRepo domain.Repository, sessionRepo domain.SessionRepository, mailer mail.Mailer, resetURL string, resetTTL time.Duration) *PasswordResetService {
+	if resetTTL <= 0 {
+		resetTTL = domain.DefaultPasswordResetTTL
+	}
+	return &PasswordResetService{
+		resetRepo:   resetRepo,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		mailer:      mailer,
+		resetURL:    resetURL,
+		resetTTL:    resetTTL,
+	}
+}
+
+// RequestReset mails a password reset link to the account with the given email.
+// AI-hint: Business rule - unknown emails succeed silently so the endpoint cannot be
+// used to discover accounts. Only the newest link of a user is valid.
+func (s *PasswordResetService) RequestReset(ctx interface{}, email string) error {
+	context := ctx.(context.Context)
+
+	user, err := s.userRepo.GetByEmail(context, email)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.resetRepo.InvalidateForUser(context, user.ID); err != nil {
+		return err
+	}
+
+	token, plaintext, err := domain.NewPasswordResetToken(user.ID, s.resetTTL)
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.Create(context, token); err != nil {
+		return err
+	}
+
+	link, err := s.resetLink(plaintext)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(context, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Feedback Hub password",
+		Body: fmt.Sprintf("Hi %s,\n\nsomeone asked to reset the password for your Feedback Hub account. "+
+			"Open this link to choose a new password:\n\n%s\n\n"+
+			"The link expires in %s and can only be used once. If you did not ask for this, you can ignore this email.\n",
+			user.Name, link, s.resetTTL),
+	})
+}
+
+// UserForToken returns the user a usable reset token belongs to without consuming it.
+// AI-hint: Lets the caller check the new password against the policy, which needs the
+// user's email and name, before hashing it and calling ResetPassword.
+func (s *PasswordResetService) UserForToken(ctx interface{}, plaintext string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	_, user, err := s.lookupToken(context, plaintext)
+	return user, err
+}
+
+// ResetPassword sets a new password using a reset token.
+// AI-hint: Consumes the token before changing the password so a link can never be used
+// twice, then revokes all of the user's sessions so anyone logged in with the old
+// password is signed out.
+func (s *PasswordResetService) ResetPassword(ctx interface{}, plaintext, newPasswordHash string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	token, user, err := s.lookupToken(context, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resetRepo.MarkUsed(context, token.ID); err != nil {
+		return nil, err
+	}
+
+	if err := user.UpdatePasswordHash(newPasswordHash); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(context, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.RevokeAllForUser(context, user.ID); err != nil {
+		log.Printf("Error: password of user %s was reset but their sessions could not be revoked: %v", user.ID, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// lookupToken resolves a plaintext reset token to the stored token and its user.
+// AI-hint: Unknown, used, and expired tokens and deleted users all yield ErrInvalidPasswordResetToken.
+func (s *PasswordResetService) lookupToken(ctx context.Context, plaintext string) (*domain.PasswordResetToken, *domain.User, error) {
+	token, err := s.resetRepo.GetByHash(ctx, domain.HashPasswordResetToken(plaintext))
+	if err != nil {
+		if err == domain.ErrPasswordResetTokenNotFound {
+			return nil, nil, domain.ErrInvalidPasswordResetToken
+		}
+		return nil, nil, err
+	}
+	if !token.IsUsable() {
+		return nil, nil, domain.ErrInvalidPasswordResetToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, nil, domain.ErrInvalidPasswordResetToken
+		}
+		return nil, nil, err
+	}
+
+	return token, user, nil
+}
+
+// resetLink builds the URL mailed to the user with the token as query parameter.
+func (s *PasswordResetService) resetLink(token string) (string, error) {
+	link, err := url.Parse(s.resetURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid password reset URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
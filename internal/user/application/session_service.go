@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"time"
+)
+
+// SessionService manages server-side login sessions and their refresh tokens.
+// AI-hint: Application service behind login, refresh, logout, and the auth middleware.
+// Access tokens are issued by the interfaces layer; this service only decides whether
+// a session may continue.
+type SessionService struct {
+	sessionRepo     domain.SessionRepository
+	userRepo        domain.Repository
+	refreshTokenTTL time.Duration
+}
+
+// NewSessionService creates a new SessionService instance.
+// AI-hint: A non-positive refreshTokenTTL falls back to domain.DefaultRefreshTokenTTL.
+func NewSessionService(sessionRepo domain.SessionRepository, userRepo domain.Repository, refreshTokenTTL time.Duration) *SessionService {
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = domain.DefaultRefreshTokenTTL
+	}
+	return &SessionService{
+		sessionRepo:     sessionRepo,
+		userRepo:        userRepo,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// StartSession creates a session for a user who just authenticated.
+// AI-hint: Returns the plaintext refresh token; it is never stored or retrievable later.
+// mfaVerified is true when the login completed two-factor authentication.
+func (s *SessionService) StartSession(ctx interface{}, userID string, mfaVerified bool) (*domain.Session, string, error) {
+	context := ctx.(context.Context)
+
+	session, refreshToken, err := domain.NewSession(userID, s.refreshTokenTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	session.MFAVerified = mfaVerified
+
+	if err := s.sessionRepo.Create(context, session); err != nil {
+		return nil, "", err
+	}
+
+	return session, refreshToken, nil
+}
+
+// RefreshSession exchanges a refresh token for a rotated one.
+// AI-hint: Business rule - a refresh token is single-use. Presenting a token that was
+// already rotated means it leaked (or a client misbehaved), so the whole session is
+// revoked and every token derived from it stops working.
+func (s *SessionService) RefreshSession(ctx interface{}, refreshToken string) (*domain.Session, *domain.User, string, error) {
+	context := ctx.(context.Context)
+
+	sessionID, err := domain.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	session, err := s.sessionRepo.GetByID(context, sessionID)
+	if err != nil {
+		if err == domain.ErrSessionNotFound {
+			return nil, nil, "", domain.ErrInvalidRefreshToken
+		}
+		return nil, nil, "", err
+	}
+
+	if !session.IsActive() {
+		return nil, nil, "", domain.ErrSessionRevoked
+	}
+
+	if !session.MatchesRefreshToken(refreshToken) {
+		s.revokeReusedSession(context, session)
+		return nil, nil, "", domain.ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetByID(context, session.UserID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	previousHash := session.RefreshTokenHash
+	newRefreshToken, err := session.Rotate(s.refreshTokenTTL)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if err := s.sessionRepo.Rotate(context, session, previousHash); err != nil {
+		if err == domain.ErrRefreshTokenReused {
+			// Lost a race against another refresh with the same token
+			s.revokeReusedSession(context, session)
+		}
+		return nil, nil, "", err
+	}
+
+	return session, user, newRefreshToken, nil
+}
+
+// ValidateSession checks that an access token's session is still active.
+// AI-hint: Called by the auth middleware on every request so logout and reuse
+// detection take effect before the access token expires. Returns the session so the
+// middleware can see whether the login completed two-factor authentication.
+func (s *SessionService) ValidateSession(ctx interface{}, sessionID, userID string) (*domain.Session, error) {
+	context := ctx.(context.Context)
+
+	if sessionID == "" {
+		return nil, domain.ErrSessionRevoked
+	}
+
+	session, err := s.sessionRepo.GetByID(context, sessionID)
+	if err != nil {
+		if err == domain.ErrSessionNotFound {
+			return nil, domain.ErrSessionRevoked
+		}
+		return nil, err
+	}
+
+	if session.UserID != userID || !session.IsActive() {
+		return nil, domain.ErrSessionRevoked
+	}
+
+	return session, nil
+}
+
+// RevokeSession ends a session so its access and refresh tokens stop working.
+func (s *SessionService) RevokeSession(ctx interface{}, sessionID string) error {
+	context := ctx.(context.Context)
+	return s.sessionRepo.Revoke(context, sessionID)
+}
+
+// RevokeByRefreshToken ends the session a current refresh token belongs to.
+// AI-hint: Used by logout when no valid access token is presented. Stale tokens are
+// rejected with ErrInvalidRefreshToken so a leaked old token cannot log anyone out.
+func (s *SessionService) RevokeByRefreshToken(ctx interface{}, refreshToken string) error {
+	context := ctx.(context.Context)
+
+	sessionID, err := domain.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.sessionRepo.GetByID(context, sessionID)
+	if err != nil {
+		if err == domain.ErrSessionNotFound {
+			return domain.ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	if !session.MatchesRefreshToken(refreshToken) {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	return s.sessionRepo.Revoke(context, session.ID)
+}
+
+// revokeReusedSession revokes a session after refresh token reuse was detected.
+func (s *SessionService) revokeReusedSession(ctx context.Context, session *domain.Session) {
+	log.Printf("Warning: refresh token reuse detected for session %s of user %s; revoking session", session.ID, session.UserID)
+	if err := s.sessionRepo.Revoke(ctx, session.ID); err != nil {
+		log.Printf("Warning: failed to revoke session %s after token reuse: %v", session.ID, err)
+	}
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+	events "feedback_hub_2/internal/shared/bus"
+	"feedback_hub_2/internal/shared/queries"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// superUserRoleName is the role first-run setup grants.
+const superUserRoleName = "Super User"
+
+// SetupService gives the first Super User a password on a fresh installation.
+// AI-hint: Application service behind SUPER_USER_PASSWORD at bootstrap and POST /setup.
+// Setup is marked complete when the first Super User gets a password; from then on every
+// method returns ErrSetupCompleted, even if that Super User is later deleted or demoted,
+// so setup cannot be used to take over the system.
+type SetupService struct {
+	setupRepo      domain.SetupTokenRepository
+	userRepo       domain.Repository
+	roleQueries    queries.RoleQueries
+	eventPublisher events.EventPublisher
+}
+
+// NewSetupService creates a new SetupService instance.
+// AI-hint: Factory method for setup service with dependency injection of repositories and event publisher.
+func NewSetupService(setupRepo domain.SetupTokenRepository, userRepo domain.Repository, roleQueries queries.RoleQueries, eventPublisher events.EventPublisher) *SetupService {
+	return &SetupService{
+		setupRepo:      setupRepo,
+		userRepo:       userRepo,
+		roleQueries:    roleQueries,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// IsComplete reports whether setup has been completed.
+// AI-hint: Reads the persisted marker only; the current Super Users do not matter.
+func (s *SetupService) IsComplete(ctx interface{}) (bool, error) {
+	context := ctx.(context.Context)
+
+	return s.setupRepo.IsCompleted(context)
+}
+
+// IssueToken creates a new setup token, invalidating earlier ones, and returns its plaintext.
+// AI-hint: Called at startup; the caller logs the token for the operator.
+func (s *SetupService) IssueToken(ctx interface{}) (string, error) {
+	context := ctx.(context.Context)
+
+	if complete, err := s.IsComplete(context); err != nil || complete {
+		if err == nil {
+			err = domain.ErrSetupCompleted
+		}
+		return "", err
+	}
+
+	token, plaintext, err := domain.NewSetupToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.setupRepo.Replace(context, token); err != nil {
+		return "", err
+	}
+	return plaintext, nil
+}
+
+// CompleteSetup gives the first Super User a password using a logged setup token.
+// AI-hint: The token is consumed in the same transaction that stores the Super User, so
+// a rejected or failed request does not burn it.
+func (s *SetupService) CompleteSetup(ctx interface{}, plaintext, email, name, passwordHash string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	superUser, isNew, err := s.prepareSuperUser(context, email, name, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveSuperUser(context, domain.HashSetupToken(plaintext), superUser, isNew); err != nil {
+		return nil, err
+	}
+	return superUser, nil
+}
+
+// ConfigureSuperUser gives the first Super User a password from the deployment configuration.
+// AI-hint: Used for SUPER_USER_PASSWORD at bootstrap. Outstanding setup tokens are removed.
+func (s *SetupService) ConfigureSuperUser(ctx interface{}, email, name, passwordHash string) (*domain.User, error) {
+	context := ctx.(context.Context)
+
+	superUser, isNew, err := s.prepareSuperUser(context, email, name, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveSuperUser(context, "", superUser, isNew); err != nil {
+		return nil, err
+	}
+	return superUser, nil
+}
+
+// prepareSuperUser checks that setup is still open and builds the Super User to save.
+// AI-hint: A passwordless Super User with the given email (e.g. created from
+// SUPER_USER_EMAIL by an earlier version) gets the password; any other existing user with
+// that email is an ErrEmailAlreadyExists, because setup never promotes an account.
+// Reports whether the returned user still has to be created.
+func (s *SetupService) prepareSuperUser(ctx context.Context, email, name, passwordHash string) (*domain.User, bool, error) {
+	complete, err := s.IsComplete(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if complete {
+		return nil, false, domain.ErrSetupCompleted
+	}
+
+	role, err := s.roleQueries.GetRoleByName(ctx, superUserRoleName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err == domain.ErrUserNotFound {
+		newUser, err := domain.NewUserWithPassword(uuid.New().String(), email, name, passwordHash, role.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		// The operator proved access to the deployment, so the address is trusted
+		newUser.MarkEmailVerified()
+		return newUser, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if existing.RoleID != role.ID {
+		return nil, false, domain.ErrEmailAlreadyExists
+	}
+
+	if err := existing.UpdateName(name); err != nil {
+		return nil, false, err
+	}
+	if err := existing.UpdatePasswordHash(passwordHash); err != nil {
+		return nil, false, err
+	}
+	existing.MarkEmailVerified()
+	return existing, false, nil
+}
+
+// saveSuperUser stores the Super User built by prepareSuperUser and marks setup complete.
+// AI-hint: An empty tokenHash is used for SUPER_USER_PASSWORD, which needs no token.
+func (s *SetupService) saveSuperUser(ctx context.Context, tokenHash string, superUser *domain.User, isNew bool) error {
+	if err := s.setupRepo.Complete(ctx, tokenHash, superUser, isNew); err != nil {
+		return err
+	}
+
+	if !isNew {
+		log.Printf("Set password of Super User %s", superUser.ID)
+		return nil
+	}
+
+	userCreatedEvent := events.NewUserCreatedEvent(superUser.ID, superUser.Email, superUser.Name, superUser.RoleID, superUserRoleName)
+	if err := s.eventPublisher.PublishEvent(ctx, userCreatedEvent); err != nil {
+		log.Printf("Warning: failed to publish user created event: %v", err)
+	}
+
+	log.Printf("Created Super User with ID: %s", superUser.ID)
+	return nil
+}
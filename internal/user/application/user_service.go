@@ -37,7 +37,8 @@ func NewUserService(userRepo domain.Repository, roleQueries queries.RoleQueries,
 
 // CreateUser creates a new user with authorization and role validation checks.
 // AI-hint: User creation with complex business rules - Super Users can create any user,
-// Product Owners can only create Contributors.
+// Product Owners can only create Contributors. Addresses entered by an administrator
+// count as verified; only self-registration requires email verification.
 func (s *UserService) CreateUser(ctx interface{}, email, name, roleID string, createdByUserID string) (*domain.User, error) {
 	context := ctx.(context.Context)
 
@@ -73,6 +74,7 @@ func (s *UserService) CreateUser(ctx interface{}, email, name, roleID string, cr
 	if err != nil {
 		return nil, err
 	}
+	newUser.MarkEmailVerified()
 
 	if err := s.userRepo.Create(context, newUser); err != nil {
 		return nil, err
@@ -140,7 +142,8 @@ func (s *UserService) UpdateUser(ctx interface{}, id, name string, updatedByUser
 }
 
 // UpdateUserRole updates a user's role with authorization checks.
-// AI-hint: Role assignment with business rule enforcement - only Super Users can change roles.
+// AI-hint: Role assignment requires the user:assign_role permission, which only Super
+// Users hold by default.
 func (s *UserService) UpdateUserRole(ctx interface{}, id, roleID string, updatedByUserID string) (*domain.User, error) {
 	context := ctx.(context.Context)
 
@@ -150,8 +153,8 @@ func (s *UserService) UpdateUserRole(ctx interface{}, id, roleID string, updated
 		return nil, err
 	}
 
-	// Only Super Users can update user roles
-	if userCtx.RoleName != "Super User" {
+	// CanPerform also applies token scopes and the 2FA policy
+	if !s.authService.CanPerform(userCtx, auth.PermissionAssignRole) {
 		return nil, domain.ErrUnauthorized
 	}
 
@@ -195,8 +198,9 @@ func (s *UserService) UpdateUserRole(ctx interface{}, id, roleID string, updated
 }
 
 // DeleteUser deletes a user with authorization checks.
-// AI-hint: User deletion with permission validation.
-func (s *UserService) DeleteUser(ctx interface{}, id string, deletedByUserID string) error {
+// AI-hint: User deletion with permission validation. The user's ideas are reassigned
+// to reassignToUserID, or anonymized when it is empty; they are never deleted.
+func (s *UserService) DeleteUser(ctx interface{}, id, reassignToUserID string, deletedByUserID string) error {
 	context := ctx.(context.Context)
 
 	// Get the user context for authorization
@@ -210,8 +214,21 @@ func (s *UserService) DeleteUser(ctx interface{}, id string, deletedByUserID str
 		return domain.ErrUnauthorized
 	}
 
+	// The reassignment target must be another existing user
+	if reassignToUserID != "" {
+		if _, err := uuid.Parse(reassignToUserID); err != nil || reassignToUserID == id {
+			return domain.ErrInvalidReassignment
+		}
+		if _, err := s.userRepo.GetByID(context, reassignToUserID); err != nil {
+			if err == domain.ErrUserNotFound {
+				return domain.ErrInvalidReassignment
+			}
+			return err
+		}
+	}
+
 	// Delete the user
-	return s.userRepo.Delete(context, id)
+	return s.userRepo.Delete(context, id, reassignToUserID)
 }
 
 // ListUsers retrieves all users with authorization checks.
@@ -268,6 +285,8 @@ func (s *UserService) CreateSuperUser(ctx interface{}, email, name string) (*dom
 	if err != nil {
 		return nil, err
 	}
+	// The address comes from the deployment configuration, so it is trusted
+	newUser.MarkEmailVerified()
 
 	if err := s.userRepo.Create(context, newUser); err != nil {
 		return nil, err
@@ -311,6 +330,11 @@ func (s *UserService) getUserContext(ctx context.Context, userID string) (*auth.
 		return nil, auth.ErrInvalidContext
 	}
 
+	// Reuse the caller resolved by the auth middleware
+	if userCtx, ok := auth.UserContextFor(ctx, userID); ok {
+		return userCtx, nil
+	}
+
 	// Get the user to determine their role
 	userEntity, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -324,7 +348,10 @@ func (s *UserService) getUserContext(ctx context.Context, userID string) (*auth.
 	}
 
 	return &auth.UserContext{
-		UserID:   userID,
-		RoleName: userRole.Name,
+		UserID:      userID,
+		RoleID:      userRole.ID,
+		RoleName:    userRole.Name,
+		Scopes:      auth.ScopesFromContext(ctx),
+		MFAVerified: auth.MFAVerifiedFromContext(ctx),
 	}, nil
 }
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Personal access token format and limits.
+// AI-hint: The "fhp_" prefix lets the auth middleware tell access tokens from JWTs and
+// lets secret scanners recognize leaked tokens. DisplayPrefix keeps enough of the
+// token to identify it in listings without revealing it.
+const (
+	AccessTokenPrefix           = "fhp_"
+	AccessTokenDisplayPrefixLen = len(AccessTokenPrefix) + 8
+	MaxAccessTokenNameLength    = 100
+)
+
+// PersonalAccessToken is a long-lived, scoped credential for scripts and integrations.
+// AI-hint: Only the SHA-256 hash of the token is stored. Scopes are permission names
+// (see auth.Permission); the application layer validates them against the owner's role.
+// A nil ExpiresAt means the token never expires. MFAVerified records whether the login
+// that created the token completed two-factor authentication.
+type PersonalAccessToken struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	Name          string     `json:"name"`
+	DisplayPrefix string     `json:"display_prefix"`
+	TokenHash     string     `json:"-"`
+	Scopes        []string   `json:"scopes"`
+	MFAVerified   bool       `json:"mfa_verified"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// NewPersonalAccessToken creates a token for the user and returns it with its plaintext value.
+// AI-hint: The plaintext token is returned only here; duplicate scopes are dropped.
+func NewPersonalAccessToken(userID, name string, scopes []string, expiresAt *time.Time) (*PersonalAccessToken, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxAccessTokenNameLength {
+		return nil, "", ErrInvalidAccessTokenName
+	}
+
+	uniqueScopes := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			return nil, "", ErrInvalidTokenScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			uniqueScopes = append(uniqueScopes, scope)
+		}
+	}
+	if len(uniqueScopes) == 0 {
+		return nil, "", ErrInvalidTokenScope
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", ErrInvalidTokenExpiry
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	token := AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &PersonalAccessToken{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Name:          name,
+		DisplayPrefix: token[:AccessTokenDisplayPrefixLen],
+		TokenHash:     HashAccessToken(token),
+		Scopes:        uniqueScopes,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+	}, token, nil
+}
+
+// IsExpired reports whether the token's expiry has passed.
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.ExpiresAt != nil && !time.Now().Before(*t.ExpiresAt)
+}
+
+// HasScope reports whether the token was granted the scope.
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPersonalAccessToken reports whether a presented credential looks like an access token.
+// AI-hint: JWTs never start with the prefix, so this is enough to route verification.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
+
+// HashAccessToken returns the hex-encoded SHA-256 hash stored for an access token.
+func HashAccessToken(token string) string {
+	return hashToken(token)
+}
+
+// AccessTokenRepository defines the interface for personal access token persistence.
+// AI-hint: GetByHash is the authentication lookup; Delete is scoped to the owner so
+// users can only revoke their own tokens. TouchLastUsed may skip writes for tokens
+// used very recently.
+type AccessTokenRepository interface {
+	Create(ctx interface{}, token *PersonalAccessToken) error
+	GetByHash(ctx interface{}, tokenHash string) (*PersonalAccessToken, error)
+	ListByUserID(ctx interface{}, userID string) ([]*PersonalAccessToken, error)
+	Delete(ctx interface{}, id, userID string) error
+	TouchLastUsed(ctx interface{}, id string) error
+}
+
+// Error types for personal access tokens.
+// AI-hint: ErrInvalidAccessToken and ErrAccessTokenExpired map to 401; the validation
+// errors map to 400 and ErrAccessTokenNotFound to 404.
+var (
+	ErrAccessTokenNotFound    = errors.New("access token not found")
+	ErrInvalidAccessToken     = errors.New("invalid access token")
+	ErrAccessTokenExpired     = errors.New("access token has expired")
+	ErrInvalidAccessTokenName = errors.New("token name must be between 1 and 100 characters")
+	ErrInvalidTokenScope      = errors.New("invalid token scope")
+	ErrInvalidTokenExpiry     = errors.New("token expiry must be in the future")
+)
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	t.Run("issues a prefixed token and stores only its hash", func(t *testing.T) {
+		token, plaintext, err := NewPersonalAccessToken("user-123", "  CI deploys  ", []string{"api:read", "api:write", "api:read"}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, IsPersonalAccessToken(plaintext))
+		assert.True(t, strings.HasPrefix(plaintext, token.DisplayPrefix))
+		assert.Len(t, token.DisplayPrefix, AccessTokenDisplayPrefixLen)
+		assert.Equal(t, HashAccessToken(plaintext), token.TokenHash)
+		assert.Equal(t, "CI deploys", token.Name)
+		assert.Equal(t, []string{"api:read", "api:write"}, token.Scopes)
+		assert.False(t, token.IsExpired())
+	})
+
+	t.Run("validates name, scopes, and expiry", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		testCases := []struct {
+			name          string
+			tokenName     string
+			scopes        []string
+			expiresAt     *time.Time
+			expectedError error
+		}{
+			{"empty name", " ", []string{"api:read"}, nil, ErrInvalidAccessTokenName},
+			{"long name", strings.Repeat("x", MaxAccessTokenNameLength+1), []string{"api:read"}, nil, ErrInvalidAccessTokenName},
+			{"no scopes", "CI", nil, nil, ErrInvalidTokenScope},
+			{"blank scope", "CI", []string{"api:read", ""}, nil, ErrInvalidTokenScope},
+			{"expiry in the past", "CI", []string{"api:read"}, &past, ErrInvalidTokenExpiry},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := NewPersonalAccessToken("user-123", tc.tokenName, tc.scopes, tc.expiresAt)
+				assert.Equal(t, tc.expectedError, err)
+			})
+		}
+	})
+}
+
+func TestPersonalAccessToken_IsExpired(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	token, _, err := NewPersonalAccessToken("user-123", "CI", []string{"api:read"}, &expiresAt)
+	require.NoError(t, err)
+	assert.False(t, token.IsExpired())
+
+	expired := time.Now().Add(-time.Second)
+	token.ExpiresAt = &expired
+	assert.True(t, token.IsExpired())
+}
+
+func TestPersonalAccessToken_HasScope(t *testing.T) {
+	token, _, err := NewPersonalAccessToken("user-123", "CI", []string{"api:read", "idea:change_status"}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, token.HasScope("api:read"))
+	assert.True(t, token.HasScope("idea:change_status"))
+	assert.False(t, token.HasScope("api:write"))
+}
+
+func TestIsPersonalAccessToken(t *testing.T) {
+	assert.True(t, IsPersonalAccessToken(AccessTokenPrefix+"abc"))
+	assert.False(t, IsPersonalAccessToken("eyJhbGciOiJIUzI1NiJ9.payload.signature"))
+}
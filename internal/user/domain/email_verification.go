@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultEmailVerificationTTL is how long an email verification link stays valid.
+const DefaultEmailVerificationTTL = 48 * time.Hour
+
+// EmailVerificationToken is a single-use token proving that a user receives mail at an address.
+// AI-hint: The token remembers the address it was sent to, so it cannot verify a
+// different address if the user's email changes in the meantime. Only the SHA-256
+// hash is stored; sending a new link invalidates the user's earlier links.
+type EmailVerificationToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Email     string     `json:"email"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewEmailVerificationToken creates a verification token for the user's address and returns its plaintext value.
+// AI-hint: The plaintext is only returned here so it can be mailed to the user.
+func NewEmailVerificationToken(userID, email string, ttl time.Duration) (*EmailVerificationToken, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	if email == "" {
+		return nil, "", errors.New("email cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultEmailVerificationTTL
+	}
+
+	plaintext, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		TokenHash: HashEmailVerificationToken(plaintext),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, plaintext, nil
+}
+
+// IsUsable reports whether the token is neither used nor expired.
+func (t *EmailVerificationToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+// MatchesEmail reports whether the token was issued for the given address.
+func (t *EmailVerificationToken) MatchesEmail(email string) bool {
+	return t.Email == strings.ToLower(strings.TrimSpace(email))
+}
+
+// HashEmailVerificationToken returns the hex-encoded SHA-256 hash stored for a verification token.
+func HashEmailVerificationToken(token string) string {
+	return hashToken(token)
+}
+
+// EmailVerificationRepository defines the interface for email verification token persistence.
+// AI-hint: Same contract as PasswordResetRepository - MarkUsed only succeeds once per token.
+type EmailVerificationRepository interface {
+	Create(ctx interface{}, token *EmailVerificationToken) error
+	GetByHash(ctx interface{}, tokenHash string) (*EmailVerificationToken, error)
+	MarkUsed(ctx interface{}, id string) error
+	InvalidateForUser(ctx interface{}, userID string) error
+}
+
+// Error types for email verification.
+// AI-hint: ErrInvalidEmailVerificationToken covers unknown, used, expired, and
+// outdated tokens alike.
+var (
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrInvalidEmailVerificationToken  = errors.New("email verification link is invalid or has expired")
+	ErrEmailAlreadyVerified           = errors.New("email address is already verified")
+)
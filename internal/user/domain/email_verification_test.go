@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmailVerificationToken(t *testing.T) {
+	t.Run("issues a token for the normalized address", func(t *testing.T) {
+		token, plaintext, err := NewEmailVerificationToken("user-123", " Test@Example.com ", time.Hour)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, plaintext)
+		assert.Equal(t, "test@example.com", token.Email)
+		assert.Equal(t, HashEmailVerificationToken(plaintext), token.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+		assert.True(t, token.IsUsable())
+	})
+
+	t.Run("falls back to the default TTL", func(t *testing.T) {
+		token, _, err := NewEmailVerificationToken("user-123", "test@example.com", 0)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultEmailVerificationTTL), token.ExpiresAt, time.Second)
+	})
+
+	t.Run("requires user ID and email", func(t *testing.T) {
+		_, _, err := NewEmailVerificationToken("", "test@example.com", time.Hour)
+		assert.Error(t, err)
+
+		_, _, err = NewEmailVerificationToken("user-123", "", time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestEmailVerificationToken_IsUsable(t *testing.T) {
+	token, _, err := NewEmailVerificationToken("user-123", "test@example.com", time.Hour)
+	require.NoError(t, err)
+
+	usedAt := time.Now()
+	token.UsedAt = &usedAt
+	assert.False(t, token.IsUsable())
+
+	token.UsedAt = nil
+	token.ExpiresAt = time.Now().Add(-time.Second)
+	assert.False(t, token.IsUsable())
+}
+
+func TestEmailVerificationToken_MatchesEmail(t *testing.T) {
+	token, _, err := NewEmailVerificationToken("user-123", "test@example.com", time.Hour)
+	require.NoError(t, err)
+
+	assert.True(t, token.MatchesEmail("TEST@example.com"))
+	assert.False(t, token.MatchesEmail("other@example.com"))
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultInvitationTTL is how long an invitation link stays valid.
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+// Invitation statuses, as reported by Invitation.Status.
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// Invitation lets a user created by an administrator choose their password.
+// AI-hint: Created together with the user. The single-use token is mailed to the user
+// and only its SHA-256 hash is stored; resending replaces the token and expiry in place,
+// so the invitation keeps its ID. Accepting sets the password and verifies the email.
+type Invitation struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	Email           string     `json:"email"`
+	InvitedByUserID string     `json:"invited_by_user_id,omitempty"`
+	TokenHash       string     `json:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewInvitation creates an invitation for the user and returns it with its plaintext token.
+// AI-hint: The plaintext is only returned here so it can be mailed to the invitee.
+func NewInvitation(userID, email, invitedByUserID string, ttl time.Duration) (*Invitation, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	if email == "" {
+		return nil, "", errors.New("email cannot be empty")
+	}
+
+	invitation := &Invitation{
+		ID:              uuid.New().String(),
+		UserID:          userID,
+		Email:           strings.ToLower(strings.TrimSpace(email)),
+		InvitedByUserID: invitedByUserID,
+		CreatedAt:       time.Now(),
+	}
+	plaintext, err := invitation.Renew(ttl)
+	if err != nil {
+		return nil, "", err
+	}
+	return invitation, plaintext, nil
+}
+
+// Renew gives the invitation a new token and expiry and returns the plaintext token.
+// AI-hint: Used when resending; the previous link stops working once the change is stored.
+func (i *Invitation) Renew(ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultInvitationTTL
+	}
+
+	plaintext, err := randomTokenSecret()
+	if err != nil {
+		return "", err
+	}
+	i.TokenHash = HashInvitationToken(plaintext)
+	i.ExpiresAt = time.Now().Add(ttl)
+	return plaintext, nil
+}
+
+// IsPending reports whether the invitation was neither accepted nor revoked, even if it expired.
+// AI-hint: Pending invitations can be resent or revoked.
+func (i *Invitation) IsPending() bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil
+}
+
+// IsUsable reports whether the invitation can still be accepted.
+func (i *Invitation) IsUsable() bool {
+	return i.IsPending() && time.Now().Before(i.ExpiresAt)
+}
+
+// Status returns the invitation's state as one of the InvitationStatus constants.
+func (i *Invitation) Status() string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !time.Now().Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// MatchesEmail reports whether the invitation was sent to the given address.
+func (i *Invitation) MatchesEmail(email string) bool {
+	return i.Email == strings.ToLower(strings.TrimSpace(email))
+}
+
+// HashInvitationToken returns the hex-encoded SHA-256 hash stored for an invitation token.
+func HashInvitationToken(token string) string {
+	return hashToken(token)
+}
+
+// InvitationRepository defines the interface for invitation persistence.
+// AI-hint: Renew, Accept, and Revoke only succeed for pending invitations and return
+// ErrInvitationNotPending otherwise, so an invitation cannot be accepted twice or after it
+// was revoked. Accept stores the invited user in the same transaction, so the invitation
+// stays pending if that fails. ListPending includes expired invitations so they can be resent.
+type InvitationRepository interface {
+	Create(ctx interface{}, invitation *Invitation) error
+	GetByID(ctx interface{}, id string) (*Invitation, error)
+	GetByHash(ctx interface{}, tokenHash string) (*Invitation, error)
+	ListPending(ctx interface{}) ([]*Invitation, error)
+	Renew(ctx interface{}, invitation *Invitation) error
+	Accept(ctx interface{}, id string, user *User) error
+	Revoke(ctx interface{}, id string) error
+}
+
+// Error types for invitations.
+// AI-hint: ErrInvalidInvitation covers unknown, used, revoked, and expired tokens alike so
+// responses do not reveal which one it was.
+var (
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvalidInvitation    = errors.New("invitation link is invalid or has expired")
+	ErrInvitationNotPending = errors.New("invitation was already accepted or revoked")
+)
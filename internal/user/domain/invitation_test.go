@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvitation(t *testing.T) {
+	t.Run("issues a token for the normalized address", func(t *testing.T) {
+		invitation, plaintext, err := NewInvitation("user-123", " New@Example.com ", "admin-1", time.Hour)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, plaintext)
+		assert.Equal(t, "new@example.com", invitation.Email)
+		assert.Equal(t, "admin-1", invitation.InvitedByUserID)
+		assert.Equal(t, HashInvitationToken(plaintext), invitation.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), invitation.ExpiresAt, time.Second)
+		assert.True(t, invitation.IsUsable())
+		assert.Equal(t, InvitationStatusPending, invitation.Status())
+		assert.True(t, invitation.MatchesEmail("NEW@example.com"))
+	})
+
+	t.Run("falls back to the default TTL", func(t *testing.T) {
+		invitation, _, err := NewInvitation("user-123", "new@example.com", "", 0)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultInvitationTTL), invitation.ExpiresAt, time.Second)
+	})
+
+	t.Run("requires user ID and email", func(t *testing.T) {
+		_, _, err := NewInvitation("", "new@example.com", "", time.Hour)
+		assert.Error(t, err)
+
+		_, _, err = NewInvitation("user-123", "", "", time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestInvitation_Renew(t *testing.T) {
+	invitation, plaintext, err := NewInvitation("user-123", "new@example.com", "", time.Minute)
+	require.NoError(t, err)
+	invitation.ExpiresAt = time.Now().Add(-time.Minute)
+	assert.Equal(t, InvitationStatusExpired, invitation.Status())
+
+	renewed, err := invitation.Renew(time.Hour)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, plaintext, renewed)
+	assert.Equal(t, HashInvitationToken(renewed), invitation.TokenHash)
+	assert.True(t, invitation.IsUsable())
+}
+
+func TestInvitation_Status(t *testing.T) {
+	now := time.Now()
+
+	accepted := &Invitation{ExpiresAt: now.Add(time.Hour), AcceptedAt: &now}
+	assert.Equal(t, InvitationStatusAccepted, accepted.Status())
+	assert.False(t, accepted.IsPending())
+	assert.False(t, accepted.IsUsable())
+
+	revoked := &Invitation{ExpiresAt: now.Add(time.Hour), RevokedAt: &now}
+	assert.Equal(t, InvitationStatusRevoked, revoked.Status())
+	assert.False(t, revoked.IsUsable())
+
+	expired := &Invitation{ExpiresAt: now.Add(-time.Hour)}
+	assert.Equal(t, InvitationStatusExpired, expired.Status())
+	assert.True(t, expired.IsPending())
+	assert.False(t, expired.IsUsable())
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Default login throttling limits.
+const (
+	DefaultLoginMaxFailures      = 5
+	DefaultLoginIPMaxFailures    = 20
+	DefaultLoginBackoffBase      = time.Second
+	DefaultLoginBackoffMax       = 30 * time.Second
+	DefaultLoginLockoutDuration  = 15 * time.Minute
+	DefaultLoginFailureRetention = time.Hour
+)
+
+// LoginAttempts tracks failed logins for one throttling key.
+// AI-hint: Keys come from AccountLoginKey and IPLoginKey so accounts and client IPs are
+// counted separately. Failures older than the policy's FailureRetention are forgotten.
+type LoginAttempts struct {
+	Key           string     `json:"key"`
+	Failures      int        `json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+// IsLocked reports whether the key is locked out at now.
+func (a *LoginAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// AccountLoginKey returns the throttling key for an account, identified by the email
+// address used to log in.
+// AI-hint: Unknown emails are tracked as well so responses do not reveal which accounts exist.
+func AccountLoginKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// IPLoginKey returns the throttling key for a client IP address.
+func IPLoginKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LoginThrottlePolicy decides how failed logins slow down and lock out further attempts.
+// AI-hint: After each failure the next attempt must wait BackoffBase doubled per earlier
+// failure, capped at BackoffMax. Reaching MaxFailures locks the key for LockoutDuration.
+// A MaxFailures of zero disables throttling for the key.
+type LoginThrottlePolicy struct {
+	MaxFailures      int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutDuration  time.Duration
+	FailureRetention time.Duration
+}
+
+// DefaultAccountLoginPolicy returns the default throttling policy for accounts.
+func DefaultAccountLoginPolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		MaxFailures:      DefaultLoginMaxFailures,
+		BackoffBase:      DefaultLoginBackoffBase,
+		BackoffMax:       DefaultLoginBackoffMax,
+		LockoutDuration:  DefaultLoginLockoutDuration,
+		FailureRetention: DefaultLoginFailureRetention,
+	}
+}
+
+// DefaultIPLoginPolicy returns the default throttling policy for client IPs.
+// AI-hint: More lenient than the account policy because many users can share an IP.
+func DefaultIPLoginPolicy() LoginThrottlePolicy {
+	policy := DefaultAccountLoginPolicy()
+	policy.MaxFailures = DefaultLoginIPMaxFailures
+	return policy
+}
+
+// Enabled reports whether the policy throttles at all.
+func (p LoginThrottlePolicy) Enabled() bool {
+	return p.MaxFailures > 0
+}
+
+// RetryAfter returns how long the key must wait before the next login attempt, or zero.
+func (p LoginThrottlePolicy) RetryAfter(attempts *LoginAttempts, now time.Time) time.Duration {
+	if !p.Enabled() || attempts == nil {
+		return 0
+	}
+	if attempts.IsLocked(now) {
+		return attempts.LockedUntil.Sub(now)
+	}
+	if attempts.Failures == 0 || now.Sub(attempts.LastFailureAt) >= p.FailureRetention {
+		return 0
+	}
+
+	wait := attempts.LastFailureAt.Add(p.Backoff(attempts.Failures)).Sub(now)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Backoff returns the delay imposed after the given number of consecutive failures.
+func (p LoginThrottlePolicy) Backoff(failures int) time.Duration {
+	if failures <= 0 || p.BackoffBase <= 0 {
+		return 0
+	}
+	delay := p.BackoffBase
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if p.BackoffMax > 0 && delay >= p.BackoffMax {
+			return p.BackoffMax
+		}
+	}
+	if p.BackoffMax > 0 && delay > p.BackoffMax {
+		return p.BackoffMax
+	}
+	return delay
+}
+
+// ShouldLock reports whether the recorded failures reach the lockout threshold.
+// AI-hint: Only true for keys that are not already locked, so a lockout is reported once.
+// Relies on LoginAttemptStore.Lock clearing the count; otherwise every failure after an
+// expired lockout would lock the key again straight away.
+func (p LoginThrottlePolicy) ShouldLock(attempts *LoginAttempts, now time.Time) bool {
+	return p.Enabled() && attempts.Failures >= p.MaxFailures && !attempts.IsLocked(now)
+}
+
+// LoginAttemptStore defines the interface for failed login tracking.
+// AI-hint: Implemented by a PostgreSQL store (shared across instances) and an in-memory
+// store (single instance, lost on restart). Get returns empty attempts for unknown keys.
+// RecordFailure must increment atomically and restart the count when the previous
+// failure is older than retention. Lock also clears the count, so the failures that
+// caused a lockout are spent and the key gets a fresh set of attempts once it expires.
+type LoginAttemptStore interface {
+	Get(ctx interface{}, key string) (*LoginAttempts, error)
+	RecordFailure(ctx interface{}, key string, at time.Time, retention time.Duration) (*LoginAttempts, error)
+	Lock(ctx interface{}, key string, until time.Time) error
+	Reset(ctx interface{}, key string) error
+}
+
+// Error types for login throttling.
+var (
+	ErrAccountLocked        = errors.New("account is temporarily locked after too many failed logins")
+	ErrTooManyLoginAttempts = errors.New("too many failed logins; try again later")
+)
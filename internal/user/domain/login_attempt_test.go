@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottlePolicy_Backoff(t *testing.T) {
+	policy := LoginThrottlePolicy{MaxFailures: 5, BackoffBase: time.Second, BackoffMax: 10 * time.Second}
+
+	assert.Equal(t, time.Duration(0), policy.Backoff(0))
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 8*time.Second, policy.Backoff(4))
+	assert.Equal(t, 10*time.Second, policy.Backoff(5), "capped at BackoffMax")
+	assert.Equal(t, 10*time.Second, policy.Backoff(100))
+}
+
+func TestLoginThrottlePolicy_RetryAfter(t *testing.T) {
+	policy := DefaultAccountLoginPolicy()
+	now := time.Now()
+
+	t.Run("no failures", func(t *testing.T) {
+		assert.Zero(t, policy.RetryAfter(&LoginAttempts{Key: "account:a@example.com"}, now))
+	})
+
+	t.Run("waits out the backoff after a failure", func(t *testing.T) {
+		attempts := &LoginAttempts{Failures: 3, LastFailureAt: now}
+
+		assert.Equal(t, 4*time.Second, policy.RetryAfter(attempts, now))
+		assert.Zero(t, policy.RetryAfter(attempts, now.Add(5*time.Second)))
+	})
+
+	t.Run("waits out a lockout", func(t *testing.T) {
+		until := now.Add(time.Minute)
+		attempts := &LoginAttempts{Failures: 5, LastFailureAt: now, LockedUntil: &until}
+
+		assert.Equal(t, time.Minute, policy.RetryAfter(attempts, now))
+		assert.Zero(t, policy.RetryAfter(attempts, now.Add(2*time.Minute)))
+	})
+
+	t.Run("forgets old failures", func(t *testing.T) {
+		attempts := &LoginAttempts{Failures: 4, LastFailureAt: now.Add(-2 * policy.FailureRetention)}
+		assert.Zero(t, policy.RetryAfter(attempts, now))
+	})
+
+	t.Run("disabled policy never throttles", func(t *testing.T) {
+		until := now.Add(time.Minute)
+		attempts := &LoginAttempts{Failures: 50, LastFailureAt: now, LockedUntil: &until}
+		assert.Zero(t, LoginThrottlePolicy{}.RetryAfter(attempts, now))
+	})
+}
+
+func TestLoginThrottlePolicy_ShouldLock(t *testing.T) {
+	policy := DefaultAccountLoginPolicy()
+	now := time.Now()
+
+	assert.False(t, policy.ShouldLock(&LoginAttempts{Failures: policy.MaxFailures - 1}, now))
+	assert.True(t, policy.ShouldLock(&LoginAttempts{Failures: policy.MaxFailures}, now))
+
+	until := now.Add(time.Minute)
+	assert.False(t, policy.ShouldLock(&LoginAttempts{Failures: policy.MaxFailures + 1, LockedUntil: &until}, now), "already locked")
+	assert.False(t, LoginThrottlePolicy{}.ShouldLock(&LoginAttempts{Failures: 100}, now))
+}
+
+func TestLoginKeys(t *testing.T) {
+	assert.Equal(t, "account:user@example.com", AccountLoginKey("  User@Example.com "))
+	assert.Equal(t, "ip:203.0.113.7", IPLoginKey("203.0.113.7"))
+	assert.NotEqual(t, AccountLoginKey("x"), IPLoginKey("x"))
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFA limits.
+const (
+	// RecoveryCodeCount is how many recovery codes a user gets at a time.
+	RecoveryCodeCount = 10
+	// DefaultMFAChallengeTTL is how long the second login step may take.
+	DefaultMFAChallengeTTL = 5 * time.Minute
+	// MaxMFAChallengeAttempts caps code guesses per challenge; six-digit codes are
+	// otherwise easy to brute-force.
+	MaxMFAChallengeAttempts = 5
+)
+
+// recoveryCodeEncoding is lowercase base32 without padding, avoiding 0/1/8/9 look-alikes.
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// NewRecoveryCodes generates a fresh set of single-use recovery codes.
+// AI-hint: Returns the plaintext codes (shown to the user once, formatted "xxxxx-xxxxx")
+// and the hashes to store.
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		encoded := recoveryCodeEncoding.EncodeToString(raw)[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash stored for a recovery code.
+// AI-hint: Case, spaces, and the dash are ignored so users can type codes loosely.
+func HashRecoveryCode(code string) string {
+	return hashToken(normalizeMFACode(code))
+}
+
+// IsRecoveryCode reports whether a code entered at login is a recovery code rather
+// than a TOTP code.
+func IsRecoveryCode(code string) bool {
+	normalized := normalizeMFACode(code)
+	if len(normalized) != TOTPDigits {
+		return true
+	}
+	for _, r := range normalized {
+		if r < '0' || r > '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMFACode lowercases a code and strips spaces and dashes.
+func normalizeMFACode(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+// MFAChallenge is the pending second step of a login for a user with two-factor authentication.
+// AI-hint: Issued after the password check instead of a session. The token is
+// "<challenge ID>.<random secret>" and only its hash is stored; the challenge is
+// deleted once completed or after MaxMFAChallengeAttempts wrong codes.
+type MFAChallenge struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	TokenHash string    `json:"-"`
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewMFAChallenge creates a challenge for the user and returns it with its plaintext token.
+func NewMFAChallenge(userID string, ttl time.Duration) (*MFAChallenge, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultMFAChallengeTTL
+	}
+
+	secret, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	challenge := &MFAChallenge{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	token := challenge.ID + "." + secret
+	challenge.TokenHash = hashToken(token)
+	return challenge, token, nil
+}
+
+// MatchesToken reports whether token is this challenge's token.
+func (c *MFAChallenge) MatchesToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(c.TokenHash)) == 1
+}
+
+// IsExpired reports whether the challenge can no longer be completed.
+func (c *MFAChallenge) IsExpired() bool {
+	return !time.Now().Before(c.ExpiresAt)
+}
+
+// ParseMFAChallengeToken extracts the challenge ID from a challenge token.
+// AI-hint: Only checks the format; the token itself is verified with MatchesToken.
+func ParseMFAChallengeToken(token string) (string, error) {
+	challengeID, secret, found := strings.Cut(token, ".")
+	if !found || secret == "" {
+		return "", ErrInvalidMFAChallenge
+	}
+	if _, err := uuid.Parse(challengeID); err != nil {
+		return "", ErrInvalidMFAChallenge
+	}
+	return challengeID, nil
+}
+
+// MFARepository defines the interface for two-factor credential persistence.
+// AI-hint: RecordTOTPStep and UseRecoveryCode must be conditional updates so a code
+// can only ever be accepted once, even by concurrent requests. Deleting the TOTP
+// credential also deletes the user's recovery codes.
+type MFARepository interface {
+	GetTOTPCredential(ctx interface{}, userID string) (*TOTPCredential, error)
+	SaveTOTPCredential(ctx interface{}, credential *TOTPCredential) error
+	RecordTOTPStep(ctx interface{}, userID string, step int64) error
+	DeleteTOTPCredential(ctx interface{}, userID string) error
+	ReplaceRecoveryCodes(ctx interface{}, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx interface{}, userID, codeHash string) error
+	CountRecoveryCodes(ctx interface{}, userID string) (int, error)
+}
+
+// MFAChallengeRepository defines the interface for pending login challenges.
+// AI-hint: RecordAttempt increments the attempt counter atomically and returns the new count.
+type MFAChallengeRepository interface {
+	Create(ctx interface{}, challenge *MFAChallenge) error
+	GetByID(ctx interface{}, id string) (*MFAChallenge, error)
+	RecordAttempt(ctx interface{}, id string) (int, error)
+	Delete(ctx interface{}, id string) error
+}
+
+// Error types for two-factor authentication.
+// AI-hint: ErrInvalidMFACode covers wrong, replayed, and used codes alike.
+var (
+	ErrTOTPNotEnrolled      = errors.New("two-factor authentication is not enabled")
+	ErrTOTPAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrInvalidMFACode       = errors.New("invalid two-factor authentication code")
+	ErrMFAChallengeNotFound = errors.New("MFA challenge not found")
+	ErrInvalidMFAChallenge  = errors.New("MFA challenge is invalid or has expired; log in again")
+	ErrMFARequiredByPolicy  = errors.New("two-factor authentication is mandatory for your role")
+)
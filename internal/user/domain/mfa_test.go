@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	require.Len(t, hashes, RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.Equal(t, HashRecoveryCode(code), hashes[i])
+		assert.True(t, IsRecoveryCode(code))
+		assert.False(t, seen[code], "codes are unique")
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode_IgnoresFormatting(t *testing.T) {
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode(" ABCDE FGHIJ "))
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode("abcdefghij"))
+}
+
+func TestIsRecoveryCode(t *testing.T) {
+	assert.False(t, IsRecoveryCode("123456"))
+	assert.False(t, IsRecoveryCode(" 123 456 "))
+	assert.True(t, IsRecoveryCode("abcde-fghij"))
+}
+
+func TestNewMFAChallenge(t *testing.T) {
+	t.Run("issues a token bound to the challenge", func(t *testing.T) {
+		challenge, token, err := NewMFAChallenge("user-123", time.Minute)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, challenge.ID+"."))
+		assert.True(t, challenge.MatchesToken(token))
+		assert.False(t, challenge.MatchesToken(token+"x"))
+		assert.False(t, challenge.IsExpired())
+
+		challengeID, err := ParseMFAChallengeToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, challenge.ID, challengeID)
+	})
+
+	t.Run("falls back to the default TTL", func(t *testing.T) {
+		challenge, _, err := NewMFAChallenge("user-123", 0)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultMFAChallengeTTL), challenge.ExpiresAt, time.Second)
+	})
+
+	t.Run("expires", func(t *testing.T) {
+		challenge, _, err := NewMFAChallenge("user-123", time.Minute)
+		require.NoError(t, err)
+
+		challenge.ExpiresAt = time.Now().Add(-time.Second)
+		assert.True(t, challenge.IsExpired())
+	})
+}
+
+func TestParseMFAChallengeToken(t *testing.T) {
+	for _, token := range []string{"", "not-a-token", "not-a-uuid.secret", "6f1c8f52-3a1e-4b8e-9a57-0d3c0f3c2b11."} {
+		_, err := ParseMFAChallengeToken(token)
+		assert.Equal(t, ErrInvalidMFAChallenge, err, "token %q", token)
+	}
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultOIDCLoginTTL is how long a user may take at the identity provider before the
+// single sign-on login must be started again.
+const DefaultOIDCLoginTTL = 10 * time.Minute
+
+// OIDCLogin is a pending single sign-on login between the redirect to the identity
+// provider and its callback.
+// AI-hint: Keyed by the SHA-256 hash of the state parameter. Holds the PKCE code verifier
+// and the nonce expected in the ID token; consumed exactly once by the callback.
+type OIDCLogin struct {
+	StateHash    string    `json:"-"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"-"`
+	Nonce        string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// NewOIDCLogin starts a login with the named provider and returns it with its plaintext state.
+// AI-hint: The state is sent to the identity provider and kept in a browser cookie; only
+// its hash is stored.
+func NewOIDCLogin(provider string, ttl time.Duration) (*OIDCLogin, string, error) {
+	if provider == "" {
+		return nil, "", errors.New("provider cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultOIDCLoginTTL
+	}
+
+	state, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	verifier, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	nonce, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &OIDCLogin{
+		StateHash:    HashOIDCState(state),
+		Provider:     provider,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}, state, nil
+}
+
+// IsExpired reports whether the login has passed its expiry time.
+func (l *OIDCLogin) IsExpired() bool {
+	return !time.Now().Before(l.ExpiresAt)
+}
+
+// CodeChallenge returns the PKCE S256 challenge for the login's code verifier.
+func (l *OIDCLogin) CodeChallenge() string {
+	return PKCEChallenge(l.CodeVerifier)
+}
+
+// PKCEChallenge derives the S256 code challenge from a PKCE code verifier (RFC 7636).
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// HashOIDCState returns the hex-encoded SHA-256 hash stored for a login state.
+func HashOIDCState(state string) string {
+	return hashToken(state)
+}
+
+// OIDCIdentity is the verified identity an identity provider returned for a login.
+// AI-hint: Built from the ID token claims. Subject is the stable account ID at the
+// provider; the email address may change and is only trusted when EmailVerified is set.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AuthMethods   []string
+}
+
+// UsedMFA reports whether the identity provider says the user logged in with more
+// than one factor (the "mfa" authentication method reference from RFC 8176).
+func (i *OIDCIdentity) UsedMFA() bool {
+	for _, method := range i.AuthMethods {
+		if method == "mfa" {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayName returns the name to give a provisioned user, falling back to the local
+// part of the email address when the provider sends no name.
+func (i *OIDCIdentity) DisplayName() string {
+	if name := strings.TrimSpace(i.Name); name != "" {
+		return name
+	}
+	local, _, _ := strings.Cut(i.Email, "@")
+	return local
+}
+
+// IdentityProvider is an OpenID Connect provider users can log in with.
+// AI-hint: Implemented by the infrastructure layer. AuthorizationURL builds the redirect
+// for the authorization code flow with PKCE; Exchange redeems the code and returns the
+// identity from the verified ID token, which must carry the given nonce.
+type IdentityProvider interface {
+	AuthorizationURL(ctx interface{}, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx interface{}, code, codeVerifier, nonce string) (*OIDCIdentity, error)
+}
+
+// OIDCLoginRepository defines the interface for pending single sign-on logins.
+// AI-hint: Consume must delete and return the login atomically so a state can only be
+// used once; unknown states return ErrOIDCLoginNotFound.
+type OIDCLoginRepository interface {
+	Create(ctx interface{}, login *OIDCLogin) error
+	Consume(ctx interface{}, stateHash string) (*OIDCLogin, error)
+}
+
+// ExternalIdentity links a user to their account at an identity provider.
+// AI-hint: One user may be linked to several providers; a provider account (provider
+// plus subject) belongs to exactly one user. Email is the last address the provider sent.
+type ExternalIdentity struct {
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"subject"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// ExternalIdentityRepository defines the interface for linked provider accounts.
+// AI-hint: Create returns ErrExternalIdentityExists when the provider account is already
+// linked; identities are deleted together with their user.
+type ExternalIdentityRepository interface {
+	GetByProviderSubject(ctx interface{}, provider, subject string) (*ExternalIdentity, error)
+	Create(ctx interface{}, identity *ExternalIdentity) error
+	RecordLogin(ctx interface{}, provider, subject, email string, at time.Time) error
+}
+
+// Error types for single sign-on.
+// AI-hint: ErrInvalidOIDCLogin covers unknown, reused, expired, and mismatched states
+// alike; ErrOIDCEmailNotVerified is returned when an unlinked identity has no verified
+// email to link or provision by.
+var (
+	ErrUnknownIdentityProvider  = errors.New("unknown identity provider")
+	ErrOIDCLoginNotFound        = errors.New("single sign-on login not found")
+	ErrInvalidOIDCLogin         = errors.New("single sign-on login is invalid or has expired; start again")
+	ErrOIDCEmailNotVerified     = errors.New("identity provider did not confirm the email address")
+	ErrOIDCProvisioningDisabled = errors.New("no account exists for this identity")
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+	ErrExternalIdentityExists   = errors.New("external identity is already linked")
+)
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOIDCLogin(t *testing.T) {
+	login, state, err := NewOIDCLogin("corp", time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, "corp", login.Provider)
+	assert.Equal(t, HashOIDCState(state), login.StateHash)
+	assert.NotEqual(t, state, login.StateHash)
+	assert.NotEmpty(t, login.CodeVerifier)
+	assert.NotEmpty(t, login.Nonce)
+	assert.NotEqual(t, login.CodeVerifier, login.Nonce)
+	assert.False(t, login.IsExpired())
+
+	t.Run("rejects an empty provider", func(t *testing.T) {
+		_, _, err := NewOIDCLogin("", time.Minute)
+		assert.Error(t, err)
+	})
+
+	t.Run("expires after the TTL", func(t *testing.T) {
+		login.ExpiresAt = time.Now().Add(-time.Second)
+		assert.True(t, login.IsExpired())
+	})
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+
+	login := &OIDCLogin{CodeVerifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+	assert.Equal(t, PKCEChallenge(login.CodeVerifier), login.CodeChallenge())
+}
+
+func TestOIDCIdentity_UsedMFA(t *testing.T) {
+	assert.True(t, (&OIDCIdentity{AuthMethods: []string{"pwd", "mfa", "otp"}}).UsedMFA())
+	assert.False(t, (&OIDCIdentity{AuthMethods: []string{"pwd"}}).UsedMFA())
+	assert.False(t, (&OIDCIdentity{}).UsedMFA())
+}
+
+func TestOIDCIdentity_DisplayName(t *testing.T) {
+	assert.Equal(t, "Jane Doe", (&OIDCIdentity{Name: " Jane Doe ", Email: "jane@example.com"}).DisplayName())
+	assert.Equal(t, "jane.doe", (&OIDCIdentity{Email: "jane.doe@example.com"}).DisplayName())
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default password policy limits.
+const (
+	DefaultPasswordMinLength = 8
+	// DefaultPasswordMaxBytes is bcrypt's input limit; longer passwords would be
+	// silently truncated or rejected by the hasher.
+	DefaultPasswordMaxBytes = 72
+	// minPersonalInfoLength keeps short names and email parts from rejecting unrelated passwords.
+	minPersonalInfoLength = 4
+)
+
+// Password policy rule names, reported in PasswordViolation.Rule.
+const (
+	PasswordRuleMinLength        = "min_length"
+	PasswordRuleMaxLength        = "max_length"
+	PasswordRuleCharacterClasses = "character_classes"
+	PasswordRulePersonalInfo     = "personal_info"
+	PasswordRuleCommonPassword   = "common_password"
+)
+
+// PasswordBlocklist reports whether a password is too common to allow.
+// AI-hint: Implemented by the infrastructure layer from the bundled list plus an
+// optional operator-supplied file; lookups are case-insensitive.
+type PasswordBlocklist interface {
+	Contains(password string) bool
+}
+
+// PasswordPolicy holds the rules new passwords must satisfy.
+// AI-hint: Applied on registration and password reset. MinCharacterClasses counts how
+// many of lowercase, uppercase, digits, and symbols must appear (0 or 1 means no
+// requirement). A nil Blocklist skips the common-password check.
+type PasswordPolicy struct {
+	MinLength           int
+	MaxBytes            int
+	MinCharacterClasses int
+	RejectPersonalInfo  bool
+	Blocklist           PasswordBlocklist
+}
+
+// DefaultPasswordPolicy returns the policy used when nothing is configured.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:          DefaultPasswordMinLength,
+		MaxBytes:           DefaultPasswordMaxBytes,
+		RejectPersonalInfo: true,
+	}
+}
+
+// PasswordViolation describes one password policy rule a password breaks.
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyError lists every rule a password breaks.
+// AI-hint: errors.Is(err, ErrPasswordPolicyViolation) matches it; use errors.As to
+// read the individual violations for the API response.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error joins the violation messages.
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return "password does not meet the policy: " + strings.Join(messages, "; ")
+}
+
+// Unwrap lets errors.Is match ErrPasswordPolicyViolation.
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicyViolation
+}
+
+// Validate checks a password for the user with the given email and name.
+// AI-hint: Returns nil or a *PasswordPolicyError with all violations at once, so users
+// can fix everything in one go. email and name may be empty when unknown.
+func (p PasswordPolicy) Validate(password, email, name string) error {
+	var violations []PasswordViolation
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMinLength,
+			Message: fmt.Sprintf("must be at least %d characters long", p.MinLength),
+		})
+	}
+
+	if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMaxLength,
+			Message: fmt.Sprintf("must be at most %d bytes long", p.MaxBytes),
+		})
+	}
+
+	if p.MinCharacterClasses > 1 && countCharacterClasses(password) < p.MinCharacterClasses {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleCharacterClasses,
+			Message: fmt.Sprintf("must mix at least %d of lowercase letters, uppercase letters, digits, and symbols", p.MinCharacterClasses),
+		})
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, email, name) {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRulePersonalInfo,
+			Message: "must not contain your email address or name",
+		})
+	}
+
+	if p.Blocklist != nil && p.Blocklist.Contains(password) {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleCommonPassword,
+			Message: "is too common; choose a password that is harder to guess",
+		})
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// countCharacterClasses counts which of lowercase, uppercase, digit, and symbol appear.
+func countCharacterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+// containsPersonalInfo reports whether the password contains the email, its local part,
+// the name, or any part of the name, ignoring case.
+func containsPersonalInfo(password, email, name string) bool {
+	password = strings.ToLower(password)
+
+	candidates := []string{}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email != "" {
+		candidates = append(candidates, email)
+		if local, _, found := strings.Cut(email, "@"); found {
+			candidates = append(candidates, local)
+		}
+	}
+	nameParts := strings.Fields(strings.ToLower(name))
+	if len(nameParts) > 0 {
+		candidates = append(candidates, strings.Join(nameParts, ""))
+		candidates = append(candidates, nameParts...)
+	}
+
+	for _, candidate := range candidates {
+		if utf8.RuneCountInString(candidate) >= minPersonalInfoLength && strings.Contains(password, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Error types for password policies.
+var (
+	ErrPasswordPolicyViolation = errors.New("password does not meet the policy")
+)
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticBlocklist is a PasswordBlocklist for tests.
+type staticBlocklist map[string]bool
+
+func (b staticBlocklist) Contains(password string) bool {
+	return b[strings.ToLower(password)]
+}
+
+// violatedRules returns the rule names of a policy error, or nil for no error.
+func violatedRules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var policyErr *PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	rules := make([]string, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	return rules
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.MinCharacterClasses = 3
+	policy.Blocklist = staticBlocklist{"correcthorse1!": true}
+
+	t.Run("accepts a good password", func(t *testing.T) {
+		assert.NoError(t, policy.Validate("Tr4vel-Ledger", "jane.doe@example.com", "Jane Doe"))
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleMinLength}, violatedRules(t, policy.Validate("Ab1!", "", "")))
+	})
+
+	t.Run("longer than bcrypt accepts", func(t *testing.T) {
+		password := "Aa1!" + strings.Repeat("x", DefaultPasswordMaxBytes)
+		assert.Equal(t, []string{PasswordRuleMaxLength}, violatedRules(t, policy.Validate(password, "", "")))
+	})
+
+	t.Run("too few character classes", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleCharacterClasses}, violatedRules(t, policy.Validate("alllowercase1", "", "")))
+	})
+
+	t.Run("contains the email or name", func(t *testing.T) {
+		for _, password := range []string{"Jane.Doe-2024!", "X1!JaneDoeX", "Secret-Jane-99"} {
+			assert.Equal(t, []string{PasswordRulePersonalInfo}, violatedRules(t, policy.Validate(password, "jane.doe@example.com", "Jane Doe")), password)
+		}
+	})
+
+	t.Run("short name parts are ignored", func(t *testing.T) {
+		assert.NoError(t, policy.Validate("Planning-Board7", "al@example.com", "Al Ng"))
+	})
+
+	t.Run("common password", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleCommonPassword}, violatedRules(t, policy.Validate("CorrectHorse1!", "", "")))
+	})
+
+	t.Run("reports every violation and matches the sentinel", func(t *testing.T) {
+		err := policy.Validate("jane", "jane@example.com", "Jane")
+
+		assert.ErrorIs(t, err, ErrPasswordPolicyViolation)
+		assert.Equal(t, []string{PasswordRuleMinLength, PasswordRuleCharacterClasses, PasswordRulePersonalInfo}, violatedRules(t, err))
+	})
+
+	t.Run("zero policy accepts anything", func(t *testing.T) {
+		assert.NoError(t, PasswordPolicy{}.Validate("x", "x@example.com", "x"))
+	})
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPasswordResetTTL is how long a password reset link stays valid.
+const DefaultPasswordResetTTL = time.Hour
+
+// PasswordResetToken is a single-use token that lets a user choose a new password.
+// AI-hint: Only the SHA-256 hash of the token is stored. A token is usable until it
+// expires or is used; requesting a new reset invalidates the user's earlier tokens.
+type PasswordResetToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewPasswordResetToken creates a reset token for the user and returns it with its plaintext value.
+// AI-hint: The plaintext is only returned here so it can be mailed to the user.
+func NewPasswordResetToken(userID string, ttl time.Duration) (*PasswordResetToken, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultPasswordResetTTL
+	}
+
+	plaintext, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: HashPasswordResetToken(plaintext),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, plaintext, nil
+}
+
+// IsUsable reports whether the token is neither used nor expired.
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+// HashPasswordResetToken returns the hex-encoded SHA-256 hash stored for a reset token.
+func HashPasswordResetToken(token string) string {
+	return hashToken(token)
+}
+
+// PasswordResetRepository defines the interface for password reset token persistence.
+// AI-hint: MarkUsed must only succeed for a token that has not been used yet, so two
+// concurrent resets with the same link cannot both change the password.
+type PasswordResetRepository interface {
+	Create(ctx interface{}, token *PasswordResetToken) error
+	GetByHash(ctx interface{}, tokenHash string) (*PasswordResetToken, error)
+	MarkUsed(ctx interface{}, id string) error
+	InvalidateForUser(ctx interface{}, userID string) error
+}
+
+// Error types for password resets.
+// AI-hint: ErrInvalidPasswordResetToken covers unknown, used, and expired tokens alike
+// so responses do not reveal which one it was.
+var (
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrInvalidPasswordResetToken  = errors.New("password reset link is invalid or has expired")
+)
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	t.Run("issues a token and stores only its hash", func(t *testing.T) {
+		token, plaintext, err := NewPasswordResetToken("user-123", 30*time.Minute)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, plaintext)
+		assert.Equal(t, "user-123", token.UserID)
+		assert.Equal(t, HashPasswordResetToken(plaintext), token.TokenHash)
+		assert.NotEqual(t, plaintext, token.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), token.ExpiresAt, time.Second)
+		assert.True(t, token.IsUsable())
+	})
+
+	t.Run("falls back to the default TTL", func(t *testing.T) {
+		token, _, err := NewPasswordResetToken("user-123", 0)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultPasswordResetTTL), token.ExpiresAt, time.Second)
+	})
+
+	t.Run("empty user ID", func(t *testing.T) {
+		_, _, err := NewPasswordResetToken("", time.Hour)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		_, first, err := NewPasswordResetToken("user-123", time.Hour)
+		require.NoError(t, err)
+		_, second, err := NewPasswordResetToken("user-123", time.Hour)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestPasswordResetToken_IsUsable(t *testing.T) {
+	t.Run("used token", func(t *testing.T) {
+		token, _, err := NewPasswordResetToken("user-123", time.Hour)
+		require.NoError(t, err)
+
+		usedAt := time.Now()
+		token.UsedAt = &usedAt
+
+		assert.False(t, token.IsUsable())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token, _, err := NewPasswordResetToken("user-123", time.Hour)
+		require.NoError(t, err)
+
+		token.ExpiresAt = time.Now().Add(-time.Second)
+
+		assert.False(t, token.IsUsable())
+	})
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRefreshTokenTTL is how long a session can go unused before its refresh token expires.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// Session is a server-side login session backing a refresh token.
+// AI-hint: Access tokens carry the session ID so revoking the session invalidates them
+// immediately. Only the SHA-256 hash of the current refresh token is stored; every
+// refresh rotates it, and presenting an older token revokes the whole session.
+// MFAVerified records that the login completed two-factor authentication.
+type Session struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	RefreshTokenHash string     `json:"-"`
+	MFAVerified      bool       `json:"mfa_verified"`
+	CreatedAt        time.Time  `json:"created_at"`
+	RefreshedAt      time.Time  `json:"refreshed_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewSession starts a session for the user and returns it with its first refresh token.
+// AI-hint: The plaintext refresh token is returned only here and from Rotate; it is
+// "<session ID>.<random secret>" so the session can be looked up without scanning hashes.
+func NewSession(userID string, ttl time.Duration) (*Session, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultRefreshTokenTTL
+	}
+
+	now := time.Now()
+	session := &Session{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		CreatedAt:   now,
+		RefreshedAt: now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	token, err := session.issueRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+	return session, token, nil
+}
+
+// Rotate replaces the session's refresh token and extends its expiry.
+// AI-hint: Callers must persist the new hash conditionally on the previous one
+// (see SessionRepository.Rotate) so concurrent refreshes cannot both succeed.
+func (s *Session) Rotate(ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultRefreshTokenTTL
+	}
+
+	token, err := s.issueRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	s.RefreshedAt = time.Now()
+	s.ExpiresAt = s.RefreshedAt.Add(ttl)
+	return token, nil
+}
+
+// MatchesRefreshToken reports whether token is the session's current refresh token.
+func (s *Session) MatchesRefreshToken(token string) bool {
+	hash := HashRefreshToken(token)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(s.RefreshTokenHash)) == 1
+}
+
+// IsActive reports whether the session is neither revoked nor expired.
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// Revoke ends the session.
+// AI-hint: Revoking twice keeps the original revocation time.
+func (s *Session) Revoke() {
+	if s.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	s.RevokedAt = &now
+}
+
+// issueRefreshToken generates a new refresh token and stores its hash on the session.
+func (s *Session) issueRefreshToken() (string, error) {
+	secret, err := randomTokenSecret()
+	if err != nil {
+		return "", err
+	}
+
+	token := s.ID + "." + secret
+	s.RefreshTokenHash = HashRefreshToken(token)
+	return token, nil
+}
+
+// ParseRefreshToken extracts the session ID from a refresh token.
+// AI-hint: Only checks the format; the token itself is verified with MatchesRefreshToken.
+func ParseRefreshToken(token string) (string, error) {
+	sessionID, secret, found := strings.Cut(token, ".")
+	if !found || secret == "" {
+		return "", ErrInvalidRefreshToken
+	}
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return "", ErrInvalidRefreshToken
+	}
+	return sessionID, nil
+}
+
+// HashRefreshToken returns the hex-encoded SHA-256 hash stored for a refresh token.
+// AI-hint: Refresh tokens carry 256 bits of randomness, so a fast unsalted hash is enough.
+func HashRefreshToken(token string) string {
+	return hashToken(token)
+}
+
+// randomTokenSecret returns 256 bits of randomness encoded for use in URLs.
+func randomTokenSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of a high-entropy token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SessionRepository defines the interface for session persistence operations.
+// AI-hint: Rotate must only succeed while previousHash is still the stored hash and the
+// session is not revoked; otherwise it returns ErrRefreshTokenReused.
+type SessionRepository interface {
+	Create(ctx interface{}, session *Session) error
+	GetByID(ctx interface{}, id string) (*Session, error)
+	Rotate(ctx interface{}, session *Session, previousHash string) error
+	Revoke(ctx interface{}, id string) error
+	RevokeAllForUser(ctx interface{}, userID string) error
+	MarkMFAVerified(ctx interface{}, id string) error
+}
+
+// Error types for sessions.
+// AI-hint: All of these map to 401 responses; the refresh endpoint also clears cookies.
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionRevoked      = errors.New("session has been revoked or has expired")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+)
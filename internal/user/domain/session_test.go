@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSession(t *testing.T) {
+	t.Run("issues a refresh token bound to the session", func(t *testing.T) {
+		session, token, err := NewSession("user-123", time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", session.UserID)
+		assert.True(t, strings.HasPrefix(token, session.ID+"."))
+		assert.NotContains(t, session.RefreshTokenHash, token)
+		assert.True(t, session.MatchesRefreshToken(token))
+		assert.True(t, session.IsActive())
+		assert.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt, time.Minute)
+	})
+
+	t.Run("falls back to the default TTL", func(t *testing.T) {
+		session, _, err := NewSession("user-123", 0)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultRefreshTokenTTL), session.ExpiresAt, time.Minute)
+	})
+
+	t.Run("requires a user", func(t *testing.T) {
+		_, _, err := NewSession("", time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestSession_Rotate(t *testing.T) {
+	session, oldToken, err := NewSession("user-123", time.Hour)
+	require.NoError(t, err)
+
+	newToken, err := session.Rotate(2 * time.Hour)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, oldToken, newToken)
+	assert.False(t, session.MatchesRefreshToken(oldToken), "rotated token must no longer match")
+	assert.True(t, session.MatchesRefreshToken(newToken))
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), session.ExpiresAt, time.Minute)
+}
+
+func TestSession_Revoke(t *testing.T) {
+	session, _, err := NewSession("user-123", time.Hour)
+	require.NoError(t, err)
+
+	session.Revoke()
+	require.NotNil(t, session.RevokedAt)
+	assert.False(t, session.IsActive())
+
+	revokedAt := *session.RevokedAt
+	session.Revoke()
+	assert.Equal(t, revokedAt, *session.RevokedAt)
+}
+
+func TestSession_IsActiveExpired(t *testing.T) {
+	session, _, err := NewSession("user-123", time.Hour)
+	require.NoError(t, err)
+
+	session.ExpiresAt = time.Now().Add(-time.Second)
+	assert.False(t, session.IsActive())
+}
+
+func TestParseRefreshToken(t *testing.T) {
+	session, token, err := NewSession("user-123", time.Hour)
+	require.NoError(t, err)
+
+	sessionID, err := ParseRefreshToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, sessionID)
+
+	for _, invalid := range []string{"", "no-separator", session.ID + ".", "not-a-uuid.secret"} {
+		_, err := ParseRefreshToken(invalid)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken, invalid)
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// SetupToken authorizes the first-run setup that gives the first Super User a password.
+// AI-hint: Issued at startup until setup is complete, and only logged to the
+// server output, so whoever completes setup must have access to the deployment. Only the
+// SHA-256 hash is stored; a newer token replaces older ones.
+type SetupToken struct {
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewSetupToken creates a setup token and returns its plaintext value.
+// AI-hint: The plaintext is only returned here so it can be logged.
+func NewSetupToken() (*SetupToken, string, error) {
+	plaintext, err := randomTokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &SetupToken{
+		TokenHash: HashSetupToken(plaintext),
+		CreatedAt: time.Now(),
+	}, plaintext, nil
+}
+
+// HashSetupToken returns the hex-encoded SHA-256 hash stored for a setup token.
+func HashSetupToken(token string) string {
+	return hashToken(token)
+}
+
+// SetupTokenRepository defines the interface for setup token persistence.
+// AI-hint: Replace keeps only the given token. Complete stores the Super User (creating it
+// when isNew), records for good that setup is done, and removes every token in one
+// transaction. With a tokenHash it first consumes that token and returns
+// ErrInvalidSetupToken if there is none, so a token works once; it returns
+// ErrSetupCompleted if setup was completed concurrently.
+type SetupTokenRepository interface {
+	Replace(ctx interface{}, token *SetupToken) error
+	Complete(ctx interface{}, tokenHash string, superUser *User, isNew bool) error
+	IsCompleted(ctx interface{}) (bool, error)
+}
+
+// Error types for first-run setup.
+var (
+	ErrSetupCompleted    = errors.New("setup has already been completed")
+	ErrInvalidSetupToken = errors.New("setup token is invalid")
+)
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSetupToken(t *testing.T) {
+	token, plaintext, err := NewSetupToken()
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, plaintext)
+	assert.Equal(t, HashSetupToken(plaintext), token.TokenHash)
+	assert.NotEqual(t, plaintext, token.TokenHash, "only the hash is kept")
+
+	_, other, err := NewSetupToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, plaintext, other)
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238 defaults understood by all authenticator apps).
+const (
+	TOTPDigits      = 6
+	TOTPPeriod      = 30 * time.Second
+	totpSecretBytes = 20
+	// totpSkewSteps accepts codes from one step before and after the current one
+	// to tolerate clock drift between server and phone.
+	totpSkewSteps = 1
+)
+
+// TOTPCredential is a user's time-based one-time password authenticator.
+// AI-hint: Created unconfirmed by enrollment and confirmed once the user proves their
+// app produces valid codes; only confirmed credentials are asked for at login.
+// LastUsedStep blocks replaying a code within its validity window.
+type TOTPCredential struct {
+	UserID       string     `json:"user_id"`
+	Secret       string     `json:"-"` // Base32, as shown to authenticator apps
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// NewTOTPCredential generates a fresh unconfirmed TOTP secret for the user.
+func NewTOTPCredential(userID string) (*TOTPCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &TOTPCredential{
+		UserID:    userID,
+		Secret:    base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsConfirmed reports whether enrollment was completed.
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.ConfirmedAt != nil
+}
+
+// Confirm completes enrollment.
+func (c *TOTPCredential) Confirm() {
+	if c.ConfirmedAt != nil {
+		return
+	}
+	now := time.Now()
+	c.ConfirmedAt = &now
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps import, usually as a QR code.
+// AI-hint: Format from the Google Authenticator key URI spec; the label is "issuer:account".
+func (c *TOTPCredential) ProvisioningURI(issuer, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", c.Secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// MatchCode checks a code against the credential and returns the time step it belongs to.
+// AI-hint: Accepts the current step and totpSkewSteps around it, but never a step at or
+// before LastUsedStep. Callers must persist the returned step (conditionally, see
+// MFARepository.RecordTOTPStep) before accepting the code.
+func (c *TOTPCredential) MatchCode(code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(c.Secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := totpStep(now)
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= c.LastUsedStep {
+			continue
+		}
+		expected := hotp(key, uint64(step), TOTPDigits)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpStep returns the RFC 6238 time step counter for t.
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// hotp computes an RFC 4226 HMAC-SHA1 one-time password.
+func hotp(key []byte, counter uint64, digits int) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	// Test vectors from RFC 6238 Appendix B (SHA-1)
+	key := []byte("12345678901234567890")
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tc := range testCases {
+		step := totpStep(time.Unix(tc.unix, 0))
+		assert.Equal(t, tc.expected, hotp(key, uint64(step), 8), "T=%d", tc.unix)
+	}
+}
+
+func TestNewTOTPCredential(t *testing.T) {
+	credential, err := NewTOTPCredential("user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", credential.UserID)
+	assert.False(t, credential.IsConfirmed())
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(credential.Secret)
+	require.NoError(t, err)
+	assert.Len(t, key, totpSecretBytes)
+
+	_, err = NewTOTPCredential("")
+	assert.Error(t, err)
+}
+
+func TestTOTPCredential_ProvisioningURI(t *testing.T) {
+	credential, err := NewTOTPCredential("user-123")
+	require.NoError(t, err)
+
+	uri := credential.ProvisioningURI("Feedback Hub", "jane@example.com")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Feedback%20Hub:jane@example.com?"))
+	assert.Contains(t, uri, "secret="+credential.Secret)
+	assert.Contains(t, uri, "issuer=Feedback+Hub")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestTOTPCredential_MatchCode(t *testing.T) {
+	credential, err := NewTOTPCredential("user-123")
+	require.NoError(t, err)
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(credential.Secret)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	current := totpStep(now)
+	codeAt := func(step int64) string { return hotp(key, uint64(step), TOTPDigits) }
+
+	t.Run("accepts the current code", func(t *testing.T) {
+		step, ok := credential.MatchCode(codeAt(current), now)
+		assert.True(t, ok)
+		assert.Equal(t, current, step)
+	})
+
+	t.Run("tolerates one step of clock drift", func(t *testing.T) {
+		_, ok := credential.MatchCode(codeAt(current-1), now)
+		assert.True(t, ok)
+		_, ok = credential.MatchCode(codeAt(current+1), now)
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects codes outside the window", func(t *testing.T) {
+		_, ok := credential.MatchCode(codeAt(current-2), now)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects malformed codes", func(t *testing.T) {
+		_, ok := credential.MatchCode("12345", now)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects replayed codes", func(t *testing.T) {
+		replayed := *credential
+		replayed.LastUsedStep = current
+		_, ok := replayed.MatchCode(codeAt(current), now)
+		assert.False(t, ok)
+
+		step, ok := replayed.MatchCode(codeAt(current+1), now)
+		assert.True(t, ok, "a later code is still accepted")
+		assert.Equal(t, current+1, step)
+	})
+}
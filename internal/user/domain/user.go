@@ -10,13 +10,14 @@ import (
 // AI-hint: Core domain entity containing user business logic and invariants.
 // Enforces email uniqueness, role assignment, and Super User protection rules.
 type User struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	Name         string    `json:"name"`
-	PasswordHash string    `json:"-"` // Never expose in JSON
-	RoleID       string    `json:"role_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	PasswordHash    string     `json:"-"` // Never expose in JSON
+	RoleID          string     `json:"role_id"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // NewUser creates a new User with validation (without password).
@@ -107,6 +108,33 @@ func (u *User) UpdateRole(roleID string) error {
 	return nil
 }
 
+// UpdatePasswordHash replaces the user's password hash.
+// AI-hint: Hashing happens in the infrastructure layer; the domain only stores the result.
+func (u *User) UpdatePasswordHash(passwordHash string) error {
+	if passwordHash == "" {
+		return errors.New("password hash cannot be empty")
+	}
+	u.PasswordHash = passwordHash
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsEmailVerified reports whether the user has proven they own their email address.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// MarkEmailVerified records that the user owns their email address.
+// AI-hint: Idempotent - verifying again keeps the original verification time.
+func (u *User) MarkEmailVerified() {
+	if u.EmailVerifiedAt != nil {
+		return
+	}
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
 // isValidEmail performs basic email validation.
 // AI-hint: Simple email validation for domain integrity.
 // More sophisticated validation can be added in future iterations.
@@ -125,13 +153,14 @@ func isValidEmail(email string) bool {
 
 // Repository defines the interface for user persistence operations.
 // AI-hint: Repository pattern interface for dependency inversion.
-// Keeps domain logic independent of persistence implementation.
+// Keeps domain logic independent of persistence implementation. Delete keeps the
+// user's ideas: they move to reassignToUserID, or are anonymized when it is empty.
 type Repository interface {
 	Create(ctx interface{}, user *User) error
 	GetByID(ctx interface{}, id string) (*User, error)
 	GetByEmail(ctx interface{}, email string) (*User, error)
 	Update(ctx interface{}, user *User) error
-	Delete(ctx interface{}, id string) error
+	Delete(ctx interface{}, id string, reassignToUserID string) error
 	List(ctx interface{}) ([]*User, error)
 	GetByRoleID(ctx interface{}, roleID string) ([]*User, error)
 }
@@ -144,15 +173,16 @@ type Service interface {
 	GetUser(ctx interface{}, id string) (*User, error)
 	UpdateUser(ctx interface{}, id, name string, updatedByUserID string) (*User, error)
 	UpdateUserRole(ctx interface{}, id, roleID string, updatedByUserID string) (*User, error)
-	DeleteUser(ctx interface{}, id string, deletedByUserID string) error
+	DeleteUser(ctx interface{}, id, reassignToUserID string, deletedByUserID string) error
 	ListUsers(ctx interface{}) ([]*User, error)
 }
 
 // Error types for the user domain.
 // AI-hint: Domain-specific errors for clear error handling and business rules.
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrInvalidUserData    = errors.New("invalid user data")
-	ErrUnauthorized       = errors.New("unauthorized operation")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrEmailAlreadyExists  = errors.New("email already exists")
+	ErrInvalidUserData     = errors.New("invalid user data")
+	ErrUnauthorized        = errors.New("unauthorized operation")
+	ErrInvalidReassignment = errors.New("invalid content reassignment target")
 )
@@ -91,6 +91,39 @@ func TestUser_UpdateRole(t *testing.T) {
 	})
 }
 
+func TestUser_UpdatePasswordHash(t *testing.T) {
+	user, _ := NewUserWithPassword("123", "test@example.com", "Test User", "old-hash", "role-123")
+	originalUpdatedAt := user.UpdatedAt
+
+	t.Run("valid password hash update", func(t *testing.T) {
+		err := user.UpdatePasswordHash("new-hash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-hash", user.PasswordHash)
+		assert.True(t, user.UpdatedAt.After(originalUpdatedAt))
+	})
+
+	t.Run("empty password hash", func(t *testing.T) {
+		err := user.UpdatePasswordHash("")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password hash cannot be empty")
+		assert.Equal(t, "new-hash", user.PasswordHash)
+	})
+}
+
+func TestUser_MarkEmailVerified(t *testing.T) {
+	user, _ := NewUserWithPassword("123", "test@example.com", "Test User", "hash", "role-123")
+	assert.False(t, user.IsEmailVerified())
+
+	user.MarkEmailVerified()
+	assert.True(t, user.IsEmailVerified())
+	verifiedAt := *user.EmailVerifiedAt
+
+	user.MarkEmailVerified()
+	assert.Equal(t, verifiedAt, *user.EmailVerifiedAt, "verifying again keeps the original time")
+}
+
 func TestIsValidEmail(t *testing.T) {
 	testCases := []struct {
 		email    string
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verifying tokens.
+const minRSAKeyBits = 2048
+
+// JWTKey is a public key that verifies access tokens, optionally paired with the
+// private key that signs them.
+// AI-hint: RSA keys sign with RS256 and Ed25519 keys with EdDSA. ID is the key's RFC 7638
+// thumbprint, so the "kid" header stays the same wherever the key is loaded.
+type JWTKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PublicKey  crypto.PublicKey
+	privateKey crypto.Signer
+}
+
+// CanSign reports whether the private key is available.
+func (k *JWTKey) CanSign() bool {
+	return k.privateKey != nil
+}
+
+// JWK describes a public key in JSON Web Key format.
+// AI-hint: Used both for the keys this API publishes and for keys read from identity
+// providers; Y is only set for elliptic curve keys.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public half of the key in JSON Web Key format.
+func (k *JWTKey) JWK() JWK {
+	jwk := JWK{Use: "sig", Alg: k.Method.Alg(), Kid: k.ID}
+	switch publicKey := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+	return jwk
+}
+
+// LoadJWTSigningKey reads the private key that signs access tokens from a PEM file.
+// AI-hint: The file must hold exactly one RSA (at least 2048 bits) or Ed25519 private key
+// in PKCS#8 or, for RSA, PKCS#1 form.
+func LoadJWTSigningKey(path string) (*JWTKey, error) {
+	keys, err := LoadJWTKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != 1 || !keys[0].CanSign() {
+		return nil, fmt.Errorf("JWT signing key file %s must contain exactly one private key", path)
+	}
+	return keys[0], nil
+}
+
+// LoadJWTKeys reads every key from a PEM file.
+// AI-hint: Used for verification keys kept during rotation; private keys are accepted as
+// well, but only their public half is needed to verify.
+func LoadJWTKeys(path string) ([]*JWTKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT key file: %w", err)
+	}
+
+	keys, err := ParseJWTKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT keys from %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// ParseJWTKeys parses every PEM block in data as a JWT key.
+// AI-hint: Accepts PKCS#8 and PKCS#1 private keys and PKIX public keys; any other block
+// type is an error so a misplaced certificate is not silently ignored.
+func ParseJWTKeys(data []byte) ([]*JWTKey, error) {
+	var keys []*JWTKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var parsed interface{}
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "PUBLIC KEY":
+			parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := newJWTKey(parsed)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no PEM encoded keys found")
+	}
+	return keys, nil
+}
+
+// newJWTKey wraps a parsed RSA or Ed25519 key.
+func newJWTKey(parsed interface{}) (*JWTKey, error) {
+	key := &JWTKey{}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.PublicKey, key.privateKey = jwt.SigningMethodRS256, &k.PublicKey, k
+	case *rsa.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.PublicKey, key.privateKey = jwt.SigningMethodEdDSA, k.Public(), k
+	case ed25519.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; use RSA or Ed25519", parsed)
+	}
+
+	if rsaKey, ok := key.PublicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key has %d bits; at least %d are required", rsaKey.N.BitLen(), minRSAKeyBits)
+	}
+
+	key.ID = jwkThumbprint(key.JWK())
+	return key, nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint of a JWK.
+// AI-hint: Only the required members in lexicographic order are hashed, so the ID does
+// not depend on "use", "alg", or "kid".
+func jwkThumbprint(jwk JWK) string {
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testRSAKeyOnce sync.Once
+	testRSAKey     *rsa.PrivateKey
+)
+
+// testRSAPrivateKey returns a 2048-bit RSA key shared by the tests, since generating one is slow.
+func testRSAPrivateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	testRSAKeyOnce.Do(func() {
+		testRSAKey, _ = rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	})
+	require.NotNil(t, testRSAKey)
+	return testRSAKey
+}
+
+func testEd25519PrivateKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return privateKey
+}
+
+func pemBlock(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func pkcs8PEM(t *testing.T, privateKey interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	return pemBlock("PRIVATE KEY", der)
+}
+
+func pkixPEM(t *testing.T, publicKey interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return pemBlock("PUBLIC KEY", der)
+}
+
+// parseOneJWTKey parses PEM data that must hold exactly one key.
+func parseOneJWTKey(t *testing.T, data []byte) *JWTKey {
+	t.Helper()
+	keys, err := ParseJWTKeys(data)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	return keys[0]
+}
+
+func TestParseJWTKeys(t *testing.T) {
+	rsaKey := testRSAPrivateKey(t)
+	edKey := testEd25519PrivateKey(t)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		method  jwt.SigningMethod
+		canSign bool
+	}{
+		{"RSA PKCS#1 private key", pemBlock("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), jwt.SigningMethodRS256, true},
+		{"RSA PKCS#8 private key", pkcs8PEM(t, rsaKey), jwt.SigningMethodRS256, true},
+		{"RSA PKIX public key", pkixPEM(t, &rsaKey.PublicKey), jwt.SigningMethodRS256, false},
+		{"Ed25519 PKCS#8 private key", pkcs8PEM(t, edKey), jwt.SigningMethodEdDSA, true},
+		{"Ed25519 PKIX public key", pkixPEM(t, edKey.Public()), jwt.SigningMethodEdDSA, false},
+	}
+
+	ids := map[jwt.SigningMethod]string{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := parseOneJWTKey(t, tt.data)
+			assert.Equal(t, tt.method, key.Method)
+			assert.Equal(t, tt.canSign, key.CanSign())
+			assert.NotEmpty(t, key.ID)
+
+			// Every encoding of the same key gets the same ID
+			if id, seen := ids[tt.method]; seen {
+				assert.Equal(t, id, key.ID)
+			}
+			ids[tt.method] = key.ID
+		})
+	}
+
+	t.Run("several keys in one file", func(t *testing.T) {
+		data := append(pkixPEM(t, &rsaKey.PublicKey), pkixPEM(t, edKey.Public())...)
+		keys, err := ParseJWTKeys(data)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, ids[jwt.SigningMethodRS256], keys[0].ID)
+		assert.Equal(t, ids[jwt.SigningMethodEdDSA], keys[1].ID)
+	})
+}
+
+func TestParseJWTKeys_Rejects(t *testing.T) {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"1024-bit RSA private key", pemBlock("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(weakKey))},
+		{"1024-bit RSA public key", pkixPEM(t, &weakKey.PublicKey)},
+		{"ECDSA private key", pkcs8PEM(t, ecKey)},
+		{"ECDSA public key", pkixPEM(t, &ecKey.PublicKey)},
+		{"certificate block", pemBlock("CERTIFICATE", []byte("not a key"))},
+		{"corrupt key", pemBlock("PRIVATE KEY", []byte("not a key"))},
+		{"no PEM data", []byte("not a key")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseJWTKeys(tt.data)
+			assert.Error(t, err)
+			assert.Nil(t, keys)
+		})
+	}
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	t.Run("RSA key from RFC 7638 section 3.1", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "RSA",
+			N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			E:   "AQAB",
+			Alg: "RS256",
+			Kid: "2011-04-29",
+		}
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwkThumbprint(jwk))
+	})
+
+	t.Run("Ed25519 key from RFC 8037 appendix A.3", func(t *testing.T) {
+		x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+		require.NoError(t, err)
+
+		key, err := newJWTKey(ed25519.PublicKey(x))
+		require.NoError(t, err)
+		assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", key.ID)
+	})
+}
+
+func TestJWTKey_JWK(t *testing.T) {
+	rsaKey := parseOneJWTKey(t, pkixPEM(t, &testRSAPrivateKey(t).PublicKey))
+	rsaJWK := rsaKey.JWK()
+	assert.Equal(t, "RSA", rsaJWK.Kty)
+	assert.Equal(t, "RS256", rsaJWK.Alg)
+	assert.Equal(t, "sig", rsaJWK.Use)
+	assert.Equal(t, rsaKey.ID, rsaJWK.Kid)
+	assert.Equal(t, "AQAB", rsaJWK.E)
+	assert.NotEmpty(t, rsaJWK.N)
+	assert.Empty(t, rsaJWK.X)
+
+	edPublicKey := testEd25519PrivateKey(t).Public().(ed25519.PublicKey)
+	edKey := parseOneJWTKey(t, pkixPEM(t, edPublicKey))
+	edJWK := edKey.JWK()
+	assert.Equal(t, "OKP", edJWK.Kty)
+	assert.Equal(t, "EdDSA", edJWK.Alg)
+	assert.Equal(t, "Ed25519", edJWK.Crv)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(edPublicKey), edJWK.X)
+	assert.Empty(t, edJWK.N)
+}
+
+func TestLoadJWTSigningKey(t *testing.T) {
+	edKey := testEd25519PrivateKey(t)
+	dir := t.TempDir()
+	writeKeyFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	key, err := LoadJWTSigningKey(writeKeyFile("signing.pem", pkcs8PEM(t, edKey)))
+	require.NoError(t, err)
+	assert.True(t, key.CanSign())
+
+	_, err = LoadJWTSigningKey(writeKeyFile("public.pem", pkixPEM(t, edKey.Public())))
+	assert.Error(t, err, "a public key cannot sign")
+
+	_, err = LoadJWTSigningKey(writeKeyFile("two.pem", append(pkcs8PEM(t, edKey), pkcs8PEM(t, testEd25519PrivateKey(t))...)))
+	assert.Error(t, err, "the signing key file must hold one key")
+
+	_, err = LoadJWTSigningKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
@@ -2,70 +2,122 @@ package auth
 
 import (
 	"fmt"
-	"os"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// DefaultAccessTokenTTL is how long an access token is valid.
+// AI-hint: Kept short because clients renew it through POST /auth/refresh.
+const DefaultAccessTokenTTL = 15 * time.Minute
+
 // JWTClaims represents the claims stored in the JWT token.
 // AI-hint: Custom JWT claims that include user info and standard claims for security.
+// SessionID ties the token to a server-side session so logout revokes it immediately.
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Email    string `json:"email"`
-	RoleName string `json:"role_name"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	RoleName  string `json:"role_name"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
+// DevJWTSecret is the HS256 secret used in development when JWT_SECRET is not set.
+// AI-hint: Startup refuses to use it in production; see the server's JWT setup.
+const DevJWTSecret = "default-dev-secret-change-in-production"
+
 // JWTService handles JWT token operations.
 // AI-hint: Service for JWT token generation, validation, and parsing with configurable expiration.
+// Signs with an RSA or Ed25519 key and a "kid" header when a key pair is configured, and
+// accepts tokens from any of its verification keys so keys can be rotated. Without a key
+// pair it falls back to HS256 with a shared secret.
 type JWTService struct {
-	secretKey []byte
+	secretKey        []byte
+	signingKey       *JWTKey
+	verificationKeys map[string]*JWTKey
+	accessTokenTTL   time.Duration
 }
 
-// NewJWTService creates a new JWT service instance.
-// AI-hint: Factory method for JWT service with secret key from environment variables.
-func NewJWTService() *JWTService {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		// Generate a default secret for development (should be set in production)
-		secretKey = "default-dev-secret-change-in-production"
+// NewJWTService creates a JWT service that signs with the shared HS256 secret.
+// AI-hint: Legacy and development mode; tokens cannot be verified by other services
+// without sharing the secret, so the JWKS document stays empty.
+// A non-positive accessTokenTTL falls back to DefaultAccessTokenTTL.
+func NewJWTService(secretKey string, accessTokenTTL time.Duration) *JWTService {
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = DefaultAccessTokenTTL
 	}
 
 	return &JWTService{
-		secretKey: []byte(secretKey),
+		secretKey:      []byte(secretKey),
+		accessTokenTTL: accessTokenTTL,
 	}
 }
 
-// GenerateToken creates a new JWT token for the given user.
-// AI-hint: Token generation with user claims and 24-hour expiration for web apps.
-func (s *JWTService) GenerateToken(userID, email, roleName string) (string, error) {
+// NewKeyPairJWTService creates a JWT service that signs with signingKey.
+// AI-hint: Tokens are verified against signingKey plus verificationKeys, which hold the
+// public keys of previous (or upcoming) signing keys during a rotation. HS256 tokens are
+// rejected. A non-positive accessTokenTTL falls back to DefaultAccessTokenTTL.
+func NewKeyPairJWTService(signingKey *JWTKey, verificationKeys []*JWTKey, accessTokenTTL time.Duration) (*JWTService, error) {
+	if signingKey == nil || !signingKey.CanSign() {
+		return nil, fmt.Errorf("JWT signing key must include its private key")
+	}
+
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	keys := map[string]*JWTKey{signingKey.ID: signingKey}
+	for _, key := range verificationKeys {
+		if _, exists := keys[key.ID]; !exists {
+			keys[key.ID] = key
+		}
+	}
+
+	return &JWTService{
+		signingKey:       signingKey,
+		verificationKeys: keys,
+		accessTokenTTL:   accessTokenTTL,
+	}, nil
+}
+
+// AccessTokenTTL returns how long generated tokens are valid.
+// AI-hint: Used to align the auth cookie lifetime with the token expiry.
+func (s *JWTService) AccessTokenTTL() time.Duration {
+	return s.accessTokenTTL
+}
+
+// GenerateToken creates a new short-lived access token for the given user session.
+// AI-hint: Token generation with user claims and the session ID; expires after AccessTokenTTL.
+func (s *JWTService) GenerateToken(userID, email, roleName, sessionID string) (string, error) {
 	claims := JWTClaims{
-		UserID:   userID,
-		Email:    email,
-		RoleName: roleName,
+		UserID:    userID,
+		Email:     email,
+		RoleName:  roleName,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "feedback-hub",
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	if s.signingKey == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(s.secretKey)
+	}
+
+	token := jwt.NewWithClaims(s.signingKey.Method, claims)
+	token.Header["kid"] = s.signingKey.ID
+	return token.SignedString(s.signingKey.privateKey)
 }
 
 // ValidateToken parses and validates a JWT token.
-// AI-hint: Token validation with expiration check and signature verification.
+// AI-hint: Token validation with expiration check and signature verification against the
+// key named by the token's "kid" header.
 func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.verificationKey)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -79,14 +131,47 @@ func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return claims, nil
 }
 
-// RefreshToken generates a new token if the current one is valid.
-// AI-hint: Token refresh mechanism for extending user sessions without re-login.
-func (s *JWTService) RefreshToken(tokenString string) (string, error) {
-	claims, err := s.ValidateToken(tokenString)
-	if err != nil {
-		return "", err
+// verificationKey picks the key that verifies a token.
+// AI-hint: The algorithm must match the key's own, so an RSA public key can never be
+// misused as an HMAC secret.
+func (s *JWTService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.signingKey == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secretKey, nil
 	}
 
-	// Generate new token with same user info but fresh expiration
-	return s.GenerateToken(claims.UserID, claims.Email, claims.RoleName)
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.PublicKey, nil
+}
+
+// JWKS returns the public keys that verify access tokens.
+// AI-hint: Served at GET /.well-known/jwks.json so other services can verify tokens. The
+// signing key comes first; the set is empty in HS256 mode because secrets are never published.
+func (s *JWTService) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	if s.signingKey == nil {
+		return set
+	}
+
+	set.Keys = append(set.Keys, s.signingKey.JWK())
+	ids := make([]string, 0, len(s.verificationKeys))
+	for id := range s.verificationKeys {
+		if id != s.signingKey.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		set.Keys = append(set.Keys, s.verificationKeys[id].JWK())
+	}
+	return set
 }
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testClaims() JWTClaims {
+	return JWTClaims{
+		UserID:    "user-id",
+		Email:     "user@example.com",
+		RoleName:  "Contributor",
+		SessionID: "session-id",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+}
+
+// signTestToken signs testClaims with an arbitrary method, kid, and key.
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, testClaims())
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// assertRoundTrip checks that the service accepts the tokens it generates.
+func assertRoundTrip(t *testing.T, service *JWTService) string {
+	t.Helper()
+	token, err := service.GenerateToken("user-id", "user@example.com", "Contributor", "session-id")
+	require.NoError(t, err)
+
+	claims, err := service.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", claims.UserID)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.Equal(t, "Contributor", claims.RoleName)
+	assert.Equal(t, "session-id", claims.SessionID)
+	return token
+}
+
+// tokenKid returns the kid header of a token without verifying it.
+func tokenKid(t *testing.T, token string) interface{} {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	return parsed.Header["kid"]
+}
+
+func TestJWTService_RoundTrip(t *testing.T) {
+	t.Run("HS256 secret", func(t *testing.T) {
+		token := assertRoundTrip(t, NewJWTService("secret", 0))
+		assert.Nil(t, tokenKid(t, token))
+	})
+
+	t.Run("RSA key pair", func(t *testing.T) {
+		key := parseOneJWTKey(t, pkcs8PEM(t, testRSAPrivateKey(t)))
+		service, err := NewKeyPairJWTService(key, nil, 0)
+		require.NoError(t, err)
+
+		token := assertRoundTrip(t, service)
+		assert.Equal(t, key.ID, tokenKid(t, token))
+	})
+
+	t.Run("Ed25519 key pair", func(t *testing.T) {
+		key := parseOneJWTKey(t, pkcs8PEM(t, testEd25519PrivateKey(t)))
+		service, err := NewKeyPairJWTService(key, nil, 0)
+		require.NoError(t, err)
+
+		token := assertRoundTrip(t, service)
+		assert.Equal(t, key.ID, tokenKid(t, token))
+	})
+}
+
+func TestNewKeyPairJWTService_RequiresPrivateKey(t *testing.T) {
+	publicKey := parseOneJWTKey(t, pkixPEM(t, testEd25519PrivateKey(t).Public()))
+
+	_, err := NewKeyPairJWTService(publicKey, nil, 0)
+	assert.Error(t, err)
+
+	_, err = NewKeyPairJWTService(nil, nil, 0)
+	assert.Error(t, err)
+}
+
+func TestJWTService_Rotation(t *testing.T) {
+	oldPrivate := testEd25519PrivateKey(t)
+	oldKey := parseOneJWTKey(t, pkcs8PEM(t, oldPrivate))
+	newKey := parseOneJWTKey(t, pkcs8PEM(t, testRSAPrivateKey(t)))
+
+	oldService, err := NewKeyPairJWTService(oldKey, nil, 0)
+	require.NoError(t, err)
+	oldToken, err := oldService.GenerateToken("user-id", "user@example.com", "Contributor", "session-id")
+	require.NoError(t, err)
+
+	t.Run("rotated-out key still verifies", func(t *testing.T) {
+		retiredKey := parseOneJWTKey(t, pkixPEM(t, oldPrivate.Public()))
+		service, err := NewKeyPairJWTService(newKey, []*JWTKey{retiredKey}, 0)
+		require.NoError(t, err)
+
+		claims, err := service.ValidateToken(oldToken)
+		require.NoError(t, err)
+		assert.Equal(t, "user-id", claims.UserID)
+
+		// New tokens are signed with the new key
+		assert.Equal(t, newKey.ID, tokenKid(t, assertRoundTrip(t, service)))
+	})
+
+	t.Run("dropped key no longer verifies", func(t *testing.T) {
+		service, err := NewKeyPairJWTService(newKey, nil, 0)
+		require.NoError(t, err)
+
+		_, err = service.ValidateToken(oldToken)
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTService_RejectsForeignTokens(t *testing.T) {
+	rsaPrivate := testRSAPrivateKey(t)
+	rsaKey := parseOneJWTKey(t, pkcs8PEM(t, rsaPrivate))
+	edKey := parseOneJWTKey(t, pkcs8PEM(t, testEd25519PrivateKey(t)))
+	service, err := NewKeyPairJWTService(rsaKey, []*JWTKey{edKey}, 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown kid", signTestToken(t, jwt.SigningMethodRS256, "unknown", rsaPrivate)},
+		{"missing kid", signTestToken(t, jwt.SigningMethodRS256, "", rsaPrivate)},
+		{"HS256 keyed with the RSA public key", signTestToken(t, jwt.SigningMethodHS256, rsaKey.ID, pkixPEM(t, &rsaPrivate.PublicKey))},
+		{"HS256 keyed with the shared secret", signTestToken(t, jwt.SigningMethodHS256, rsaKey.ID, []byte(DevJWTSecret))},
+		{"RS256 under the EdDSA kid", signTestToken(t, jwt.SigningMethodRS256, edKey.ID, rsaPrivate)},
+		{"unsigned", signTestToken(t, jwt.SigningMethodNone, rsaKey.ID, jwt.UnsafeAllowNoneSignatureType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := service.ValidateToken(tt.token)
+			assert.Error(t, err)
+			assert.Nil(t, claims)
+		})
+	}
+
+	t.Run("HS256 service rejects key pair tokens", func(t *testing.T) {
+		_, err := NewJWTService(DevJWTSecret, 0).ValidateToken(signTestToken(t, jwt.SigningMethodRS256, rsaKey.ID, rsaPrivate))
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTService_JWKS(t *testing.T) {
+	t.Run("HS256 publishes no keys", func(t *testing.T) {
+		set := NewJWTService("secret", 0).JWKS()
+		assert.NotNil(t, set.Keys)
+		assert.Empty(t, set.Keys)
+	})
+
+	t.Run("signing key first, then verification keys", func(t *testing.T) {
+		signingKey := parseOneJWTKey(t, pkcs8PEM(t, testRSAPrivateKey(t)))
+		first := parseOneJWTKey(t, pkixPEM(t, testEd25519PrivateKey(t).Public()))
+		second := parseOneJWTKey(t, pkixPEM(t, testEd25519PrivateKey(t).Public()))
+		if first.ID > second.ID {
+			first, second = second, first
+		}
+
+		service, err := NewKeyPairJWTService(signingKey, []*JWTKey{second, signingKey, first}, 0)
+		require.NoError(t, err)
+
+		set := service.JWKS()
+		require.Len(t, set.Keys, 3)
+		assert.Equal(t, signingKey.JWK(), set.Keys[0])
+		assert.Equal(t, first.JWK(), set.Keys[1])
+		assert.Equal(t, second.JWK(), set.Keys[2])
+	})
+}
@@ -0,0 +1,378 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"feedback_hub_2/internal/user/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// oidcMaxResponseBytes caps discovery, JWKS, and token responses.
+	oidcMaxResponseBytes = 1 << 20
+	// oidcKeyRefreshInterval limits JWKS refetches triggered by unknown key IDs.
+	oidcKeyRefreshInterval = time.Minute
+	// oidcClockSkew is the leeway allowed on ID token timestamps.
+	oidcClockSkew = time.Minute
+)
+
+// oidcSigningMethods are the ID token algorithms accepted, grouped by JWK key type.
+var oidcSigningMethods = map[string][]string{
+	"RSA": {"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"},
+	"EC":  {"ES256", "ES384", "ES512"},
+	"OKP": {"EdDSA"},
+}
+
+// OIDCProviderConfig holds the client registration at an OpenID Connect provider.
+// AI-hint: Issuer is the provider's issuer URL, used for discovery and checked against the
+// ID token "iss" claim. Without ClientSecret the client is public and relies on PKCE alone.
+type OIDCProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// oidcMetadata is the subset of the discovery document the login flow needs.
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcIDTokenClaims are the ID token claims read during login.
+type oidcIDTokenClaims struct {
+	Email           string      `json:"email"`
+	EmailVerified   interface{} `json:"email_verified"`
+	Name            string      `json:"name"`
+	Nonce           string      `json:"nonce"`
+	AuthorizedParty string      `json:"azp"`
+	AuthMethods     []string    `json:"amr"`
+	jwt.RegisteredClaims
+}
+
+// OIDCProvider implements domain.IdentityProvider for an OpenID Connect provider.
+// AI-hint: Discovery runs on first use rather than at startup, so an unreachable provider
+// does not keep the API from starting. Signing keys are cached and refetched when an ID
+// token names an unknown key, which picks up key rotation at the provider.
+type OIDCProvider struct {
+	config OIDCProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *oidcMetadata
+	keys          map[string]JWK
+	keysFetchedAt time.Time
+}
+
+// NewOIDCProvider creates a provider client for the given registration.
+// AI-hint: A nil client uses one with a ten second timeout.
+func NewOIDCProvider(config OIDCProviderConfig, client *http.Client) *OIDCProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+
+	return &OIDCProvider{
+		config: config,
+		client: client,
+	}
+}
+
+// AuthorizationURL returns the provider URL that starts an authorization code login with PKCE.
+func (p *OIDCProvider) AuthorizationURL(ctx interface{}, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx.(context.Context))
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange redeems an authorization code and returns the identity from the verified ID token.
+// AI-hint: The ID token must be signed by the provider, issued for this client, unexpired,
+// and carry the nonce of the login that started the flow.
+func (p *OIDCProvider) Exchange(ctx interface{}, code, codeVerifier, nonce string) (*domain.OIDCIdentity, error) {
+	context := ctx.(context.Context)
+
+	metadata, err := p.discover(context)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	if p.config.ClientSecret == "" {
+		form.Set("client_id", p.config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(context, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponseBytes)).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("invalid token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || tokens.Error != "" {
+		return nil, fmt.Errorf("token request rejected (status %d): %s %s", resp.StatusCode, tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+
+	return p.verifyIDToken(context, metadata, tokens.IDToken, nonce)
+}
+
+// verifyIDToken checks an ID token's signature and claims and extracts the identity.
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, metadata *oidcMetadata, rawIDToken, nonce string) (*domain.OIDCIdentity, error) {
+	var validMethods []string
+	for _, methods := range oidcSigningMethods {
+		validMethods = append(validMethods, methods...)
+	}
+
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.verificationKey(ctx, metadata, kid, token.Method.Alg())
+	},
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("invalid ID token: nonce does not match")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("invalid ID token: issued to %q", claims.AuthorizedParty)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid ID token: missing subject")
+	}
+
+	return &domain.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+		Name:          claims.Name,
+		AuthMethods:   claims.AuthMethods,
+	}, nil
+}
+
+// verificationKey returns the provider key that verifies a token signed with alg.
+// AI-hint: An unknown key ID refetches the JWKS at most once per oidcKeyRefreshInterval.
+// Tokens without a key ID are accepted only while the provider publishes a single key.
+func (p *OIDCProvider) verificationKey(ctx context.Context, metadata *oidcMetadata, kid, alg string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	jwk, found := p.findKey(kid)
+	if !found && time.Since(p.keysFetchedAt) >= oidcKeyRefreshInterval {
+		keys, err := p.fetchKeys(ctx, metadata.JWKSURI)
+		if err != nil {
+			return nil, err
+		}
+		p.keys, p.keysFetchedAt = keys, time.Now()
+		jwk, found = p.findKey(kid)
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if jwk.Alg != "" && jwk.Alg != alg {
+		return nil, fmt.Errorf("key %q is not for %s", kid, alg)
+	}
+	allowed := false
+	for _, method := range oidcSigningMethods[jwk.Kty] {
+		allowed = allowed || method == alg
+	}
+	if !allowed {
+		return nil, fmt.Errorf("key %q of type %s cannot verify %s", kid, jwk.Kty, alg)
+	}
+
+	return jwk.publicKey()
+}
+
+// findKey looks up a cached key; callers must hold p.mu.
+func (p *OIDCProvider) findKey(kid string) (JWK, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, jwk := range p.keys {
+			return jwk, true
+		}
+	}
+	jwk, found := p.keys[kid]
+	return jwk, found
+}
+
+// fetchKeys downloads the provider's signing keys, skipping encryption keys.
+func (p *OIDCProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]JWK, error) {
+	var set JWKSet
+	if err := p.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	keys := make(map[string]JWK, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use == "" || jwk.Use == "sig" {
+			keys[jwk.Kid] = jwk
+		}
+	}
+	return keys, nil
+}
+
+// discover loads and caches the provider's discovery document.
+// AI-hint: The advertised issuer must equal the configured one (OpenID Connect
+// Discovery 1.0, section 4.3), otherwise a compromised document could name another issuer.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var metadata oidcMetadata
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("OIDC discovery returned issuer %q, expected %q", metadata.Issuer, p.config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document is missing endpoints")
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// getJSON fetches a URL and decodes its JSON body.
+func (p *OIDCProvider) getJSON(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponseBytes)).Decode(target)
+}
+
+// publicKey converts an RSA, EC, or Ed25519 JWK into a public key.
+func (j JWK) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key has %d bits; at least %d are required", key.N.BitLen(), minRSAKeyBits)
+		}
+		return key, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(j.X)
+		y, errY := base64.RawURLEncoding.DecodeString(j.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC point")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", j.Crv)
+		}
+		return key, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || j.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// bundledCommonPasswords is the built-in list of passwords that are too common to allow.
+//
+//go:embed common_passwords.txt
+var bundledCommonPasswords string
+
+// PasswordBlocklist implements the user.PasswordBlocklist interface from in-memory word lists.
+// AI-hint: Always contains the bundled list; operators add their own (e.g. a larger
+// breach corpus) with PASSWORD_BLOCKLIST_FILE. Lookups ignore case and surrounding spaces.
+type PasswordBlocklist struct {
+	passwords map[string]struct{}
+}
+
+// NewPasswordBlocklist loads the bundled list plus the optional operator-supplied file.
+// AI-hint: The file has one password per line; blank lines and lines starting with "#"
+// are ignored. An empty path uses only the bundled list.
+func NewPasswordBlocklist(path string) (*PasswordBlocklist, error) {
+	blocklist := &PasswordBlocklist{passwords: make(map[string]struct{})}
+	if err := blocklist.load(strings.NewReader(bundledCommonPasswords)); err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open password blocklist: %w", err)
+		}
+		defer file.Close()
+
+		if err := blocklist.load(file); err != nil {
+			return nil, fmt.Errorf("failed to read password blocklist %s: %w", path, err)
+		}
+	}
+
+	return blocklist, nil
+}
+
+// Contains reports whether the password is on the blocklist.
+func (b *PasswordBlocklist) Contains(password string) bool {
+	_, found := b.passwords[normalizeBlocklistEntry(password)]
+	return found
+}
+
+// Len returns how many distinct passwords are on the blocklist.
+func (b *PasswordBlocklist) Len() int {
+	return len(b.passwords)
+}
+
+// load adds every password listed in r.
+func (b *PasswordBlocklist) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := normalizeBlocklistEntry(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		b.passwords[line] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// normalizeBlocklistEntry lowercases and trims a password for lookup.
+func normalizeBlocklistEntry(password string) string {
+	return strings.ToLower(strings.TrimSpace(password))
+}
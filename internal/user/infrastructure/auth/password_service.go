@@ -1,17 +1,23 @@
 package auth
 
 import (
+	"feedback_hub_2/internal/user/domain"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
-// PasswordService handles password hashing and verification.
+// PasswordService handles password hashing, verification, and the password policy.
 // AI-hint: Secure password service using bcrypt for hashing with salt and proper cost factor.
-type PasswordService struct{}
+type PasswordService struct {
+	policy domain.PasswordPolicy
+}
 
 // NewPasswordService creates a new password service instance.
-// AI-hint: Factory method for password service with no dependencies.
-func NewPasswordService() *PasswordService {
-	return &PasswordService{}
+// AI-hint: Factory method for password service; policy is checked by ValidatePassword.
+func NewPasswordService(policy domain.PasswordPolicy) *PasswordService {
+	return &PasswordService{
+		policy: policy,
+	}
 }
 
 // HashPassword creates a bcrypt hash of the given password.
@@ -30,9 +36,9 @@ func (s *PasswordService) VerifyPassword(hashedPassword, password string) error
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-// IsValidPassword checks if a password meets basic requirements.
-// AI-hint: Basic password validation for minimum length - extend with complexity rules as needed.
-func (s *PasswordService) IsValidPassword(password string) bool {
-	// Basic validation - extend as needed
-	return len(password) >= 8
+// ValidatePassword checks a new password against the password policy.
+// AI-hint: email and name are the account's, so passwords containing them are rejected.
+// Returns a *domain.PasswordPolicyError listing every violated rule.
+func (s *PasswordService) ValidatePassword(password, email, name string) error {
+	return s.policy.Validate(password, email, name)
 }
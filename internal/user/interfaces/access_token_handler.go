@@ -0,0 +1,194 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"errors"
+	"feedback_hub_2/internal/shared/web"
+	userapp "feedback_hub_2/internal/user/application"
+	"feedback_hub_2/internal/user/domain"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessTokenHandler handles HTTP requests for personal access token management.
+// AI-hint: HTTP transport layer for /users/me/tokens. Tokens always belong to the
+// authenticated user; there is no endpoint to manage other users' tokens.
+type AccessTokenHandler struct {
+	tokenService *userapp.AccessTokenService
+}
+
+// NewAccessTokenHandler creates a new AccessTokenHandler instance.
+// AI-hint: Factory method for access token handler with dependency injection of the token service.
+func NewAccessTokenHandler(tokenService *userapp.AccessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+// CreateAccessTokenRequest represents the request body for creating a personal access token.
+// AI-hint: Scopes are permission names such as "api:read" or "idea:change_status";
+// omit ExpiresAt for a token that never expires.
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// AccessTokenResponse represents a personal access token in API responses.
+// AI-hint: Token is only set in the creation response; it cannot be retrieved later.
+type AccessTokenResponse struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	DisplayPrefix string     `json:"display_prefix"`
+	Scopes        []string   `json:"scopes"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Token         string     `json:"token,omitempty"`
+}
+
+// CreateAccessToken handles POST /users/me/tokens requests.
+// AI-hint: Returns the plaintext token exactly once. Requires a session login.
+//
+// @Summary Create a personal access token
+// @Description Create a long-lived scoped token for scripts and integrations. The token is only shown in this response.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Param token body CreateAccessTokenRequest true "Token name, scopes, and optional expiry"
+// @Success 201 {object} AccessTokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /users/me/tokens [post]
+func (h *AccessTokenHandler) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req CreateAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	token, plaintext, err := h.tokenService.CreateToken(r.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		switch {
+		case err == domain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Access tokens can only be managed from a login session")
+		case errors.Is(err, domain.ErrInvalidTokenScope),
+			err == domain.ErrInvalidAccessTokenName,
+			err == domain.ErrInvalidTokenExpiry:
+			web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create access token")
+		}
+		return
+	}
+
+	response := toAccessTokenResponse(token)
+	response.Token = plaintext
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListAccessTokens handles GET /users/me/tokens requests.
+// AI-hint: Lists the caller's tokens with their display prefix and last use, never the secret.
+//
+// @Summary List personal access tokens
+// @Description List the authenticated user's personal access tokens
+// @Tags tokens
+// @Produce json
+// @Success 200 {array} AccessTokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /users/me/tokens [get]
+func (h *AccessTokenHandler) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tokens, err := h.tokenService.ListTokens(r.Context(), userID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to list access tokens")
+		return
+	}
+
+	response := make([]AccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, toAccessTokenResponse(token))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeAccessToken handles DELETE /users/me/tokens/{id} requests.
+// AI-hint: Deleting a token stops it from authenticating immediately. Requires a session login.
+//
+// @Summary Revoke a personal access token
+// @Description Delete one of the authenticated user's personal access tokens
+// @Tags tokens
+// @Param id path string true "Token ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /users/me/tokens/{id} [delete]
+func (h *AccessTokenHandler) RevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tokenID := web.ExtractIDFromPath(r.URL.Path, "/users/me/tokens/")
+	if _, err := uuid.Parse(tokenID); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(r.Context(), userID, tokenID); err != nil {
+		switch err {
+		case domain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Access tokens can only be managed from a login session")
+		case domain.ErrAccessTokenNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Access token not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to revoke access token")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toAccessTokenResponse converts a token into its API representation without the secret.
+func toAccessTokenResponse(token *domain.PersonalAccessToken) AccessTokenResponse {
+	return AccessTokenResponse{
+		ID:            token.ID,
+		Name:          token.Name,
+		DisplayPrefix: token.DisplayPrefix,
+		Scopes:        token.Scopes,
+		ExpiresAt:     token.ExpiresAt,
+		LastUsedAt:    token.LastUsedAt,
+		CreatedAt:     token.CreatedAt,
+	}
+}
@@ -2,43 +2,89 @@ package interfaces
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	roleapp "feedback_hub_2/internal/role/application"
 	"feedback_hub_2/internal/shared/web"
 	userapp "feedback_hub_2/internal/user/application"
+	userdomain "feedback_hub_2/internal/user/domain"
 	"feedback_hub_2/internal/user/infrastructure/auth"
 
 	"github.com/google/uuid"
 )
 
+// Cookie names used for authentication.
+// AI-hint: The refresh cookie is scoped to /auth so it is only sent to refresh and logout.
+const (
+	accessTokenCookie  = "auth_token"
+	refreshTokenCookie = "refresh_token"
+	refreshCookiePath  = "/auth"
+)
+
 // AuthHandler handles HTTP requests for authentication operations.
 // AI-hint: HTTP transport layer for authentication with JWT and password-based login.
-// Provides secure login/logout with HTTP-only cookie token storage.
+// Provides secure login/logout with HTTP-only cookie token storage. Every login starts
+// a server-side session: a short-lived access token plus a rotating refresh token.
 type AuthHandler struct {
-	userService     *userapp.UserService
-	roleService     *roleapp.RoleService
-	jwtService      *auth.JWTService
-	passwordService *auth.PasswordService
+	userService          *userapp.UserService
+	roleService          *roleapp.RoleService
+	sessionService       *userapp.SessionService
+	passwordResetService *userapp.PasswordResetService
+	verificationService  *userapp.EmailVerificationService
+	mfaService           *userapp.MFAService
+	throttleService      *userapp.LoginThrottleService
+	jwtService           *auth.JWTService
+	passwordService      *auth.PasswordService
+	trustProxyHeaders    bool
 }
 
 // NewAuthHandler creates a new AuthHandler instance.
 // AI-hint: Factory method for auth handler with dependency injection of required services.
-func NewAuthHandler(userService *userapp.UserService, roleService *roleapp.RoleService, jwtService *auth.JWTService, passwordService *auth.PasswordService) *AuthHandler {
+// trustProxyHeaders makes login throttling read the client IP from X-Forwarded-For.
+func NewAuthHandler(userService *userapp.UserService, roleService *roleapp.RoleService, sessionService *userapp.SessionService, passwordResetService *userapp.PasswordResetService, verificationService *userapp.EmailVerificationService, mfaService *userapp.MFAService, throttleService *userapp.LoginThrottleService, jwtService *auth.JWTService, passwordService *auth.PasswordService, trustProxyHeaders bool) *AuthHandler {
 	return &AuthHandler{
-		userService:     userService,
-		roleService:     roleService,
-		jwtService:      jwtService,
-		passwordService: passwordService,
+		userService:          userService,
+		roleService:          roleService,
+		sessionService:       sessionService,
+		passwordResetService: passwordResetService,
+		verificationService:  verificationService,
+		mfaService:           mfaService,
+		throttleService:      throttleService,
+		jwtService:           jwtService,
+		passwordService:      passwordService,
+		trustProxyHeaders:    trustProxyHeaders,
 	}
 }
 
+// Token delivery modes for login and refresh.
+// AI-hint: Browsers use cookie mode; CLI scripts and backend jobs use body mode and send
+// the access token as "Authorization: Bearer <token>".
+const (
+	tokenModeCookie = "cookie"
+	tokenModeBody   = "body"
+)
+
 // LoginRequest represents the request body for user login.
-// AI-hint: DTO for login API with email and password fields.
+// AI-hint: DTO for login API with email and password fields. TokenMode is "cookie"
+// (default) or "body" to receive the tokens in the response instead of cookies.
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	TokenMode string `json:"token_mode,omitempty" enums:"cookie,body"`
+}
+
+// RefreshRequest represents the optional request body for refreshing a session.
+// AI-hint: Machine clients send the refresh token here; browsers send an empty body
+// and rely on the refresh_token cookie.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // RegisterRequest represents the request body for user registration.
@@ -49,28 +95,105 @@ type RegisterRequest struct {
 	Password string `json:"password"`
 }
 
+// ForgotPasswordRequest represents the request body for requesting a password reset link.
+// AI-hint: DTO for the forgot-password API; the response never reveals whether the email exists.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents the request body for setting a new password.
+// AI-hint: Token is the value of the "token" query parameter from the mailed link.
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// VerifyEmailRequest represents the request body for verifying an email address.
+// AI-hint: Token is the value of the "token" query parameter from the mailed link.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// LoginMFARequest represents the request body for the second login step.
+// AI-hint: MFAToken comes from the MFAChallengeResponse of /auth/login; Code is a
+// current authenticator code or an unused recovery code.
+type LoginMFARequest struct {
+	MFAToken  string `json:"mfa_token"`
+	Code      string `json:"code"`
+	TokenMode string `json:"token_mode,omitempty" enums:"cookie,body"`
+}
+
 // AuthResponse represents the response body for authentication operations.
 // AI-hint: DTO for auth responses with user info (token stored in HTTP-only cookie).
+// MFASetupRequired is set when the user's role requires two-factor authentication but
+// the user has not enrolled yet; their permissions are withheld until they do.
 type AuthResponse struct {
-	UserID   string `json:"user_id"`
-	Email    string `json:"email"`
-	Name     string `json:"name"`
-	RoleName string `json:"role_name"`
-	Message  string `json:"message"`
+	UserID           string `json:"user_id"`
+	Email            string `json:"email"`
+	Name             string `json:"name"`
+	RoleName         string `json:"role_name"`
+	EmailVerified    bool   `json:"email_verified"`
+	MFASetupRequired bool   `json:"mfa_setup_required,omitempty"`
+	Message          string `json:"message"`
+}
+
+// MFAChallengeResponse is returned by login instead of a session when two-factor
+// authentication is enabled.
+// AI-hint: ExpiresIn is the challenge lifetime in seconds; post the token and a code
+// to /auth/login/mfa.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	Message     string `json:"message"`
+}
+
+// PasswordPolicyErrorResponse is returned when a new password breaks the password policy.
+// AI-hint: Violations lists every broken rule ("min_length", "max_length",
+// "character_classes", "personal_info", "common_password") so clients can show
+// them next to the password field.
+type PasswordPolicyErrorResponse struct {
+	Error      string                         `json:"error"`
+	Message    string                         `json:"message"`
+	Violations []userdomain.PasswordViolation `json:"violations"`
+}
+
+// TokenResponse represents the body-mode response for login and refresh.
+// AI-hint: DTO for machine clients; no cookies are set when tokens are returned here.
+// ExpiresIn is the access token lifetime in seconds.
+type TokenResponse struct {
+	AuthResponse
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// sessionTokens are the credentials issued for a session.
+type sessionTokens struct {
+	accessToken      string
+	refreshToken     string
+	refreshExpiresAt time.Time
 }
 
 // Login handles POST /auth/login requests.
 // AI-hint: User authentication endpoint with password verification and JWT token generation.
+// Tokens go into HTTP-only cookies unless token_mode is "body". Users with two-factor
+// authentication get an MFAChallengeResponse instead and finish at /auth/login/mfa.
+// Repeated failures per account and per client IP are answered with 429 and Retry-After.
 //
 // @Summary User login
-// @Description Authenticate user with email and password, returns JWT in HTTP-only cookie
+// @Description Authenticate user with email and password, returns JWT in HTTP-only cookie, or in the response body (TokenResponse) when token_mode is "body". Users with two-factor authentication receive an MFA challenge instead.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body LoginRequest true "Login credentials"
 // @Success 200 {object} AuthResponse
+// @Success 200 {object} TokenResponse
+// @Success 200 {object} MFAChallengeResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
@@ -85,25 +208,155 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		web.WriteErrorResponse(w, http.StatusBadRequest, "Email and password are required")
 		return
 	}
+	if req.TokenMode == "" {
+		req.TokenMode = tokenModeCookie
+	}
+	if req.TokenMode != tokenModeCookie && req.TokenMode != tokenModeBody {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "token_mode must be 'cookie' or 'body'")
+		return
+	}
+
+	// Refuse attempts while the account or client IP is backing off or locked out
+	clientIP := web.ClientIP(r, h.trustProxyHeaders)
+	if wait, err := h.throttleService.CheckLogin(r.Context(), req.Email, clientIP); err != nil {
+		writeLoginThrottled(w, wait, err)
+		return
+	}
 
 	// Get user by email
 	user, err := h.userService.GetUserByEmail(r.Context(), req.Email)
 	if err != nil {
+		h.throttleService.RecordFailure(r.Context(), req.Email, clientIP)
 		web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
 
 	// Verify password
 	if user.PasswordHash == "" {
+		h.throttleService.RecordFailure(r.Context(), req.Email, clientIP)
 		web.WriteErrorResponse(w, http.StatusUnauthorized, "Password login not available for this account")
 		return
 	}
 
 	if err := h.passwordService.VerifyPassword(user.PasswordHash, req.Password); err != nil {
+		h.throttleService.RecordFailure(r.Context(), req.Email, clientIP)
 		web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
 
+	// Users with two-factor authentication must pass the second step before a session exists;
+	// their failed logins are only cleared once it succeeds
+	mfaEnabled, err := h.mfaService.IsEnabled(r.Context(), user.ID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to check two-factor authentication")
+		return
+	}
+	if mfaEnabled {
+		mfaToken, expiresAt, err := h.mfaService.StartChallenge(r.Context(), user.ID)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to start two-factor authentication")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresIn:   int(time.Until(expiresAt).Seconds()),
+			Message:     "Two-factor authentication code required",
+		})
+		return
+	}
+
+	h.throttleService.RecordSuccess(r.Context(), req.Email)
+	h.completeLogin(w, r, user, req.TokenMode, false)
+}
+
+// LoginMFA handles POST /auth/login/mfa requests.
+// AI-hint: Second login step; exchanges an MFA challenge token plus a TOTP or recovery
+// code for a session marked as two-factor verified. Wrong codes count as failed logins
+// for the account and client IP, so new challenges do not allow unlimited guesses.
+//
+// @Summary Complete two-factor login
+// @Description Finish a login that returned mfa_required using an authenticator code or a recovery code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param challenge body LoginMFARequest true "MFA challenge token and code"
+// @Success 200 {object} AuthResponse
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/login/mfa [post]
+func (h *AuthHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req LoginMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.MFAToken == "" || req.Code == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "mfa_token and code are required")
+		return
+	}
+	if req.TokenMode == "" {
+		req.TokenMode = tokenModeCookie
+	}
+	if req.TokenMode != tokenModeCookie && req.TokenMode != tokenModeBody {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "token_mode must be 'cookie' or 'body'")
+		return
+	}
+
+	// Apply the account's throttle as well when the challenge identifies it
+	clientIP := web.ClientIP(r, h.trustProxyHeaders)
+	email := ""
+	challengeUser, err := h.mfaService.ChallengeUser(r.Context(), req.MFAToken)
+	if err == nil {
+		email = challengeUser.Email
+	} else if err != userdomain.ErrInvalidMFAChallenge {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to verify two-factor authentication code")
+		return
+	}
+	if wait, err := h.throttleService.CheckLogin(r.Context(), email, clientIP); err != nil {
+		writeLoginThrottled(w, wait, err)
+		return
+	}
+
+	user, err := h.mfaService.CompleteChallenge(r.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		switch err {
+		case userdomain.ErrInvalidMFACode, userdomain.ErrInvalidMFAChallenge:
+			h.throttleService.RecordFailure(r.Context(), email, clientIP)
+			web.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to verify two-factor authentication code")
+		}
+		return
+	}
+	h.throttleService.RecordSuccess(r.Context(), user.Email)
+
+	h.completeLogin(w, r, user, req.TokenMode, true)
+}
+
+// writeLoginThrottled answers a login refused by the throttle, with Retry-After on 429.
+func writeLoginThrottled(w http.ResponseWriter, wait time.Duration, err error) {
+	switch err {
+	case userdomain.ErrAccountLocked, userdomain.ErrTooManyLoginAttempts:
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		web.WriteErrorResponse(w, http.StatusTooManyRequests, err.Error())
+	default:
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to check login attempts")
+	}
+}
+
+// completeLogin starts a session for an authenticated user and writes the login response.
+// AI-hint: Shared by Login and LoginMFA; mfaVerified marks the session as having passed
+// two-factor authentication.
+func (h *AuthHandler) completeLogin(w http.ResponseWriter, r *http.Request, user *userdomain.User, tokenMode string, mfaVerified bool) {
 	// Get user's role for JWT claims
 	role, err := h.roleService.GetRole(r.Context(), user.RoleID)
 	if err != nil {
@@ -111,37 +364,30 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user.ID, user.Email, role.Name)
+	// Start a session and issue its tokens
+	tokens, err := h.startSession(r, user, role.Name, mfaVerified)
 	if err != nil {
 		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
-	// Set HTTP-only cookie with environment-based security
-	isProduction := os.Getenv("ENVIRONMENT") == "production"
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    token,
-		HttpOnly: true,
-		Secure:   isProduction, // HTTPS only in production
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   86400, // 24 hours
-		Path:     "/",
-	})
-
-	// Return user info (not the token)
+	// Return user info, with the tokens only in body mode
 	response := AuthResponse{
-		UserID:   user.ID,
-		Email:    user.Email,
-		Name:     user.Name,
-		RoleName: role.Name,
-		Message:  "Login successful",
+		UserID:        user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		RoleName:      role.Name,
+		EmailVerified: user.IsEmailVerified(),
+		Message:       "Login successful",
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	if !mfaVerified {
+		required, err := h.mfaService.RoleRequiresMFA(r.Context(), user)
+		if err != nil {
+			log.Printf("Warning: failed to check two-factor policy for user %s: %v", user.ID, err)
+		}
+		response.MFASetupRequired = required
+	}
+	h.writeSessionResponse(w, http.StatusOK, tokenMode, response, tokens)
 }
 
 // Register handles POST /auth/register requests.
@@ -156,6 +402,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 // @Success 201 {object} AuthResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} PasswordPolicyErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -172,8 +419,8 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate password strength
-	if !h.passwordService.IsValidPassword(req.Password) {
-		web.WriteErrorResponse(w, http.StatusBadRequest, "Password must be at least 8 characters long")
+	if err := h.passwordService.ValidatePassword(req.Password, req.Email, req.Name); err != nil {
+		writePasswordPolicyError(w, err)
 		return
 	}
 
@@ -203,32 +450,27 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token for immediate login
-	token, err := h.jwtService.GenerateToken(user.ID, user.Email, contributorRole.Name)
+	// Send the verification link; the user can ask for a new one if this fails
+	if err := h.verificationService.SendVerification(r.Context(), user.ID); err != nil {
+		log.Printf("Warning: failed to send verification email to new user %s: %v", user.ID, err)
+	}
+
+	// Start a session for immediate login
+	tokens, err := h.startSession(r, user, contributorRole.Name, false)
 	if err != nil {
 		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
-
-	// Set HTTP-only cookie with environment-based security
-	isProduction := os.Getenv("ENVIRONMENT") == "production"
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    token,
-		HttpOnly: true,
-		Secure:   isProduction, // HTTPS only in production
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   86400, // 24 hours
-		Path:     "/",
-	})
+	h.setAuthCookies(w, tokens)
 
 	// Return user info
 	response := AuthResponse{
-		UserID:   user.ID,
-		Email:    user.Email,
-		Name:     user.Name,
-		RoleName: contributorRole.Name,
-		Message:  "Registration successful",
+		UserID:        user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		RoleName:      contributorRole.Name,
+		EmailVerified: user.IsEmailVerified(),
+		Message:       "Registration successful; check your inbox to verify your email address",
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -236,39 +478,303 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// Refresh handles POST /auth/refresh requests.
+// AI-hint: Exchanges a refresh token for a new access token and a rotated refresh token.
+// Reusing an already rotated refresh token revokes the session. A refresh_token in the
+// body takes precedence over the cookie and selects body mode for the response.
+//
+// @Summary Refresh session
+// @Description Issue a new access token and rotate the refresh token sent in the body or stored in the refresh_token cookie
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest false "Refresh token for machine clients"
+// @Success 200 {object} AuthResponse
+// @Success 200 {object} TokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	mode := tokenModeBody
+	presentedToken := req.RefreshToken
+	if presentedToken == "" {
+		mode = tokenModeCookie
+		if cookie, err := r.Cookie(refreshTokenCookie); err == nil {
+			presentedToken = cookie.Value
+		}
+	}
+	if presentedToken == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Refresh token required")
+		return
+	}
+
+	session, user, refreshToken, err := h.sessionService.RefreshSession(r.Context(), presentedToken)
+	if err != nil {
+		switch err {
+		case userdomain.ErrInvalidRefreshToken, userdomain.ErrSessionRevoked, userdomain.ErrRefreshTokenReused, userdomain.ErrUserNotFound:
+			if mode == tokenModeCookie {
+				clearAuthCookies(w)
+			}
+			web.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to refresh session")
+		}
+		return
+	}
+
+	// Get user's role for JWT claims
+	role, err := h.roleService.GetRole(r.Context(), user.RoleID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get user role")
+		return
+	}
+
+	token, err := h.jwtService.GenerateToken(user.ID, user.Email, role.Name, session.ID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	response := AuthResponse{
+		UserID:        user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		RoleName:      role.Name,
+		EmailVerified: user.IsEmailVerified(),
+		Message:       "Session refreshed",
+	}
+	h.writeSessionResponse(w, http.StatusOK, mode, response, &sessionTokens{
+		accessToken:      token,
+		refreshToken:     refreshToken,
+		refreshExpiresAt: session.ExpiresAt,
+	})
+}
+
 // Logout handles POST /auth/logout requests.
-// AI-hint: User logout endpoint that clears the authentication cookie.
+// AI-hint: User logout endpoint that revokes the server-side session and clears the
+// authentication cookies. The session is found through the access token (bearer header
+// or cookie), or through the refresh token cookie when the access token has expired.
 //
 // @Summary User logout
-// @Description Logout user by clearing authentication cookie
+// @Description Logout user by revoking the session and clearing authentication cookies
 // @Tags auth
 // @Success 200 {object} map[string]string
 // @Router /auth/logout [post]
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Clear the auth cookie with environment-based security
-	isProduction := os.Getenv("ENVIRONMENT") == "production"
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    "",
-		HttpOnly: true,
-		Secure:   isProduction, // HTTPS only in production
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   -1, // Delete cookie
-		Path:     "/",
-	})
+	revoked := false
+	if token, err := accessTokenFromRequest(r); err == nil {
+		if claims, err := h.jwtService.ValidateToken(token); err == nil && claims.SessionID != "" {
+			if err := h.sessionService.RevokeSession(r.Context(), claims.SessionID); err == nil {
+				revoked = true
+			} else if err != userdomain.ErrSessionNotFound {
+				log.Printf("Warning: failed to revoke session on logout: %v", err)
+			}
+		}
+	}
+	if !revoked {
+		if cookie, err := r.Cookie(refreshTokenCookie); err == nil {
+			if err := h.sessionService.RevokeByRefreshToken(r.Context(), cookie.Value); err != nil && err != userdomain.ErrInvalidRefreshToken {
+				log.Printf("Warning: failed to revoke session on logout: %v", err)
+			}
+		}
+	}
+
+	clearAuthCookies(w)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Logout successful"})
 }
 
+// ForgotPassword handles POST /auth/password/forgot requests.
+// AI-hint: Always answers 202 with the same message, whether or not the email belongs
+// to an account, so the endpoint cannot be used to discover accounts.
+//
+// @Summary Request a password reset
+// @Description Email a single-use password reset link to the account with this email address, if there is one
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Email == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(r.Context(), req.Email); err != nil {
+		// Failures are only logged; a different response would reveal that the account exists
+		log.Printf("Error: failed to send password reset link: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"message": "If an account exists for this email, a password reset link has been sent"})
+}
+
+// ResetPassword handles POST /auth/password/reset requests.
+// AI-hint: Sets a new password with a reset token and signs the user out everywhere.
+// The user has to log in again with the new password.
+//
+// @Summary Reset password
+// @Description Set a new password using the token from a password reset link. All of the user's sessions are revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} PasswordPolicyErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Token == "" || req.NewPassword == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Token and new password are required")
+		return
+	}
+
+	// Validate password strength against the account the token belongs to
+	user, err := h.passwordResetService.UserForToken(r.Context(), req.Token)
+	if err != nil {
+		if err == userdomain.ErrInvalidPasswordResetToken {
+			web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+	if err := h.passwordService.ValidatePassword(req.NewPassword, user.Email, user.Name); err != nil {
+		writePasswordPolicyError(w, err)
+		return
+	}
+
+	hashedPassword, err := h.passwordService.HashPassword(req.NewPassword)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	if _, err := h.passwordResetService.ResetPassword(r.Context(), req.Token, hashedPassword); err != nil {
+		if err == userdomain.ErrInvalidPasswordResetToken {
+			web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	// Any cookies in this browser belong to a session that was just revoked
+	clearAuthCookies(w)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Password has been reset; please log in again"})
+}
+
+// VerifyEmail handles POST /auth/verify-email requests.
+// AI-hint: Public endpoint (the link is opened from the inbox, possibly on another
+// device), so the token alone identifies the user.
+//
+// @Summary Verify email address
+// @Description Confirm the user's email address with the token from a verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyEmailRequest true "Verification token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Token == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	if _, err := h.verificationService.VerifyEmail(r.Context(), req.Token); err != nil {
+		if err == userdomain.ErrInvalidEmailVerificationToken {
+			web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to verify email address")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Email address verified"})
+}
+
+// ResendVerificationEmail handles POST /auth/verify-email/resend requests.
+// AI-hint: Sends a fresh link to the authenticated user; earlier links stop working.
+//
+// @Summary Resend verification email
+// @Description Send a new email verification link to the authenticated user
+// @Tags auth
+// @Produce json
+// @Success 202 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.verificationService.SendVerification(r.Context(), userID); err != nil {
+		switch err {
+		case userdomain.ErrEmailAlreadyVerified:
+			web.WriteErrorResponse(w, http.StatusConflict, err.Error())
+		case userdomain.ErrUserNotFound:
+			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to send verification email")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Verification email sent"})
+}
+
 // Me handles GET /auth/me requests.
 // AI-hint: Current user info endpoint that returns authenticated user details.
 //
 // @Summary Get current user
 // @Description Get current authenticated user information
 // @Tags auth
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Produce json
 // @Success 200 {object} AuthResponse
 // @Failure 401 {object} ErrorResponse
@@ -297,14 +803,137 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 
 	// Return user info
 	response := AuthResponse{
-		UserID:   user.ID,
-		Email:    user.Email,
-		Name:     user.Name,
-		RoleName: role.Name,
-		Message:  "Authenticated",
+		UserID:        user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		RoleName:      role.Name,
+		EmailVerified: user.IsEmailVerified(),
+		Message:       "Authenticated",
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// JWKS handles GET /.well-known/jwks.json requests.
+// AI-hint: Publishes the public keys that verify access tokens so other services can check
+// them without calling this API. Cacheable for a few minutes; keep retired keys in the set
+// for longer than that plus the access token TTL.
+//
+// @Summary Get token verification keys
+// @Description Get the JSON Web Key Set used to verify access tokens
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.jwtService.JWKS())
+}
+
+// startSession creates a session for the user and issues its access and refresh tokens.
+// AI-hint: Shared by login and Register so both issue the same token pair.
+func (h *AuthHandler) startSession(r *http.Request, user *userdomain.User, roleName string, mfaVerified bool) (*sessionTokens, error) {
+	session, refreshToken, err := h.sessionService.StartSession(r.Context(), user.ID, mfaVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := h.jwtService.GenerateToken(user.ID, user.Email, roleName, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionTokens{
+		accessToken:      token,
+		refreshToken:     refreshToken,
+		refreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// writeSessionResponse delivers a session's tokens together with the user info.
+// AI-hint: Cookie mode sets HTTP-only cookies and keeps the tokens out of the body;
+// body mode returns them in a TokenResponse and sets no cookies.
+func (h *AuthHandler) writeSessionResponse(w http.ResponseWriter, status int, mode string, info AuthResponse, tokens *sessionTokens) {
+	var response interface{} = info
+	if mode == tokenModeBody {
+		response = TokenResponse{
+			AuthResponse: info,
+			AccessToken:  tokens.accessToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    int(h.jwtService.AccessTokenTTL().Seconds()),
+			RefreshToken: tokens.refreshToken,
+		}
+		// Tokens in the body must not end up in shared caches
+		w.Header().Set("Cache-Control", "no-store")
+	} else {
+		h.setAuthCookies(w, tokens)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// setAuthCookies sets HTTP-only cookies for the access token and the refresh token.
+// AI-hint: Cookie lifetimes follow the token lifetimes; Secure is only set in production.
+func (h *AuthHandler) setAuthCookies(w http.ResponseWriter, tokens *sessionTokens) {
+	isProduction := os.Getenv("ENVIRONMENT") == "production"
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    tokens.accessToken,
+		HttpOnly: true,
+		Secure:   isProduction, // HTTPS only in production
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int(h.jwtService.AccessTokenTTL().Seconds()),
+		Path:     "/",
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    tokens.refreshToken,
+		HttpOnly: true,
+		Secure:   isProduction,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int(time.Until(tokens.refreshExpiresAt).Seconds()),
+		Path:     refreshCookiePath,
+	})
+}
+
+// writePasswordPolicyError writes a 422 response listing the violated password rules.
+func writePasswordPolicyError(w http.ResponseWriter, err error) {
+	var policyErr *userdomain.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to validate password")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(PasswordPolicyErrorResponse{
+		Error:      http.StatusText(http.StatusUnprocessableEntity),
+		Message:    "Password does not meet the password policy",
+		Violations: policyErr.Violations,
+	})
+}
+
+// clearAuthCookies deletes the access and refresh token cookies.
+func clearAuthCookies(w http.ResponseWriter) {
+	isProduction := os.Getenv("ENVIRONMENT") == "production"
+	for _, cookie := range []struct{ name, path string }{
+		{accessTokenCookie, "/"},
+		{refreshTokenCookie, refreshCookiePath},
+	} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookie.name,
+			Value:    "",
+			HttpOnly: true,
+			Secure:   isProduction, // HTTPS only in production
+			SameSite: http.SameSiteStrictMode,
+			MaxAge:   -1, // Delete cookie
+			Path:     cookie.path,
+		})
+	}
+}
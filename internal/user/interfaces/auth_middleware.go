@@ -1,56 +1,139 @@
 package interfaces
 
 import (
+	"context"
+	"errors"
+	sharedauth "feedback_hub_2/internal/shared/auth"
+	"feedback_hub_2/internal/shared/queries"
 	"feedback_hub_2/internal/shared/web"
 	userapp "feedback_hub_2/internal/user/application"
+	userdomain "feedback_hub_2/internal/user/domain"
 	"feedback_hub_2/internal/user/infrastructure/auth"
 	"net/http"
+	"strings"
 )
 
 // AuthMiddleware provides authentication functionality for HTTP requests.
-// AI-hint: JWT-based authentication middleware that validates tokens from HTTP-only cookies.
-// Provides secure authentication with proper token validation and user context.
+// AI-hint: JWT-based authentication middleware for browsers (HTTP-only cookie) and machine
+// clients (Authorization: Bearer header). JWTs are only accepted while their server-side
+// session is active; personal access tokens ("fhp_" prefix) are limited to their scopes.
+// Sessions that completed two-factor authentication mark the context as MFA verified.
+// The caller's role and permissions are resolved once and stored as an auth.UserContext.
 type AuthMiddleware struct {
-	userService *userapp.UserService
-	jwtService  *auth.JWTService
+	userService        *userapp.UserService
+	sessionService     *userapp.SessionService
+	accessTokenService *userapp.AccessTokenService
+	jwtService         *auth.JWTService
+	roleQueries        queries.RoleQueries
+	authService        *sharedauth.AuthorizationService
 }
 
 // NewAuthMiddleware creates a new AuthMiddleware instance.
 // AI-hint: Factory method for auth middleware with dependency injection of services.
-func NewAuthMiddleware(userService *userapp.UserService, jwtService *auth.JWTService) *AuthMiddleware {
+func NewAuthMiddleware(userService *userapp.UserService, sessionService *userapp.SessionService, accessTokenService *userapp.AccessTokenService, jwtService *auth.JWTService, roleQueries queries.RoleQueries, authService *sharedauth.AuthorizationService) *AuthMiddleware {
 	return &AuthMiddleware{
-		userService: userService,
-		jwtService:  jwtService,
+		userService:        userService,
+		sessionService:     sessionService,
+		accessTokenService: accessTokenService,
+		jwtService:         jwtService,
+		roleQueries:        roleQueries,
+		authService:        authService,
 	}
 }
 
 // RequireAuth is a middleware that requires authentication for the wrapped handler.
-// AI-hint: JWT-based authentication middleware that validates tokens from HTTP-only cookies.
+// AI-hint: JWT-based authentication middleware that validates bearer tokens or tokens from
+// HTTP-only cookies (see accessTokenFromRequest for the precedence).
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get JWT token from HTTP-only cookie
-		cookie, err := r.Cookie("auth_token")
+		// Get JWT token from the Authorization header or the HTTP-only cookie
+		token, err := accessTokenFromRequest(r)
 		if err != nil {
-			web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+			if err == errMalformedAuthorization {
+				web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid authorization header")
+			} else {
+				web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+			}
 			return
 		}
 
-		// Validate JWT token
-		claims, err := m.jwtService.ValidateToken(cookie.Value)
-		if err != nil {
-			web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid authentication token")
-			return
+		ctx := r.Context()
+		var userID string
+		if userdomain.IsPersonalAccessToken(token) {
+			// Personal access tokens are checked against their scopes instead of a session
+			accessToken, err := m.accessTokenService.Authenticate(ctx, token)
+			if err != nil {
+				switch err {
+				case userdomain.ErrInvalidAccessToken, userdomain.ErrAccessTokenExpired:
+					web.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+				default:
+					web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to validate access token")
+				}
+				return
+			}
+
+			required := requiredAPIScope(r)
+			if !accessToken.HasScope(string(required)) {
+				web.WriteErrorResponse(w, http.StatusForbidden, "Access token is missing the "+string(required)+" scope")
+				return
+			}
+
+			userID = accessToken.UserID
+			ctx = withAccessToken(ctx, accessToken)
+		} else {
+			// Validate JWT token
+			claims, err := m.jwtService.ValidateToken(token)
+			if err != nil {
+				web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid authentication token")
+				return
+			}
+
+			// Reject tokens whose session was logged out or revoked after token reuse
+			session, err := m.sessionService.ValidateSession(ctx, claims.SessionID, claims.UserID)
+			if err != nil {
+				if err == userdomain.ErrSessionRevoked {
+					web.WriteErrorResponse(w, http.StatusUnauthorized, "Session has been revoked")
+				} else {
+					web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to validate session")
+				}
+				return
+			}
+
+			userID = claims.UserID
+			ctx = withSessionID(ctx, session.ID)
+			if session.MFAVerified {
+				ctx = sharedauth.WithMFAVerified(ctx)
+			}
 		}
 
 		// Verify that the user still exists (important for user deletion/deactivation)
-		user, err := m.userService.GetUser(r.Context(), claims.UserID)
+		user, err := m.userService.GetUser(ctx, userID)
 		if err != nil {
 			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
 			return
 		}
 
-		// Add user ID to request context
-		ctx := web.SetUserIDInContext(r.Context(), user.ID)
+		// Resolve the caller's role and permissions once for the whole request
+		role, err := m.roleQueries.GetRoleByID(ctx, user.RoleID)
+		if err != nil {
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to load user role")
+			return
+		}
+		userCtx := &sharedauth.UserContext{
+			UserID:      user.ID,
+			RoleID:      role.ID,
+			RoleName:    role.Name,
+			Scopes:      sharedauth.ScopesFromContext(ctx),
+			MFAVerified: sharedauth.MFAVerifiedFromContext(ctx),
+		}
+		if err := m.authService.ResolvePermissions(ctx, userCtx); err != nil {
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to load user permissions")
+			return
+		}
+
+		// Add user ID and user context to request context
+		ctx = web.SetUserIDInContext(ctx, user.ID)
+		ctx = sharedauth.WithUserContext(ctx, userCtx)
 		r = r.WithContext(ctx)
 
 		// Call the next handler
@@ -61,35 +144,101 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 // RequireAuthFunc is a middleware function that requires authentication for the wrapped handler function.
 // AI-hint: JWT-based function wrapper version of RequireAuth for direct handler function wrapping.
 func (m *AuthMiddleware) RequireAuthFunc(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Get JWT token from HTTP-only cookie
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
-			return
-		}
+	return m.RequireAuth(next).ServeHTTP
+}
 
-		// Validate JWT token
-		claims, err := m.jwtService.ValidateToken(cookie.Value)
-		if err != nil {
-			web.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid authentication token")
-			return
-		}
+// RequirePermission returns middleware that only lets callers holding the permission through.
+// AI-hint: Must run inside RequireAuth, which stores the caller's UserContext; without one
+// the request is rejected with 401. CanPerform applies token scopes and the 2FA policy, and
+// failures answer 403 before the handler runs. Services keep their own checks as well.
+func (m *AuthMiddleware) RequirePermission(permission sharedauth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userCtx, ok := sharedauth.UserContextFromContext(r.Context())
+			if !ok {
+				web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+				return
+			}
 
-		// Verify that the user still exists
-		user, err := m.userService.GetUser(r.Context(), claims.UserID)
-		if err != nil {
-			web.WriteErrorResponse(w, http.StatusUnauthorized, "User not found")
-			return
-		}
+			if !m.authService.CanPerform(userCtx, permission) {
+				web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
+				return
+			}
 
-		// Add user ID to request context
-		ctx := web.SetUserIDInContext(r.Context(), user.ID)
-		r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		// Call the next handler
-		next.ServeHTTP(w, r)
+// RequirePermissionFunc is the handler function version of RequirePermission.
+// AI-hint: Used inside routes already wrapped in RequireAuthFunc, e.g. per HTTP method.
+func (m *AuthMiddleware) RequirePermissionFunc(permission sharedauth.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return m.RequirePermission(permission)(next).ServeHTTP
+}
+
+// withAccessToken limits the request to the personal access token's scopes.
+// AI-hint: The token counts as two-factor verified only if the login that created it was;
+// tokens created before 2FA was required, or before their owner was promoted, are not.
+func withAccessToken(ctx context.Context, token *userdomain.PersonalAccessToken) context.Context {
+	ctx = sharedauth.WithScopes(ctx, userapp.TokenPermissions(token))
+	if token.MFAVerified {
+		ctx = sharedauth.WithMFAVerified(ctx)
+	}
+	return ctx
+}
+
+// sessionContextKey is the context key for the session ID of a JWT-authenticated request.
+type sessionContextKey struct{}
+
+// withSessionID stores the authenticated session's ID in the request context.
+func withSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, sessionID)
+}
+
+// sessionIDFromContext returns the session ID set by RequireAuth.
+// AI-hint: Empty for requests authenticated with a personal access token.
+func sessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionContextKey{}).(string)
+	return sessionID
+}
+
+// requiredAPIScope returns the coarse scope an access token needs for the request.
+// AI-hint: Safe methods need api:read, everything else api:write. Finer-grained scopes
+// are enforced by AuthorizationService.CanPerform in the application services.
+func requiredAPIScope(r *http.Request) sharedauth.Permission {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return sharedauth.PermissionAPIRead
+	default:
+		return sharedauth.PermissionAPIWrite
+	}
+}
+
+// Errors returned by accessTokenFromRequest.
+var (
+	errMissingAccessToken     = errors.New("no access token presented")
+	errMalformedAuthorization = errors.New("authorization header is not a bearer token")
+)
+
+// accessTokenFromRequest returns the access token presented with the request.
+// AI-hint: Precedence - an Authorization header wins over the auth_token cookie. A header
+// that is not a Bearer token is rejected instead of falling back to the cookie, so a
+// misconfigured client never silently acts as whoever is logged in to the browser.
+func accessTokenFromRequest(r *http.Request) (string, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		token = strings.TrimSpace(token)
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", errMalformedAuthorization
+		}
+		return token, nil
+	}
+
+	cookie, err := r.Cookie(accessTokenCookie)
+	if err != nil || cookie.Value == "" {
+		return "", errMissingAccessToken
 	}
+	return cookie.Value, nil
 }
 
 // CORS middleware to handle Cross-Origin Resource Sharing.
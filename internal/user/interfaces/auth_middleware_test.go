@@ -0,0 +1,81 @@
+package interfaces
+
+import (
+	"context"
+	sharedauth "feedback_hub_2/internal/shared/auth"
+	userdomain "feedback_hub_2/internal/user/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokenFromRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		cookie        string
+		wantToken     string
+		wantErr       error
+	}{
+		{name: "header wins over cookie", authorization: "Bearer header-token", cookie: "cookie-token", wantToken: "header-token"},
+		{name: "header only", authorization: "Bearer header-token", wantToken: "header-token"},
+		{name: "scheme is case-insensitive", authorization: "bearer header-token", wantToken: "header-token"},
+		{name: "cookie only", cookie: "cookie-token", wantToken: "cookie-token"},
+		{name: "basic header does not fall back to cookie", authorization: "Basic dXNlcjpwYXNz", cookie: "cookie-token", wantErr: errMalformedAuthorization},
+		{name: "bearer with empty token", authorization: "Bearer ", cookie: "cookie-token", wantErr: errMalformedAuthorization},
+		{name: "bearer without token", authorization: "Bearer", wantErr: errMalformedAuthorization},
+		{name: "neither header nor cookie", wantErr: errMissingAccessToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/ideas", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: accessTokenCookie, Value: tt.cookie})
+			}
+
+			token, err := accessTokenFromRequest(r)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantToken, token)
+		})
+	}
+}
+
+func TestWithAccessToken_MFAPolicy(t *testing.T) {
+	authService := sharedauth.NewAuthorizationServiceWithPolicy(sharedauth.AuthorizationPolicy{MFARequiredRoles: []string{"Product Owner"}})
+	scopes := []string{string(sharedauth.PermissionAPIWrite), string(sharedauth.PermissionChangeIdeaStatus)}
+
+	// userContextFor builds the caller the way RequireAuth does for a Product Owner's token
+	userContextFor := func(token *userdomain.PersonalAccessToken) *sharedauth.UserContext {
+		ctx := withAccessToken(context.Background(), token)
+		userCtx := &sharedauth.UserContext{
+			UserID:      token.UserID,
+			RoleName:    "Product Owner",
+			Scopes:      sharedauth.ScopesFromContext(ctx),
+			MFAVerified: sharedauth.MFAVerifiedFromContext(ctx),
+		}
+		require.NoError(t, authService.ResolvePermissions(ctx, userCtx))
+		return userCtx
+	}
+
+	t.Run("token without two-factor verification", func(t *testing.T) {
+		token, _, err := userdomain.NewPersonalAccessToken("owner-id", "ci", scopes, nil)
+		require.NoError(t, err)
+
+		assert.False(t, authService.CanPerform(userContextFor(token), sharedauth.PermissionChangeIdeaStatus))
+	})
+
+	t.Run("token created from a two-factor verified login", func(t *testing.T) {
+		token, _, err := userdomain.NewPersonalAccessToken("owner-id", "ci", scopes, nil)
+		require.NoError(t, err)
+		token.MFAVerified = true
+
+		assert.True(t, authService.CanPerform(userContextFor(token), sharedauth.PermissionChangeIdeaStatus))
+	})
+}
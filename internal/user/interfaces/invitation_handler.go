@@ -0,0 +1,291 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"feedback_hub_2/internal/shared/web"
+	userapp "feedback_hub_2/internal/user/application"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationHandler handles HTTP requests for user invitations.
+// AI-hint: HTTP transport layer for /invitations (administrators managing pending
+// invitations) and /auth/invitations/{token} (the invitee choosing their password).
+type InvitationHandler struct {
+	invitationService *userapp.InvitationService
+	authHandler       *AuthHandler
+}
+
+// NewInvitationHandler creates a new InvitationHandler instance.
+// AI-hint: Accepting an invitation logs the invitee in through authHandler, so they get
+// the same cookies as with password login.
+func NewInvitationHandler(invitationService *userapp.InvitationService, authHandler *AuthHandler) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+		authHandler:       authHandler,
+	}
+}
+
+// AcceptInvitationRequest represents the request body for accepting an invitation.
+type AcceptInvitationRequest struct {
+	Password string `json:"password"`
+}
+
+// InvitationResponse represents a pending invitation in API responses.
+// AI-hint: Status is "pending" or "expired"; expired invitations can still be resent.
+type InvitationResponse struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	Email           string    `json:"email"`
+	Name            string    `json:"name"`
+	RoleName        string    `json:"role_name"`
+	InvitedByUserID string    `json:"invited_by_user_id,omitempty"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// InvitationInfoResponse describes an invitation to the invitee before they accept it.
+type InvitationInfoResponse struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// ListInvitations handles GET /invitations requests.
+// AI-hint: Only lists invitations for users whose role the caller may create.
+//
+// @Summary List pending invitations
+// @Description List invitations that were neither accepted nor revoked, including expired ones
+// @Tags invitations
+// @Produce json
+// @Success 200 {array} InvitationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /invitations [get]
+func (h *InvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	details, err := h.invitationService.ListPending(r.Context(), userID)
+	if err != nil {
+		if err == domain.ErrUnauthorized {
+			web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
+			return
+		}
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to list invitations")
+		return
+	}
+
+	response := make([]InvitationResponse, 0, len(details))
+	for _, detail := range details {
+		response = append(response, toInvitationResponse(detail.Invitation, detail.User, detail.RoleName))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ResendInvitation handles POST /invitations/{id}/resend requests.
+// AI-hint: Mails a new link with a fresh expiry; the previous link stops working.
+//
+// @Summary Resend an invitation
+// @Description Mail the invitation again with a new link and expiry
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	invitationID := web.ExtractIDFromPath(r.URL.Path, "/invitations/")
+	if _, err := uuid.Parse(invitationID); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid invitation ID")
+		return
+	}
+
+	invitation, err := h.invitationService.Resend(r.Context(), invitationID, userID)
+	if err != nil {
+		if invitation == nil {
+			writeInvitationError(w, err, "Failed to resend invitation")
+			return
+		}
+		// The new link was stored but could not be mailed
+		log.Printf("Failed to resend invitation %s: %v", invitationID, err)
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to send invitation email")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Invitation has been sent to " + invitation.Email})
+}
+
+// RevokeInvitation handles DELETE /invitations/{id} requests.
+// AI-hint: The invited user is kept without a password; delete them via /users/{id} if needed.
+//
+// @Summary Revoke an invitation
+// @Description Cancel a pending invitation so its link no longer works
+// @Tags invitations
+// @Param id path string true "Invitation ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	invitationID := web.ExtractIDFromPath(r.URL.Path, "/invitations/")
+	if _, err := uuid.Parse(invitationID); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.invitationService.Revoke(r.Context(), invitationID, userID); err != nil {
+		writeInvitationError(w, err, "Failed to revoke invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetInvitation handles GET /auth/invitations/{token} requests.
+// AI-hint: Public endpoint the accept page calls to check the link and greet the invitee.
+//
+// @Summary Look up an invitation
+// @Description Check an invitation link and return who it was sent to
+// @Tags auth
+// @Produce json
+// @Param token path string true "Invitation token from the mailed link"
+// @Success 200 {object} InvitationInfoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/invitations/{token} [get]
+func (h *InvitationHandler) GetInvitation(w http.ResponseWriter, r *http.Request) {
+	token := web.ExtractIDFromPath(r.URL.Path, "/auth/invitations/")
+	w.Header().Set("Cache-Control", "no-store")
+
+	user, err := h.invitationService.UserForToken(r.Context(), token)
+	if err != nil {
+		writeInvitationError(w, err, "Failed to look up invitation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(InvitationInfoResponse{Email: user.Email, Name: user.Name})
+}
+
+// AcceptInvitation handles POST /auth/invitations/{token}/accept requests.
+// AI-hint: Sets the invitee's first password, verifies their email address, and logs
+// them in with session cookies. Each link can only be accepted once.
+//
+// @Summary Accept an invitation
+// @Description Choose a password with the token from an invitation link and log in
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token path string true "Invitation token from the mailed link"
+// @Param request body AcceptInvitationRequest true "New password"
+// @Success 200 {object} AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} PasswordPolicyErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/invitations/{token}/accept [post]
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	token := web.ExtractIDFromPath(r.URL.Path, "/auth/invitations/")
+
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Password == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Password is required")
+		return
+	}
+
+	// Validate password strength against the account the invitation belongs to
+	user, err := h.invitationService.UserForToken(r.Context(), token)
+	if err != nil {
+		writeInvitationError(w, err, "Failed to accept invitation")
+		return
+	}
+	if err := h.authHandler.passwordService.ValidatePassword(req.Password, user.Email, user.Name); err != nil {
+		writePasswordPolicyError(w, err)
+		return
+	}
+
+	hashedPassword, err := h.authHandler.passwordService.HashPassword(req.Password)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	user, err = h.invitationService.Accept(r.Context(), token, hashedPassword)
+	if err != nil {
+		writeInvitationError(w, err, "Failed to accept invitation")
+		return
+	}
+
+	h.authHandler.completeLogin(w, r, user, tokenModeCookie, false)
+}
+
+// writeInvitationError maps invitation errors to HTTP responses.
+func writeInvitationError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case domain.ErrInvalidInvitation:
+		web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case domain.ErrUnauthorized:
+		web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
+	case domain.ErrInvitationNotFound:
+		web.WriteErrorResponse(w, http.StatusNotFound, "Invitation not found")
+	case domain.ErrInvitationNotPending:
+		web.WriteErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		web.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// toInvitationResponse converts an invitation and its user to the API representation.
+func toInvitationResponse(invitation *domain.Invitation, user *domain.User, roleName string) InvitationResponse {
+	return InvitationResponse{
+		ID:              invitation.ID,
+		UserID:          user.ID,
+		Email:           invitation.Email,
+		Name:            user.Name,
+		RoleName:        roleName,
+		InvitedByUserID: invitation.InvitedByUserID,
+		Status:          invitation.Status(),
+		CreatedAt:       invitation.CreatedAt,
+		ExpiresAt:       invitation.ExpiresAt,
+	}
+}
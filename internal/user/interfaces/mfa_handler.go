@@ -0,0 +1,253 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"feedback_hub_2/internal/shared/web"
+	userapp "feedback_hub_2/internal/user/application"
+	"feedback_hub_2/internal/user/domain"
+	"net/http"
+)
+
+// MFAHandler handles HTTP requests for two-factor authentication management.
+// AI-hint: HTTP transport layer for /auth/mfa. Everything acts on the authenticated user;
+// the second login step itself lives in AuthHandler.LoginMFA.
+type MFAHandler struct {
+	mfaService *userapp.MFAService
+}
+
+// NewMFAHandler creates a new MFAHandler instance.
+// AI-hint: Factory method for MFA handler with dependency injection of the MFA service.
+func NewMFAHandler(mfaService *userapp.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// MFACodeRequest represents a request body carrying a two-factor authentication code.
+// AI-hint: Code is a current authenticator code; where noted, an unused recovery code also works.
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPEnrollmentResponse represents a started TOTP enrollment.
+// AI-hint: Render ProvisioningURI as a QR code, or let users type Secret into their app.
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse represents a freshly generated set of recovery codes.
+// AI-hint: The codes are shown only once; each can replace an authenticator code one time.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// GetMFAStatus handles GET /auth/mfa requests.
+//
+// @Summary Get two-factor authentication status
+// @Description Show whether two-factor authentication is enabled, required for the user's role, and how many recovery codes are left
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} userapp.MFAStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/mfa [get]
+func (h *MFAHandler) GetMFAStatus(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	status, err := h.mfaService.Status(r.Context(), userID)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get two-factor authentication status")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
+
+// BeginTOTPEnrollment handles POST /auth/mfa/totp requests.
+// AI-hint: Returns a new secret; two-factor authentication is only enabled after
+// /auth/mfa/totp/confirm. Requires a session login.
+//
+// @Summary Start TOTP enrollment
+// @Description Generate an authenticator secret and its otpauth:// provisioning URI for a QR code
+// @Tags mfa
+// @Produce json
+// @Success 201 {object} TOTPEnrollmentResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/mfa/totp [post]
+func (h *MFAHandler) BeginTOTPEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	credential, uri, err := h.mfaService.BeginEnrollment(r.Context(), userID)
+	if err != nil {
+		writeMFAError(w, err, "Failed to start two-factor enrollment")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(TOTPEnrollmentResponse{
+		Secret:          credential.Secret,
+		ProvisioningURI: uri,
+	})
+}
+
+// ConfirmTOTPEnrollment handles POST /auth/mfa/totp/confirm requests.
+// AI-hint: Enables two-factor authentication and returns the first recovery codes. The
+// current session counts as two-factor verified afterwards.
+//
+// @Summary Confirm TOTP enrollment
+// @Description Enable two-factor authentication by submitting a code from the authenticator app
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body MFACodeRequest true "Current authenticator code"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTPEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(r.Context(), userID, sessionIDFromContext(r.Context()), req.Code)
+	if err != nil {
+		writeMFAError(w, err, "Failed to confirm two-factor enrollment")
+		return
+	}
+
+	writeRecoveryCodes(w, codes)
+}
+
+// DisableTOTP handles DELETE /auth/mfa/totp requests.
+// AI-hint: Needs a current authenticator or recovery code; refused for roles that must
+// use two-factor authentication.
+//
+// @Summary Disable two-factor authentication
+// @Description Remove the authenticator and recovery codes after confirming a current code
+// @Tags mfa
+// @Accept json
+// @Param code body MFACodeRequest true "Current authenticator or recovery code"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/mfa/totp [delete]
+func (h *MFAHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	if err := h.mfaService.Disable(r.Context(), userID, req.Code); err != nil {
+		writeMFAError(w, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes handles POST /auth/mfa/recovery-codes requests.
+// AI-hint: Replaces all recovery codes; the old ones stop working immediately.
+//
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes after confirming a current authenticator or recovery code
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body MFACodeRequest true "Current authenticator or recovery code"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /auth/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+	if err != nil {
+		writeMFAError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	writeRecoveryCodes(w, codes)
+}
+
+// writeRecoveryCodes writes a set of recovery codes that must not be cached.
+func writeRecoveryCodes(w http.ResponseWriter, codes []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// writeMFAError maps MFA service errors to HTTP responses.
+func writeMFAError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case domain.ErrUnauthorized:
+		web.WriteErrorResponse(w, http.StatusForbidden, "Two-factor authentication can only be managed from a login session")
+	case domain.ErrMFARequiredByPolicy:
+		web.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+	case domain.ErrInvalidMFACode:
+		web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case domain.ErrTOTPNotEnrolled:
+		web.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+	case domain.ErrTOTPAlreadyEnabled:
+		web.WriteErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		web.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,254 @@
+package interfaces
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"feedback_hub_2/internal/shared/web"
+	userapp "feedback_hub_2/internal/user/application"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// oidcStateCookie binds a single sign-on login to the browser that started it.
+// AI-hint: SameSite=Lax so the cookie survives the top-level redirect back from the
+// identity provider; scoped to /auth/oidc so it is only sent to the callback.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/auth/oidc"
+)
+
+// Error codes appended as ?error= when a single sign-on login fails.
+const (
+	oidcErrorAccessDenied     = "access_denied"
+	oidcErrorInvalidState     = "invalid_state"
+	oidcErrorEmailNotVerified = "email_not_verified"
+	oidcErrorNoAccount        = "no_account"
+	oidcErrorFailed           = "sso_failed"
+)
+
+// OIDCHandler handles HTTP requests for OpenID Connect single sign-on.
+// AI-hint: HTTP transport layer for /auth/oidc. Both endpoints are browser navigations:
+// login redirects to the identity provider, and the callback sets the usual auth cookies
+// and redirects to the frontend, with ?error= on failure.
+type OIDCHandler struct {
+	oidcService      *userapp.OIDCService
+	mfaService       *userapp.MFAService
+	authHandler      *AuthHandler
+	loginRedirectURL string
+}
+
+// NewOIDCHandler creates a new OIDCHandler instance.
+// AI-hint: Sessions are started through authHandler so single sign-on issues the same
+// cookies as password login. loginRedirectURL is the frontend page the callback returns to.
+func NewOIDCHandler(oidcService *userapp.OIDCService, mfaService *userapp.MFAService, authHandler *AuthHandler, loginRedirectURL string) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:      oidcService,
+		mfaService:       mfaService,
+		authHandler:      authHandler,
+		loginRedirectURL: loginRedirectURL,
+	}
+}
+
+// OIDCProviderInfo describes a provider users can log in with.
+type OIDCProviderInfo struct {
+	Name     string `json:"name"`
+	LoginURL string `json:"login_url"`
+}
+
+// OIDCProvidersResponse lists the configured single sign-on providers.
+type OIDCProvidersResponse struct {
+	Providers []OIDCProviderInfo `json:"providers"`
+}
+
+// ListProviders handles GET /auth/oidc requests.
+// AI-hint: Lets the login page show a button per provider.
+//
+// @Summary List single sign-on providers
+// @Description List the OpenID Connect providers users can log in with
+// @Tags auth
+// @Produce json
+// @Success 200 {object} OIDCProvidersResponse
+// @Router /auth/oidc [get]
+func (h *OIDCHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
+	response := OIDCProvidersResponse{Providers: []OIDCProviderInfo{}}
+	for _, name := range h.oidcService.ProviderNames() {
+		response.Providers = append(response.Providers, OIDCProviderInfo{
+			Name:     name,
+			LoginURL: "/auth/oidc/" + name + "/login",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Login handles GET /auth/oidc/{provider}/login requests.
+// AI-hint: Starts the authorization code flow with PKCE and redirects the browser to
+// the provider. The state is also stored in the oidc_state cookie.
+//
+// @Summary Start single sign-on
+// @Description Redirect to the identity provider to log in
+// @Tags auth
+// @Param provider path string true "Provider name"
+// @Success 302
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /auth/oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	provider := web.ExtractIDFromPath(r.URL.Path, "/auth/oidc/")
+
+	authURL, state, err := h.oidcService.StartLogin(r.Context(), provider)
+	if err != nil {
+		if err == domain.ErrUnknownIdentityProvider {
+			web.WriteErrorResponse(w, http.StatusNotFound, "Unknown identity provider")
+			return
+		}
+		log.Printf("Failed to start single sign-on with %s: %v", provider, err)
+		web.WriteErrorResponse(w, http.StatusBadGateway, "Identity provider is unavailable")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		HttpOnly: true,
+		Secure:   os.Getenv("ENVIRONMENT") == "production",
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(domain.DefaultOIDCLoginTTL.Seconds()),
+		Path:     oidcStateCookiePath,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback handles GET /auth/oidc/{provider}/callback requests.
+// AI-hint: Verifies the state against the cookie, redeems the code, and logs the user in.
+// Users with local two-factor authentication are sent to the frontend with mfa_token
+// unless the provider reports that it already checked a second factor.
+//
+// @Summary Finish single sign-on
+// @Description Callback the identity provider redirects to after login
+// @Tags auth
+// @Param provider path string true "Provider name"
+// @Param code query string false "Authorization code"
+// @Param state query string true "Login state"
+// @Success 302
+// @Failure 404 {object} ErrorResponse
+// @Router /auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	provider := web.ExtractIDFromPath(r.URL.Path, "/auth/oidc/")
+	query := r.URL.Query()
+	w.Header().Set("Cache-Control", "no-store")
+
+	cookieState := ""
+	if cookie, err := r.Cookie(oidcStateCookie); err == nil {
+		cookieState = cookie.Value
+	}
+	clearOIDCStateCookie(w)
+
+	if query.Get("error") != "" {
+		code := oidcErrorFailed
+		if query.Get("error") == oidcErrorAccessDenied {
+			code = oidcErrorAccessDenied
+		}
+		h.redirect(w, r, url.Values{"error": {code}})
+		return
+	}
+
+	state := query.Get("state")
+	if state == "" || cookieState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		h.redirect(w, r, url.Values{"error": {oidcErrorInvalidState}})
+		return
+	}
+
+	user, identity, err := h.oidcService.CompleteLogin(r.Context(), provider, state, query.Get("code"))
+	if err != nil {
+		switch err {
+		case domain.ErrUnknownIdentityProvider:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Unknown identity provider")
+		case domain.ErrInvalidOIDCLogin:
+			h.redirect(w, r, url.Values{"error": {oidcErrorInvalidState}})
+		case domain.ErrOIDCEmailNotVerified:
+			h.redirect(w, r, url.Values{"error": {oidcErrorEmailNotVerified}})
+		case domain.ErrOIDCProvisioningDisabled:
+			h.redirect(w, r, url.Values{"error": {oidcErrorNoAccount}})
+		default:
+			log.Printf("Single sign-on with %s failed: %v", provider, err)
+			h.redirect(w, r, url.Values{"error": {oidcErrorFailed}})
+		}
+		return
+	}
+
+	mfaVerified := identity.UsedMFA()
+	if !mfaVerified {
+		mfaEnabled, err := h.mfaService.IsEnabled(r.Context(), user.ID)
+		if err != nil {
+			log.Printf("Failed to check two-factor authentication for user %s: %v", user.ID, err)
+			h.redirect(w, r, url.Values{"error": {oidcErrorFailed}})
+			return
+		}
+		if mfaEnabled {
+			mfaToken, _, err := h.mfaService.StartChallenge(r.Context(), user.ID)
+			if err != nil {
+				log.Printf("Failed to start two-factor authentication for user %s: %v", user.ID, err)
+				h.redirect(w, r, url.Values{"error": {oidcErrorFailed}})
+				return
+			}
+			h.redirect(w, r, url.Values{"mfa_required": {"true"}, "mfa_token": {mfaToken}})
+			return
+		}
+	}
+
+	role, err := h.authHandler.roleService.GetRole(r.Context(), user.RoleID)
+	if err != nil {
+		log.Printf("Failed to get role of user %s: %v", user.ID, err)
+		h.redirect(w, r, url.Values{"error": {oidcErrorFailed}})
+		return
+	}
+	tokens, err := h.authHandler.startSession(r, user, role.Name, mfaVerified)
+	if err != nil {
+		log.Printf("Failed to start session for user %s: %v", user.ID, err)
+		h.redirect(w, r, url.Values{"error": {oidcErrorFailed}})
+		return
+	}
+	h.authHandler.setAuthCookies(w, tokens)
+
+	params := url.Values{}
+	if !mfaVerified {
+		if required, err := h.mfaService.RoleRequiresMFA(r.Context(), user); err == nil && required {
+			params.Set("mfa_setup_required", "true")
+		}
+	}
+	h.redirect(w, r, params)
+}
+
+// redirect sends the browser to the login redirect URL with the given query parameters.
+func (h *OIDCHandler) redirect(w http.ResponseWriter, r *http.Request, params url.Values) {
+	target := h.loginRedirectURL
+	if encoded := params.Encode(); encoded != "" {
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+		target += separator + encoded
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// clearOIDCStateCookie deletes the single sign-on state cookie.
+func clearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		HttpOnly: true,
+		Secure:   os.Getenv("ENVIRONMENT") == "production",
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+		Path:     oidcStateCookiePath,
+	})
+}
@@ -0,0 +1,110 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"feedback_hub_2/internal/shared/web"
+	userapp "feedback_hub_2/internal/user/application"
+	"feedback_hub_2/internal/user/domain"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// SetupHandler handles the first-run setup of a fresh installation.
+// AI-hint: HTTP transport layer for /setup. The endpoint answers 410 Gone for good once
+// the first Super User has a password, whether it was set here or from SUPER_USER_PASSWORD,
+// and stays gone after that account is deleted or demoted.
+type SetupHandler struct {
+	setupService *userapp.SetupService
+	authHandler  *AuthHandler
+}
+
+// NewSetupHandler creates a new SetupHandler instance.
+// AI-hint: Completing setup logs the new Super User in through authHandler.
+func NewSetupHandler(setupService *userapp.SetupService, authHandler *AuthHandler) *SetupHandler {
+	return &SetupHandler{
+		setupService: setupService,
+		authHandler:  authHandler,
+	}
+}
+
+// SetupRequest represents the request body for first-run setup.
+// AI-hint: Token is the one-time setup token from the server log. If a passwordless Super
+// User with this email exists (from SUPER_USER_EMAIL), it gets the password.
+type SetupRequest struct {
+	Token    string `json:"token"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// Setup handles POST /setup requests.
+// AI-hint: Public endpoint; the logged token proves the caller has access to the deployment.
+//
+// @Summary Complete first-run setup
+// @Description Create the first Super User with a password using the one-time token from the server log
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body SetupRequest true "Setup token and Super User details"
+// @Success 200 {object} AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} PasswordPolicyErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /setup [post]
+func (h *SetupHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	complete, err := h.setupService.IsComplete(r.Context())
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to check setup status")
+		return
+	}
+	if complete {
+		web.WriteErrorResponse(w, http.StatusGone, domain.ErrSetupCompleted.Error())
+		return
+	}
+
+	var req SetupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Token == "" || strings.TrimSpace(req.Email) == "" || strings.TrimSpace(req.Name) == "" || req.Password == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "Token, email, name and password are required")
+		return
+	}
+
+	if err := h.authHandler.passwordService.ValidatePassword(req.Password, req.Email, req.Name); err != nil {
+		writePasswordPolicyError(w, err)
+		return
+	}
+	hashedPassword, err := h.authHandler.passwordService.HashPassword(req.Password)
+	if err != nil {
+		web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	user, err := h.setupService.CompleteSetup(r.Context(), req.Token, req.Email, req.Name, hashedPassword)
+	if err != nil {
+		switch err {
+		case domain.ErrSetupCompleted:
+			web.WriteErrorResponse(w, http.StatusGone, err.Error())
+		case domain.ErrInvalidSetupToken:
+			web.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+		case domain.ErrEmailAlreadyExists:
+			web.WriteErrorResponse(w, http.StatusConflict, "Email belongs to a user who is not a Super User")
+		default:
+			if strings.Contains(err.Error(), "email") || strings.Contains(err.Error(), "name") {
+				web.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			log.Printf("First-run setup failed: %v", err)
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to complete setup")
+		}
+		return
+	}
+
+	h.authHandler.completeLogin(w, r, user, tokenModeCookie, false)
+}
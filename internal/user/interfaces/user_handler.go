@@ -5,6 +5,7 @@ import (
 	"feedback_hub_2/internal/shared/web"
 	userapp "feedback_hub_2/internal/user/application"
 	"feedback_hub_2/internal/user/domain"
+	"log"
 	"net/http"
 	"strings"
 )
@@ -13,14 +14,18 @@ import (
 // AI-hint: HTTP transport layer for user operations following REST conventions.
 // Provides proper error handling, status codes, and JSON responses.
 type UserHandler struct {
-	userService *userapp.UserService
+	userService       *userapp.UserService
+	throttleService   *userapp.LoginThrottleService
+	invitationService *userapp.InvitationService
 }
 
 // NewUserHandler creates a new UserHandler instance.
-// AI-hint: Factory method for user handler with dependency injection of user service.
-func NewUserHandler(userService *userapp.UserService) *UserHandler {
+// AI-hint: Factory method for user handler with dependency injection of user, login throttle, and invitation services.
+func NewUserHandler(userService *userapp.UserService, throttleService *userapp.LoginThrottleService, invitationService *userapp.InvitationService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:       userService,
+		throttleService:   throttleService,
+		invitationService: invitationService,
 	}
 }
 
@@ -57,9 +62,10 @@ type UpdateUserRoleRequest struct {
 
 // CreateUser handles POST /users requests.
 // AI-hint: User creation endpoint with authorization, validation, and proper error handling.
+// The new user has no password; they are mailed an invitation to choose one.
 //
 // @Summary Create a new user
-// @Description Create a new user with role assignment (authorization rules apply)
+// @Description Create a new user with role assignment (authorization rules apply) and mail them an invitation to set their password
 // @Tags users
 // @Accept json
 // @Produce json
@@ -70,7 +76,7 @@ type UpdateUserRoleRequest struct {
 // @Failure 403 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users [post]
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -118,6 +124,11 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Invite the user to choose a password; a failed mail can be resent from /invitations
+	if _, err := h.invitationService.Invite(r.Context(), newUser.ID, userID); err != nil {
+		log.Printf("Warning: failed to send invitation to new user %s: %v", newUser.ID, err)
+	}
+
 	// Return the created user
 	response := UserResponse{
 		ID:        newUser.ID,
@@ -145,7 +156,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -198,7 +209,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} UserResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users [get]
 func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -248,7 +259,7 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -321,7 +332,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users/{id}/role [put]
 func (h *UserHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -386,15 +397,17 @@ func (h *UserHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
 // AI-hint: User deletion endpoint with proper authorization and error handling.
 //
 // @Summary Delete a user
-// @Description Delete a user (authorization rules apply)
+// @Description Delete a user (authorization rules apply). The user's ideas are kept: they are reassigned to reassign_to, or anonymized when it is omitted.
 // @Tags users
 // @Param id path string true "User ID"
+// @Param reassign_to query string false "User ID to take over the deleted user's ideas" format(uuid)
 // @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Security JWTAuth
+// @Security JWTAuth || BearerAuth
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by authentication middleware)
@@ -411,10 +424,12 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete the user
-	err := h.userService.DeleteUser(r.Context(), targetUserID, userID)
+	// Delete the user, moving their ideas to reassign_to when given
+	err := h.userService.DeleteUser(r.Context(), targetUserID, r.URL.Query().Get("reassign_to"), userID)
 	if err != nil {
 		switch err {
+		case domain.ErrInvalidReassignment:
+			web.WriteErrorResponse(w, http.StatusBadRequest, "reassign_to must be another existing user")
 		case domain.ErrUnauthorized:
 			web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
 		case domain.ErrUserNotFound:
@@ -428,3 +443,49 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Return 204 No Content for successful deletion
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// UnlockUser handles POST /users/{id}/unlock requests.
+// AI-hint: Lifts a login lockout early and clears the account's failed logins. The
+// client IP lockout of whoever was guessing is not affected.
+//
+// @Summary Unlock a user account
+// @Description Lift a temporary lockout caused by failed logins (Super User only)
+// @Tags users
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security JWTAuth || BearerAuth
+// @Router /users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by authentication middleware)
+	userID := web.GetUserIDFromContext(r.Context())
+	if userID == "" {
+		web.WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract user ID from URL path
+	targetUserID := web.ExtractIDFromPath(r.URL.Path, "/users/")
+	if targetUserID == "" {
+		web.WriteErrorResponse(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	if err := h.throttleService.UnlockUser(r.Context(), targetUserID, userID); err != nil {
+		switch err {
+		case domain.ErrUnauthorized:
+			web.WriteErrorResponse(w, http.StatusForbidden, "Insufficient permissions")
+		case domain.ErrUserNotFound:
+			web.WriteErrorResponse(w, http.StatusNotFound, "User not found")
+		default:
+			web.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
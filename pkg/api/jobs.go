@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ideadomain "feedback_hub_2/internal/idea/domain"
+	appconfig "feedback_hub_2/pkg/config"
+)
+
+// StartBackgroundJobs starts periodic maintenance jobs such as purging soft-deleted ideas.
+// AI-hint: Only long-running servers should call this; serverless handlers skip it.
+// Jobs stop when Close is called. Must be called after Initialize.
+func (s *Server) StartBackgroundJobs() {
+	if !s.initialized || s.stopJobs != nil {
+		return
+	}
+
+	retention := ideadomain.DefaultPurgeRetention
+	if days := appconfig.IdeaPurgeRetentionDays(); days == 0 {
+		log.Println("Idea purge disabled (IDEA_PURGE_RETENTION_DAYS=0)")
+		return
+	} else if days > 0 {
+		retention = time.Duration(days) * 24 * time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopJobs = cancel
+
+	go s.runIdeaPurge(ctx, retention, appconfig.IdeaPurgeInterval())
+}
+
+// runIdeaPurge purges expired soft-deleted ideas on every tick until ctx is cancelled.
+func (s *Server) runIdeaPurge(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.ideaService.PurgeDeletedIdeas(ctx, retention)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Warning: failed to purge deleted ideas: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d ideas deleted more than %s ago", purged, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	commentapp "feedback_hub_2/internal/comment/application"
+	commentinterfaces "feedback_hub_2/internal/comment/interfaces"
 	ideaapp "feedback_hub_2/internal/idea/application"
 	ideainterfaces "feedback_hub_2/internal/idea/interfaces"
 	roleapp "feedback_hub_2/internal/role/application"
@@ -16,12 +18,15 @@ import (
 	"feedback_hub_2/internal/shared/auth"
 	"feedback_hub_2/internal/shared/bootstrap"
 	events "feedback_hub_2/internal/shared/bus"
+	"feedback_hub_2/internal/shared/mail"
 	"feedback_hub_2/internal/shared/persistence"
 	"feedback_hub_2/internal/shared/queries"
 	web "feedback_hub_2/internal/shared/web"
 	userapp "feedback_hub_2/internal/user/application"
+	userdomain "feedback_hub_2/internal/user/domain"
 	authinfra "feedback_hub_2/internal/user/infrastructure/auth"
 	userinterfaces "feedback_hub_2/internal/user/interfaces"
+	appconfig "feedback_hub_2/pkg/config"
 
 	_ "feedback_hub_2/docs"
 
@@ -35,13 +40,22 @@ import (
 // This allows external packages like Vercel functions to use the application without
 // directly importing internal packages.
 type Server struct {
-	dbPool         *pgxpool.Pool
-	roleHandler    *roleinterfaces.RoleHandler
-	userHandler    *userinterfaces.UserHandler
-	ideaHandler    *ideainterfaces.IdeaHandler
-	authHandler    *userinterfaces.AuthHandler
-	authMiddleware *userinterfaces.AuthMiddleware
-	initialized    bool
+	dbPool             *pgxpool.Pool
+	roleHandler        *roleinterfaces.RoleHandler
+	userHandler        *userinterfaces.UserHandler
+	accessTokenHandler *userinterfaces.AccessTokenHandler
+	mfaHandler         *userinterfaces.MFAHandler
+	oidcHandler        *userinterfaces.OIDCHandler
+	invitationHandler  *userinterfaces.InvitationHandler
+	setupHandler       *userinterfaces.SetupHandler
+	ideaHandler        *ideainterfaces.IdeaHandler
+	tagHandler         *ideainterfaces.TagHandler
+	commentHandler     *commentinterfaces.CommentHandler
+	authHandler        *userinterfaces.AuthHandler
+	authMiddleware     *userinterfaces.AuthMiddleware
+	ideaService        *ideaapp.IdeaApplicationService
+	stopJobs           context.CancelFunc
+	initialized        bool
 }
 
 // NewServer creates a new Server instance but doesn't initialize it yet.
@@ -98,29 +112,83 @@ func (s *Server) Initialize(ctx context.Context) error {
 	roleRepo := persistence.NewRoleRepository(s.dbPool)
 	userRepo := persistence.NewUserRepository(s.dbPool)
 	ideaRepo := persistence.NewIdeaRepository(s.dbPool)
+	ideaVoteRepo := persistence.NewIdeaVoteRepository(s.dbPool)
+	tagRepo := persistence.NewTagRepository(s.dbPool)
+	ideaRevisionRepo := persistence.NewIdeaRevisionRepository(s.dbPool)
+	ideaMergeRepo := persistence.NewIdeaMergeRepository(s.dbPool)
+	commentRepo := persistence.NewCommentRepository(s.dbPool)
+	sessionRepo := persistence.NewSessionRepository(s.dbPool)
+	accessTokenRepo := persistence.NewAccessTokenRepository(s.dbPool)
+	passwordResetRepo := persistence.NewPasswordResetRepository(s.dbPool)
+	emailVerificationRepo := persistence.NewEmailVerificationRepository(s.dbPool)
+	mfaRepo := persistence.NewMFARepository(s.dbPool)
+	mfaChallengeRepo := persistence.NewMFAChallengeRepository(s.dbPool)
+	oidcLoginRepo := persistence.NewOIDCLoginRepository(s.dbPool)
+	externalIdentityRepo := persistence.NewExternalIdentityRepository(s.dbPool)
+	invitationRepo := persistence.NewInvitationRepository(s.dbPool)
+	setupTokenRepo := persistence.NewSetupTokenRepository(s.dbPool)
+	loginAttemptStore := newLoginAttemptStore(s.dbPool)
 
 	// Create shared query services
 	roleQueries := queries.NewRoleQueryService(roleRepo)
 	userQueries := queries.NewUserQueryService(userRepo)
 
 	// Create domain services
-	authService := auth.NewAuthorizationService()
+	permissionResolver := auth.NewCachedPermissionResolver(roleQueries, appconfig.RolePermissionCacheTTL())
+	authPolicy := auth.AuthorizationPolicy{Permissions: permissionResolver}
+	if appconfig.RequireMFAForPrivilegedRoles() {
+		authPolicy.MFARequiredRoles = auth.PrivilegedRoles()
+	}
+	authService := auth.NewAuthorizationServiceWithPolicy(authPolicy)
+	ideaQueries := queries.NewIdeaQueryService(ideaRepo, authService)
 
 	// Create authentication services
-	jwtService := authinfra.NewJWTService()
-	passwordService := authinfra.NewPasswordService()
+	jwtService, err := newJWTService()
+	if err != nil {
+		return err
+	}
+	passwordPolicy, err := newPasswordPolicy()
+	if err != nil {
+		return err
+	}
+	passwordService := authinfra.NewPasswordService(passwordPolicy)
+
+	// Create mailer
+	mailer := newMailer()
 
 	// Create event system
 	eventBus := events.NewInMemoryEventBus()
 	eventPublisher := events.NewEventBusPublisher(eventBus)
+	for _, eventType := range auth.PermissionInvalidatingEvents {
+		if err := eventBus.Subscribe(eventType, func(ctx context.Context, event events.DomainEvent) error {
+			permissionResolver.Invalidate()
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
 
 	// Create application services
 	roleService := roleapp.NewRoleService(roleRepo, userQueries, authService, eventPublisher)
 	userService := userapp.NewUserService(userRepo, roleQueries, authService, eventPublisher)
-	ideaService := ideaapp.NewIdeaApplicationService(ideaRepo, userQueries)
+	sessionService := userapp.NewSessionService(sessionRepo, userRepo, appconfig.RefreshTokenTTL())
+	accessTokenService := userapp.NewAccessTokenService(accessTokenRepo, userRepo, roleQueries, authService)
+	passwordResetService := userapp.NewPasswordResetService(passwordResetRepo, userRepo, sessionRepo, mailer, appconfig.PasswordResetURL(), appconfig.PasswordResetTTL())
+	emailVerificationService := userapp.NewEmailVerificationService(emailVerificationRepo, userRepo, mailer, appconfig.EmailVerificationURL(), appconfig.EmailVerificationTTL())
+	setupService := userapp.NewSetupService(setupTokenRepo, userRepo, roleQueries, eventPublisher)
+	invitationService := userapp.NewInvitationService(invitationRepo, userRepo, roleQueries, authService, mailer, appconfig.InvitationURL(), appconfig.InvitationTTL())
+	loginThrottleService := userapp.NewLoginThrottleService(loginAttemptStore, userRepo, roleQueries, authService, eventPublisher, accountLoginPolicy(), ipLoginPolicy())
+	mfaService := userapp.NewMFAService(mfaRepo, mfaChallengeRepo, userRepo, sessionRepo, roleQueries, authService, appconfig.MFAIssuer())
+	identityProviders, err := newIdentityProviders()
+	if err != nil {
+		return err
+	}
+	oidcService := userapp.NewOIDCService(identityProviders, oidcLoginRepo, externalIdentityRepo, userRepo, sessionRepo, roleQueries, eventPublisher, appconfig.OIDCDefaultRole(), appconfig.OIDCAutoProvision())
+	ideaService := ideaapp.NewIdeaApplicationService(ideaRepo, ideaVoteRepo, tagRepo, ideaRevisionRepo, ideaMergeRepo, userQueries, roleQueries, authService, eventPublisher, appconfig.RequireEmailVerification())
+	commentService := commentapp.NewCommentApplicationService(commentRepo, ideaQueries, userQueries, roleQueries, authService, eventPublisher, appconfig.CommentMaxReplyDepth(), appconfig.RequireEmailVerification())
 
 	// Create bootstrap service and initialize system
-	bootstrapService := bootstrap.NewBootstrapService(roleService, userService)
+	bootstrapService := bootstrap.NewBootstrapService(roleService, userService, setupService, passwordService)
 	initCtx, initCancel := context.WithTimeout(ctx, 30*time.Second)
 	defer initCancel()
 
@@ -130,20 +198,167 @@ func (s *Server) Initialize(ctx context.Context) error {
 
 	// Create HTTP handlers
 	s.roleHandler = roleinterfaces.NewRoleHandler(roleService)
-	s.userHandler = userinterfaces.NewUserHandler(userService)
+	s.userHandler = userinterfaces.NewUserHandler(userService, loginThrottleService, invitationService)
+	s.accessTokenHandler = userinterfaces.NewAccessTokenHandler(accessTokenService)
+	s.mfaHandler = userinterfaces.NewMFAHandler(mfaService)
 	s.ideaHandler = ideainterfaces.NewIdeaHandler(ideaService)
-	s.authHandler = userinterfaces.NewAuthHandler(userService, roleService, jwtService, passwordService)
+	s.tagHandler = ideainterfaces.NewTagHandler(ideaService)
+	s.commentHandler = commentinterfaces.NewCommentHandler(commentService)
+	s.authHandler = userinterfaces.NewAuthHandler(userService, roleService, sessionService, passwordResetService, emailVerificationService, mfaService, loginThrottleService, jwtService, passwordService, appconfig.TrustProxyHeaders())
+	s.oidcHandler = userinterfaces.NewOIDCHandler(oidcService, mfaService, s.authHandler, appconfig.OIDCLoginRedirectURL())
+	s.invitationHandler = userinterfaces.NewInvitationHandler(invitationService, s.authHandler)
+	s.setupHandler = userinterfaces.NewSetupHandler(setupService, s.authHandler)
 
 	// Create authentication middleware
-	s.authMiddleware = userinterfaces.NewAuthMiddleware(userService, jwtService)
+	s.authMiddleware = userinterfaces.NewAuthMiddleware(userService, sessionService, accessTokenService, jwtService, roleQueries, authService)
+
+	s.ideaService = ideaService
 
 	s.initialized = true
 	return nil
 }
 
+// newMailer selects the mail transport from the configuration.
+// AI-hint: SMTP when SMTP_HOST is set, otherwise the local outbox (files in
+// MAIL_OUTBOX_DIR, or the log when that is unset as well).
+func newMailer() mail.Mailer {
+	if host := appconfig.SMTPHost(); host != "" {
+		return mail.NewSMTPMailer(host, appconfig.SMTPPort(), appconfig.SMTPUsername(), appconfig.SMTPPassword(), appconfig.MailFrom())
+	}
+	log.Printf("SMTP_HOST not set; outgoing mail goes to the local outbox")
+	return mail.NewOutboxMailer(appconfig.MailOutboxDir(), appconfig.MailFrom())
+}
+
+// newPasswordPolicy builds the password policy from the configuration.
+// AI-hint: The common-password blocklist always includes the bundled list; a configured
+// PASSWORD_BLOCKLIST_FILE that cannot be read stops startup instead of being skipped.
+func newPasswordPolicy() (userdomain.PasswordPolicy, error) {
+	policy := userdomain.DefaultPasswordPolicy()
+	if minLength := appconfig.PasswordMinLength(); minLength >= 0 {
+		policy.MinLength = minLength
+	}
+	if maxBytes := appconfig.PasswordMaxBytes(); maxBytes > 0 && maxBytes <= userdomain.DefaultPasswordMaxBytes {
+		policy.MaxBytes = maxBytes
+	}
+	if classes := appconfig.PasswordMinCharacterClasses(); classes >= 0 {
+		policy.MinCharacterClasses = classes
+	}
+	policy.RejectPersonalInfo = appconfig.PasswordRejectPersonalInfo()
+
+	blocklist, err := authinfra.NewPasswordBlocklist(appconfig.PasswordBlocklistFile())
+	if err != nil {
+		return policy, err
+	}
+	log.Printf("Password blocklist loaded with %d entries", blocklist.Len())
+	policy.Blocklist = blocklist
+
+	return policy, nil
+}
+
+// newJWTService builds the access token signer from the configuration.
+// AI-hint: Uses the key pair from JWT_SIGNING_KEY_FILE (plus JWT_VERIFICATION_KEYS_FILE for
+// rotation) when set, otherwise HS256 with JWT_SECRET. Refuses to fall back to the
+// development secret in production.
+func newJWTService() (*authinfra.JWTService, error) {
+	if path := appconfig.JWTSigningKeyFile(); path != "" {
+		signingKey, err := authinfra.LoadJWTSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var verificationKeys []*authinfra.JWTKey
+		if path := appconfig.JWTVerificationKeysFile(); path != "" {
+			verificationKeys, err = authinfra.LoadJWTKeys(path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		log.Printf("Signing access tokens with %s key %s (%d additional verification keys)", signingKey.Method.Alg(), signingKey.ID, len(verificationKeys))
+		return authinfra.NewKeyPairJWTService(signingKey, verificationKeys, appconfig.AccessTokenTTL())
+	}
+
+	secret := appconfig.JWTSecret()
+	if secret == "" || secret == authinfra.DevJWTSecret {
+		if appconfig.IsProduction() {
+			return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE or JWT_SECRET must be set in production; refusing to use the development secret")
+		}
+		log.Printf("Warning: JWT_SECRET is not set; signing access tokens with the development secret")
+		secret = authinfra.DevJWTSecret
+	}
+	return authinfra.NewJWTService(secret, appconfig.AccessTokenTTL()), nil
+}
+
+// newIdentityProviders builds the single sign-on providers from the configuration.
+// AI-hint: Each provider's callback URL is derived from OIDC_REDIRECT_BASE_URL and must be
+// registered with the provider. Discovery happens on first use.
+func newIdentityProviders() (map[string]userdomain.IdentityProvider, error) {
+	settings, err := appconfig.OIDCProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]userdomain.IdentityProvider, len(settings))
+	for _, provider := range settings {
+		providers[provider.Name] = authinfra.NewOIDCProvider(authinfra.OIDCProviderConfig{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  appconfig.OIDCRedirectBaseURL() + "/auth/oidc/" + provider.Name + "/callback",
+			Scopes:       provider.Scopes,
+		}, nil)
+		log.Printf("Single sign-on enabled with %s (%s)", provider.Name, provider.Issuer)
+	}
+	return providers, nil
+}
+
+// newLoginAttemptStore selects where failed logins are tracked from the configuration.
+// AI-hint: PostgreSQL by default so limits hold across instances; LOGIN_ATTEMPT_STORE=memory
+// keeps them in process memory instead.
+func newLoginAttemptStore(pool *pgxpool.Pool) userdomain.LoginAttemptStore {
+	if appconfig.LoginAttemptStore() == "memory" {
+		log.Printf("Tracking failed logins in memory; limits apply per server instance")
+		return persistence.NewInMemoryLoginAttemptStore()
+	}
+	return persistence.NewLoginAttemptStore(pool)
+}
+
+// accountLoginPolicy builds the per-account login throttling policy from the configuration.
+func accountLoginPolicy() userdomain.LoginThrottlePolicy {
+	policy := userdomain.DefaultAccountLoginPolicy()
+	if maxFailures := appconfig.LoginMaxFailures(); maxFailures >= 0 {
+		policy.MaxFailures = maxFailures
+	}
+	applyLoginPolicyDurations(&policy)
+	return policy
+}
+
+// ipLoginPolicy builds the per-IP login throttling policy from the configuration.
+func ipLoginPolicy() userdomain.LoginThrottlePolicy {
+	policy := userdomain.DefaultIPLoginPolicy()
+	if maxFailures := appconfig.LoginIPMaxFailures(); maxFailures >= 0 {
+		policy.MaxFailures = maxFailures
+	}
+	applyLoginPolicyDurations(&policy)
+	return policy
+}
+
+// applyLoginPolicyDurations overrides the lockout and backoff durations shared by both policies.
+func applyLoginPolicyDurations(policy *userdomain.LoginThrottlePolicy) {
+	if duration := appconfig.LoginLockoutDuration(); duration > 0 {
+		policy.LockoutDuration = duration
+	}
+	if backoffMax := appconfig.LoginBackoffMax(); backoffMax > 0 {
+		policy.BackoffMax = backoffMax
+	}
+}
+
 // Close cleans up resources.
 // AI-hint: Cleanup method for graceful shutdown, primarily closes database connections.
 func (s *Server) Close() {
+	if s.stopJobs != nil {
+		s.stopJobs()
+	}
 	if s.dbPool != nil {
 		s.dbPool.Close()
 	}
@@ -174,7 +389,47 @@ func (s *Server) Handler() http.Handler {
 	// AI-hint: Swagger UI for API documentation
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
 
+	// AI-hint: Public keys for verifying access tokens in other services
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.authHandler.JWKS(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+		}
+	})
+
 	// AI-hint: Authentication routes (no auth required)
+	mux.HandleFunc("/auth/oidc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.oidcHandler.ListProviders(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+		}
+	})
+
+	// AI-hint: Single sign-on browser redirects: /auth/oidc/{provider}/login and /callback
+	mux.HandleFunc("/auth/oidc/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+			return
+		}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/login"):
+			s.oidcHandler.Login(w, r)
+		case strings.HasSuffix(r.URL.Path, "/callback"):
+			s.oidcHandler.Callback(w, r)
+		default:
+			web.WriteErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+
 	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			s.authHandler.Login(w, r)
@@ -185,6 +440,16 @@ func (s *Server) Handler() http.Handler {
 		}
 	})
 
+	mux.HandleFunc("/auth/login/mfa", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.LoginMFA(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
 	mux.HandleFunc("/auth/register", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			s.authHandler.Register(w, r)
@@ -195,6 +460,89 @@ func (s *Server) Handler() http.Handler {
 		}
 	})
 
+	mux.HandleFunc("/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.Refresh(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
+	mux.HandleFunc("/auth/password/forgot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.ForgotPassword(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
+	mux.HandleFunc("/auth/password/reset", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.ResetPassword(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
+	mux.HandleFunc("/auth/verify-email", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.VerifyEmail(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
+	mux.HandleFunc("/auth/verify-email/resend", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.authHandler.ResendVerificationEmail(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	}))
+
+	// AI-hint: First-run setup; answers 410 Gone once setup is complete
+	mux.HandleFunc("/setup", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.setupHandler.Setup(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	})
+
+	// AI-hint: Public invitation links; GET checks a token, POST .../accept sets the first password
+	mux.HandleFunc("/auth/invitations/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/accept") {
+			if r.Method == http.MethodPost {
+				s.invitationHandler.AcceptInvitation(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			s.invitationHandler.GetInvitation(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+		}
+	})
+
 	mux.HandleFunc("/auth/logout", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			s.authHandler.Logout(w, r)
@@ -208,13 +556,57 @@ func (s *Server) Handler() http.Handler {
 	// AI-hint: Authenticated route to get current user info
 	mux.HandleFunc("/auth/me", s.authMiddleware.RequireAuthFunc(s.authHandler.Me))
 
+	// AI-hint: Two-factor authentication management routes (authenticated)
+	mux.HandleFunc("/auth/mfa", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.mfaHandler.GetMFAStatus(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/auth/mfa/totp", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			s.mfaHandler.BeginTOTPEnrollment(w, r)
+		case http.MethodDelete:
+			s.mfaHandler.DisableTOTP(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST and DELETE allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/auth/mfa/totp/confirm", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.mfaHandler.ConfirmTOTPEnrollment(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/auth/mfa/recovery-codes", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			s.mfaHandler.RegenerateRecoveryCodes(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+		}
+	}))
+
 	// AI-hint: Role management routes (authenticated)
 	mux.HandleFunc("/roles", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			s.roleHandler.ListRoles(w, r)
 		case http.MethodPost:
-			s.roleHandler.CreateRole(w, r)
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionCreateRole, s.roleHandler.CreateRole)(w, r)
 		default:
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -223,13 +615,27 @@ func (s *Server) Handler() http.Handler {
 	}))
 
 	mux.HandleFunc("/roles/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/permissions/") {
+			switch r.Method {
+			case http.MethodPut:
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionUpdateRole, s.roleHandler.GrantPermission)(w, r)
+			case http.MethodDelete:
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionUpdateRole, s.roleHandler.RevokePermission)(w, r)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only PUT and DELETE allowed"}`))
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			s.roleHandler.GetRole(w, r)
 		case http.MethodPut:
-			s.roleHandler.UpdateRole(w, r)
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionUpdateRole, s.roleHandler.UpdateRole)(w, r)
 		case http.MethodDelete:
-			s.roleHandler.DeleteRole(w, r)
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionDeleteRole, s.roleHandler.DeleteRole)(w, r)
 		default:
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -251,10 +657,90 @@ func (s *Server) Handler() http.Handler {
 		}
 	}))
 
+	// AI-hint: Personal access tokens of the current user; registered before /users/ so "me" is not parsed as an ID
+	mux.HandleFunc("/users/me/tokens", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.accessTokenHandler.ListAccessTokens(w, r)
+		case http.MethodPost:
+			s.accessTokenHandler.CreateAccessToken(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET and POST allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/users/me/tokens/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			s.accessTokenHandler.RevokeAccessToken(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only DELETE allowed"}`))
+		}
+	}))
+
+	// AI-hint: Pending invitations of users created by administrators (authenticated)
+	mux.HandleFunc("/invitations", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.invitationHandler.ListInvitations(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/invitations/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/resend") {
+			if r.Method == http.MethodPost {
+				s.invitationHandler.ResendInvitation(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			s.invitationHandler.RevokeInvitation(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only DELETE allowed"}`))
+		}
+	}))
+
 	mux.HandleFunc("/users/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if this is a role update endpoint
 		if strings.HasSuffix(r.URL.Path, "/role") && r.Method == http.MethodPut {
-			s.userHandler.UpdateUserRole(w, r)
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionAssignRole, s.userHandler.UpdateUserRole)(w, r)
+			return
+		}
+
+		// Check if this is an account unlock endpoint
+		if strings.HasSuffix(r.URL.Path, "/unlock") {
+			if r.Method == http.MethodPost {
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionUnlockUser, s.userHandler.UnlockUser)(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a user's ideas listing endpoint
+		if strings.HasSuffix(r.URL.Path, "/ideas") {
+			if r.Method == http.MethodGet {
+				s.ideaHandler.ListIdeasByUser(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+			}
 			return
 		}
 
@@ -275,24 +761,220 @@ func (s *Server) Handler() http.Handler {
 	// AI-hint: Ideas management routes (authenticated)
 	mux.HandleFunc("/ideas", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
+		case http.MethodGet:
+			s.ideaHandler.ListIdeas(w, r)
 		case http.MethodPost:
 			s.ideaHandler.CreateIdea(w, r)
 		default:
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusMethodNotAllowed)
-			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET and POST allowed"}`))
+		}
+	}))
+
+	// AI-hint: Idea search route (authenticated); registered before /ideas/ so it is not parsed as an ID
+	mux.HandleFunc("/ideas/search", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.ideaHandler.SearchIdeas(w, r)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
 		}
 	}))
 
 	// AI-hint: Individual idea management routes (authenticated)
 	mux.HandleFunc("/ideas/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check if this is a revision history endpoint
+		if strings.Contains(r.URL.Path, "/revisions") {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/restore"):
+				if r.Method == http.MethodPost {
+					s.ideaHandler.RestoreRevision(w, r)
+					return
+				}
+			case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/revisions/diff"):
+				s.ideaHandler.DiffRevisions(w, r)
+				return
+			case r.Method == http.MethodGet:
+				s.ideaHandler.ListRevisions(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed, or POST to restore"}`))
+			return
+		}
+
+		// Check if this is a duplicate detection endpoint
+		if strings.HasSuffix(r.URL.Path, "/duplicates") {
+			if r.Method == http.MethodGet {
+				s.ideaHandler.ListDuplicates(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a merge endpoint
+		if strings.HasSuffix(r.URL.Path, "/merge") {
+			if r.Method == http.MethodPost {
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionMergeIdeas, s.ideaHandler.MergeIdea)(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a soft-delete restore endpoint
+		if strings.HasSuffix(r.URL.Path, "/restore") {
+			if r.Method == http.MethodPost {
+				s.ideaHandler.RestoreIdea(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is an archive endpoint
+		if strings.HasSuffix(r.URL.Path, "/archive") {
+			switch r.Method {
+			case http.MethodPost:
+				s.ideaHandler.ArchiveIdea(w, r)
+			case http.MethodDelete:
+				s.ideaHandler.UnarchiveIdea(w, r)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST and DELETE allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is an idea discussion endpoint
+		if strings.HasSuffix(r.URL.Path, "/comments") {
+			switch r.Method {
+			case http.MethodGet:
+				s.commentHandler.ListComments(w, r)
+			case http.MethodPost:
+				s.commentHandler.CreateComment(w, r)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET and POST allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a status workflow endpoint
+		if strings.HasSuffix(r.URL.Path, "/status") {
+			if r.Method == http.MethodPut {
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionChangeIdeaStatus, s.ideaHandler.ChangeIdeaStatus)(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only PUT allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a visibility change endpoint
+		if strings.HasSuffix(r.URL.Path, "/visibility") {
+			if r.Method == http.MethodPut {
+				s.ideaHandler.ChangeIdeaVisibility(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only PUT allowed"}`))
+			}
+			return
+		}
+
+		// Check if this is a voting endpoint
+		if strings.HasSuffix(r.URL.Path, "/vote") {
+			switch r.Method {
+			case http.MethodPost:
+				s.ideaHandler.VoteIdea(w, r)
+			case http.MethodDelete:
+				s.ideaHandler.UnvoteIdea(w, r)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST and DELETE allowed"}`))
+			}
+			return
+		}
+
 		switch r.Method {
+		case http.MethodGet:
+			s.ideaHandler.GetIdea(w, r)
 		case http.MethodPut:
 			s.ideaHandler.UpdateIdea(w, r)
+		case http.MethodDelete:
+			s.ideaHandler.DeleteIdea(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET, PUT, and DELETE allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/tags", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.tagHandler.ListTags(w, r)
+		case http.MethodPost:
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionCreateTag, s.tagHandler.CreateTag)(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET and POST allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/tags/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check if this is a tag merge endpoint
+		if strings.HasSuffix(r.URL.Path, "/merge") {
+			if r.Method == http.MethodPost {
+				s.authMiddleware.RequirePermissionFunc(auth.PermissionUpdateTag, s.tagHandler.MergeTag)(w, r)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				w.Write([]byte(`{"error":"Method Not Allowed","message":"Only POST allowed"}`))
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.tagHandler.GetTag(w, r)
+		case http.MethodPut:
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionUpdateTag, s.tagHandler.RenameTag)(w, r)
+		case http.MethodDelete:
+			s.authMiddleware.RequirePermissionFunc(auth.PermissionDeleteTag, s.tagHandler.DeleteTag)(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only GET, PUT, and DELETE allowed"}`))
+		}
+	}))
+
+	mux.HandleFunc("/comments/", s.authMiddleware.RequireAuthFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			s.commentHandler.UpdateComment(w, r)
+		case http.MethodDelete:
+			s.commentHandler.DeleteComment(w, r)
 		default:
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusMethodNotAllowed)
-			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only PUT allowed"}`))
+			w.Write([]byte(`{"error":"Method Not Allowed","message":"Only PUT and DELETE allowed"}`))
 		}
 	}))
 
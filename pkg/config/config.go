@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -25,3 +28,404 @@ func Load() error {
 func DatabaseURL() string {
 	return os.Getenv("DATABASE_URL")
 }
+
+// CommentMaxReplyDepth returns how deeply comment replies may nest.
+// AI-hint: Read from COMMENT_MAX_REPLY_DEPTH; 0 disables replies. Returns -1 when unset
+// or invalid so the comment service falls back to its domain default.
+func CommentMaxReplyDepth() int {
+	value := os.Getenv("COMMENT_MAX_REPLY_DEPTH")
+	if value == "" {
+		return -1
+	}
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < 0 {
+		return -1
+	}
+	return depth
+}
+
+// IdeaPurgeRetentionDays returns how many days soft-deleted ideas are kept before purging.
+// AI-hint: Read from IDEA_PURGE_RETENTION_DAYS; 0 disables purging. Returns -1 when unset
+// or invalid so callers fall back to the idea domain default.
+func IdeaPurgeRetentionDays() int {
+	value := os.Getenv("IDEA_PURGE_RETENTION_DAYS")
+	if value == "" {
+		return -1
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return -1
+	}
+	return days
+}
+
+// IdeaPurgeInterval returns how often the purge job looks for expired soft-deleted ideas.
+// AI-hint: Read from IDEA_PURGE_INTERVAL as a Go duration (e.g. "30m"); defaults to one hour.
+func IdeaPurgeInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("IDEA_PURGE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Hour
+	}
+	return interval
+}
+
+// AccessTokenTTL returns how long access tokens stay valid.
+// AI-hint: Read from ACCESS_TOKEN_TTL as a Go duration (e.g. "15m"). Returns 0 when unset
+// or invalid so the JWT service falls back to its default.
+func AccessTokenTTL() time.Duration {
+	return positiveDuration("ACCESS_TOKEN_TTL")
+}
+
+// RefreshTokenTTL returns how long a session may go unused before its refresh token expires.
+// AI-hint: Read from REFRESH_TOKEN_TTL as a Go duration (e.g. "720h"). Returns 0 when unset
+// or invalid so the session service falls back to the user domain default.
+func RefreshTokenTTL() time.Duration {
+	return positiveDuration("REFRESH_TOKEN_TTL")
+}
+
+// IsProduction reports whether the server runs in production.
+// AI-hint: Read from ENVIRONMENT; only the exact value "production" counts.
+func IsProduction() bool {
+	return os.Getenv("ENVIRONMENT") == "production"
+}
+
+// JWTSecret returns the shared secret for HS256 access tokens.
+// AI-hint: Read from JWT_SECRET. Only used when no signing key file is configured.
+func JWTSecret() string {
+	return os.Getenv("JWT_SECRET")
+}
+
+// JWTSigningKeyFile returns the PEM file holding the private key that signs access tokens.
+// AI-hint: Read from JWT_SIGNING_KEY_FILE; an RSA key signs with RS256 and an Ed25519 key
+// with EdDSA. When empty, tokens are signed with JWT_SECRET using HS256.
+func JWTSigningKeyFile() string {
+	return os.Getenv("JWT_SIGNING_KEY_FILE")
+}
+
+// JWTVerificationKeysFile returns a PEM file of additional keys that still verify access tokens.
+// AI-hint: Read from JWT_VERIFICATION_KEYS_FILE. Holds the public keys of previous signing
+// keys during a rotation; they are published in the JWKS document alongside the signing key.
+func JWTVerificationKeysFile() string {
+	return os.Getenv("JWT_VERIFICATION_KEYS_FILE")
+}
+
+// positiveDuration parses an environment variable as a positive Go duration, or returns 0.
+func positiveDuration(key string) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || duration <= 0 {
+		return 0
+	}
+	return duration
+}
+
+// PasswordResetTTL returns how long password reset links stay valid.
+// AI-hint: Read from PASSWORD_RESET_TTL as a Go duration (e.g. "30m"). Returns 0 when unset
+// or invalid so the password reset service falls back to the user domain default.
+func PasswordResetTTL() time.Duration {
+	return positiveDuration("PASSWORD_RESET_TTL")
+}
+
+// PasswordResetURL returns the page that password reset links point to.
+// AI-hint: Read from PASSWORD_RESET_URL; the reset token is appended as the "token" query
+// parameter. Defaults to a local frontend for development.
+func PasswordResetURL() string {
+	if value := os.Getenv("PASSWORD_RESET_URL"); value != "" {
+		return value
+	}
+	return "http://localhost:3000/reset-password"
+}
+
+// SMTPHost returns the SMTP server used for outgoing mail.
+// AI-hint: Read from SMTP_HOST. When empty, mail goes to the local outbox instead (see MailOutboxDir).
+func SMTPHost() string {
+	return os.Getenv("SMTP_HOST")
+}
+
+// SMTPPort returns the SMTP server port.
+// AI-hint: Read from SMTP_PORT; defaults to the submission port 587.
+func SMTPPort() int {
+	port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil || port <= 0 {
+		return 587
+	}
+	return port
+}
+
+// SMTPUsername returns the SMTP login; empty disables SMTP authentication.
+func SMTPUsername() string {
+	return os.Getenv("SMTP_USERNAME")
+}
+
+// SMTPPassword returns the SMTP password.
+func SMTPPassword() string {
+	return os.Getenv("SMTP_PASSWORD")
+}
+
+// MailFrom returns the sender address for outgoing mail.
+// AI-hint: Read from MAIL_FROM; defaults to a placeholder that is fine for the local outbox.
+func MailFrom() string {
+	if value := os.Getenv("MAIL_FROM"); value != "" {
+		return value
+	}
+	return "Feedback Hub <noreply@localhost>"
+}
+
+// MailOutboxDir returns the directory the local outbox writes mails to.
+// AI-hint: Read from MAIL_OUTBOX_DIR and only used when SMTP_HOST is unset; when empty,
+// mails are printed to the log instead.
+func MailOutboxDir() string {
+	return os.Getenv("MAIL_OUTBOX_DIR")
+}
+
+// RequireEmailVerification reports whether users must verify their email address
+// before creating ideas or comments.
+// AI-hint: Read from REQUIRE_EMAIL_VERIFICATION ("true"/"false"); defaults to false.
+// Unverified users can always log in and read.
+func RequireEmailVerification() bool {
+	required, err := strconv.ParseBool(os.Getenv("REQUIRE_EMAIL_VERIFICATION"))
+	return err == nil && required
+}
+
+// EmailVerificationURL returns the page that email verification links point to.
+// AI-hint: Read from EMAIL_VERIFICATION_URL; the token is appended as the "token" query
+// parameter. Defaults to a local frontend for development.
+func EmailVerificationURL() string {
+	if value := os.Getenv("EMAIL_VERIFICATION_URL"); value != "" {
+		return value
+	}
+	return "http://localhost:3000/verify-email"
+}
+
+// EmailVerificationTTL returns how long email verification links stay valid.
+// AI-hint: Read from EMAIL_VERIFICATION_TTL as a Go duration (e.g. "48h"). Returns 0 when
+// unset or invalid so the verification service falls back to the user domain default.
+func EmailVerificationTTL() time.Duration {
+	return positiveDuration("EMAIL_VERIFICATION_TTL")
+}
+
+// InvitationURL returns the page that invitation links point to.
+// AI-hint: Read from INVITATION_URL; the token is appended as the "token" query parameter.
+// The page should post the chosen password to /auth/invitations/{token}/accept.
+func InvitationURL() string {
+	if value := os.Getenv("INVITATION_URL"); value != "" {
+		return value
+	}
+	return "http://localhost:3000/accept-invitation"
+}
+
+// InvitationTTL returns how long invitation links stay valid.
+// AI-hint: Read from INVITATION_TTL as a Go duration (e.g. "72h"). Returns 0 when unset
+// or invalid so the invitation service falls back to the user domain default.
+func InvitationTTL() time.Duration {
+	return positiveDuration("INVITATION_TTL")
+}
+
+// RequireMFAForPrivilegedRoles reports whether Super Users and Product Owners must use
+// two-factor authentication.
+// AI-hint: Read from REQUIRE_MFA_FOR_PRIVILEGED_ROLES ("true"/"false"); defaults to false.
+// When enabled, members of those roles get no role permissions until they log in with 2FA.
+func RequireMFAForPrivilegedRoles() bool {
+	required, err := strconv.ParseBool(os.Getenv("REQUIRE_MFA_FOR_PRIVILEGED_ROLES"))
+	return err == nil && required
+}
+
+// RolePermissionCacheTTL returns how long role permissions are cached before being reloaded.
+// AI-hint: Read from ROLE_PERMISSION_CACHE_TTL as a Go duration; defaults to one minute.
+// Changes made on this instance apply at once, so this only bounds how long other
+// instances keep stale permissions.
+func RolePermissionCacheTTL() time.Duration {
+	if ttl := positiveDuration("ROLE_PERMISSION_CACHE_TTL"); ttl > 0 {
+		return ttl
+	}
+	return time.Minute
+}
+
+// MFAIssuer returns the issuer name shown in authenticator apps.
+// AI-hint: Read from MFA_ISSUER; defaults to "Feedback Hub".
+func MFAIssuer() string {
+	if value := os.Getenv("MFA_ISSUER"); value != "" {
+		return value
+	}
+	return "Feedback Hub"
+}
+
+// LoginMaxFailures returns how many failed logins lock an account.
+// AI-hint: Read from LOGIN_MAX_FAILURES; 0 disables per-account throttling. Returns -1
+// when unset or invalid so the server falls back to the user domain default.
+func LoginMaxFailures() int {
+	return nonNegativeInt("LOGIN_MAX_FAILURES")
+}
+
+// LoginIPMaxFailures returns how many failed logins from one client IP lock that IP.
+// AI-hint: Read from LOGIN_IP_MAX_FAILURES; 0 disables per-IP throttling. Returns -1
+// when unset or invalid so the server falls back to the user domain default.
+func LoginIPMaxFailures() int {
+	return nonNegativeInt("LOGIN_IP_MAX_FAILURES")
+}
+
+// LoginLockoutDuration returns how long an account or IP stays locked.
+// AI-hint: Read from LOGIN_LOCKOUT_DURATION as a Go duration (e.g. "15m"). Returns 0 when
+// unset or invalid so the server falls back to the user domain default.
+func LoginLockoutDuration() time.Duration {
+	return positiveDuration("LOGIN_LOCKOUT_DURATION")
+}
+
+// LoginBackoffMax returns the longest delay imposed between failed logins.
+// AI-hint: Read from LOGIN_BACKOFF_MAX as a Go duration (e.g. "30s"). Returns 0 when unset
+// or invalid so the server falls back to the user domain default.
+func LoginBackoffMax() time.Duration {
+	return positiveDuration("LOGIN_BACKOFF_MAX")
+}
+
+// LoginAttemptStore returns where failed logins are tracked: "postgres" or "memory".
+// AI-hint: Read from LOGIN_ATTEMPT_STORE; defaults to "postgres". The memory store only
+// suits a single server instance.
+func LoginAttemptStore() string {
+	if value := os.Getenv("LOGIN_ATTEMPT_STORE"); value != "" {
+		return value
+	}
+	return "postgres"
+}
+
+// TrustProxyHeaders reports whether client IPs are taken from X-Forwarded-For.
+// AI-hint: Read from TRUST_PROXY_HEADERS ("true"/"false"); defaults to false. Enable only
+// behind a reverse proxy that sets the header, otherwise clients can spoof their IP.
+func TrustProxyHeaders() bool {
+	trusted, err := strconv.ParseBool(os.Getenv("TRUST_PROXY_HEADERS"))
+	return err == nil && trusted
+}
+
+// nonNegativeInt parses an environment variable as a non-negative integer, or returns -1.
+func nonNegativeInt(key string) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return -1
+	}
+	return value
+}
+
+// PasswordMinLength returns the minimum number of characters in a password.
+// AI-hint: Read from PASSWORD_MIN_LENGTH. Returns -1 when unset or invalid so the server
+// falls back to the user domain default.
+func PasswordMinLength() int {
+	return nonNegativeInt("PASSWORD_MIN_LENGTH")
+}
+
+// PasswordMaxBytes returns the maximum password length in bytes.
+// AI-hint: Read from PASSWORD_MAX_BYTES. Returns -1 when unset or invalid; values above
+// bcrypt's 72-byte limit are ignored by the server.
+func PasswordMaxBytes() int {
+	return nonNegativeInt("PASSWORD_MAX_BYTES")
+}
+
+// PasswordMinCharacterClasses returns how many character classes a password must mix.
+// AI-hint: Read from PASSWORD_MIN_CHARACTER_CLASSES (0-4; lowercase, uppercase, digits,
+// symbols). Returns -1 when unset or invalid so no class requirement applies.
+func PasswordMinCharacterClasses() int {
+	return nonNegativeInt("PASSWORD_MIN_CHARACTER_CLASSES")
+}
+
+// PasswordRejectPersonalInfo reports whether passwords may not contain the user's email or name.
+// AI-hint: Read from PASSWORD_REJECT_PERSONAL_INFO ("true"/"false"); defaults to true.
+func PasswordRejectPersonalInfo() bool {
+	reject, err := strconv.ParseBool(os.Getenv("PASSWORD_REJECT_PERSONAL_INFO"))
+	return err != nil || reject
+}
+
+// PasswordBlocklistFile returns the path of an operator-supplied list of forbidden passwords.
+// AI-hint: Read from PASSWORD_BLOCKLIST_FILE; one password per line, added to the bundled
+// list of common passwords. Empty means only the bundled list is used.
+func PasswordBlocklistFile() string {
+	return os.Getenv("PASSWORD_BLOCKLIST_FILE")
+}
+
+// OIDCProviderSettings is the client registration of one OpenID Connect provider.
+// AI-hint: Name appears in the /auth/oidc/{provider}/... URLs; ClientSecret may be empty
+// for public clients that rely on PKCE alone.
+type OIDCProviderSettings struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// OIDCProviders returns the configured single sign-on providers.
+// AI-hint: OIDC_PROVIDERS lists provider names separated by commas (lowercase letters,
+// digits, and dashes). Each provider NAME is configured through OIDC_<NAME>_ISSUER,
+// OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET, and optionally OIDC_<NAME>_SCOPES
+// (space separated), with NAME upper-cased and dashes turned into underscores.
+func OIDCProviders() ([]OIDCProviderSettings, error) {
+	var providers []OIDCProviderSettings
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !isValidProviderName(name) {
+			return nil, fmt.Errorf("invalid OIDC provider name %q: use lowercase letters, digits, and dashes", name)
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider := OIDCProviderSettings{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("OIDC provider %q needs %sISSUER and %sCLIENT_ID", name, prefix, prefix)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+// isValidProviderName reports whether a provider name is safe to use in URL paths.
+func isValidProviderName(name string) bool {
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// OIDCRedirectBaseURL returns the public base URL of this API for single sign-on callbacks.
+// AI-hint: Read from OIDC_REDIRECT_BASE_URL; providers redirect to
+// <base>/auth/oidc/<name>/callback, which must be registered with them. Defaults to the
+// local API.
+func OIDCRedirectBaseURL() string {
+	if value := os.Getenv("OIDC_REDIRECT_BASE_URL"); value != "" {
+		return strings.TrimSuffix(value, "/")
+	}
+	return "http://localhost:8080"
+}
+
+// OIDCLoginRedirectURL returns the frontend page browsers land on after single sign-on.
+// AI-hint: Read from OIDC_LOGIN_REDIRECT_URL; failures append ?error=<code>. Defaults to a
+// local frontend for development.
+func OIDCLoginRedirectURL() string {
+	if value := os.Getenv("OIDC_LOGIN_REDIRECT_URL"); value != "" {
+		return value
+	}
+	return "http://localhost:3000/"
+}
+
+// OIDCDefaultRole returns the role given to users provisioned through single sign-on.
+// AI-hint: Read from OIDC_DEFAULT_ROLE; defaults to "Contributor" like self-registration.
+func OIDCDefaultRole() string {
+	if value := os.Getenv("OIDC_DEFAULT_ROLE"); value != "" {
+		return value
+	}
+	return "Contributor"
+}
+
+// OIDCAutoProvision reports whether single sign-on creates accounts for unknown users.
+// AI-hint: Read from OIDC_AUTO_PROVISION ("true"/"false"); defaults to true. When false,
+// only users whose email matches an existing account can log in through a provider.
+func OIDCAutoProvision() bool {
+	provision, err := strconv.ParseBool(os.Getenv("OIDC_AUTO_PROVISION"))
+	return err != nil || provision
+}
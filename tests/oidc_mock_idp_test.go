@@ -0,0 +1,259 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"feedback_hub_2/internal/user/domain"
+	authinfra "feedback_hub_2/internal/user/infrastructure/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIdP is a minimal OpenID Connect provider for testing single sign-on.
+// AI-hint: Implements discovery, the authorization endpoint (which logs in a fixed user
+// without a login page), the token endpoint with PKCE and client secret checks, and the JWKS.
+type mockIdP struct {
+	server       *httptest.Server
+	key          *rsa.PrivateKey
+	clientID     string
+	clientSecret string
+
+	mu       sync.Mutex
+	codes    map[string]url.Values
+	claims   jwt.MapClaims
+	audience string
+	rogueKey *rsa.PrivateKey
+}
+
+// newMockIdP starts a mock provider that issues ID tokens with the given extra claims.
+func newMockIdP(t *testing.T, claims jwt.MapClaims) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &mockIdP{key: key, clientID: "feedback-hub", clientSecret: "s3cret", codes: map[string]url.Values{}, claims: claims}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": "mock-key",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize issues a code for the fixed user and redirects back to the client.
+func (idp *mockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != idp.clientID || query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	idp.mu.Lock()
+	code := "code-" + query.Get("state")
+	idp.codes[code] = query
+	idp.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token redeems a code once, checking the client secret, redirect URI, and PKCE verifier.
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, _ := r.BasicAuth()
+	r.ParseForm()
+
+	idp.mu.Lock()
+	authorization, found := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if clientID != idp.clientID || clientSecret != idp.clientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+	if !found || r.PostForm.Get("redirect_uri") != authorization.Get("redirect_uri") ||
+		domain.PKCEChallenge(r.PostForm.Get("code_verifier")) != authorization.Get("code_challenge") {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idp.mu.Lock()
+	audience, signingKey := idp.clientID, idp.key
+	if idp.audience != "" {
+		audience = idp.audience
+	}
+	if idp.rogueKey != nil {
+		signingKey = idp.rogueKey
+	}
+	claims := jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"sub":   "employee-42",
+		"aud":   audience,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": authorization.Get("nonce"),
+	}
+	for name, value := range idp.claims {
+		claims[name] = value
+	}
+	idp.mu.Unlock()
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = "mock-key"
+	signed, _ := idToken.SignedString(signingKey)
+	json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": signed})
+}
+
+// login runs the browser part of the flow against the mock and returns the callback's code and state.
+func (idp *mockIdP) login(t *testing.T, provider *authinfra.OIDCProvider, login *domain.OIDCLogin, state string) (string, string) {
+	authURL, err := provider.AuthorizationURL(context.Background(), state, login.Nonce, login.CodeChallenge())
+	require.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/auth/oidc/mock/callback", callback.Path)
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func newMockProvider(idp *mockIdP) *authinfra.OIDCProvider {
+	return authinfra.NewOIDCProvider(authinfra.OIDCProviderConfig{
+		Issuer:       idp.server.URL,
+		ClientID:     idp.clientID,
+		ClientSecret: idp.clientSecret,
+		RedirectURL:  "http://localhost:8080/auth/oidc/mock/callback",
+	}, nil)
+}
+
+// TestOIDCProvider_MockIdP runs the authorization code flow with PKCE against a mock provider.
+func TestOIDCProvider_MockIdP(t *testing.T) {
+	idp := newMockIdP(t, jwt.MapClaims{
+		"email":          "Jane.Doe@Example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"amr":            []string{"pwd", "mfa"},
+	})
+	provider := newMockProvider(idp)
+
+	t.Run("returns the verified identity", func(t *testing.T) {
+		login, state, err := domain.NewOIDCLogin("mock", time.Minute)
+		require.NoError(t, err)
+
+		code, returnedState := idp.login(t, provider, login, state)
+		assert.Equal(t, state, returnedState)
+
+		identity, err := provider.Exchange(context.Background(), code, login.CodeVerifier, login.Nonce)
+		require.NoError(t, err)
+		assert.Equal(t, "employee-42", identity.Subject)
+		assert.Equal(t, "jane.doe@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.Equal(t, "Jane Doe", identity.Name)
+		assert.True(t, identity.UsedMFA())
+	})
+
+	t.Run("codes cannot be redeemed twice", func(t *testing.T) {
+		login, state, _ := domain.NewOIDCLogin("mock", time.Minute)
+		code, _ := idp.login(t, provider, login, state)
+
+		_, err := provider.Exchange(context.Background(), code, login.CodeVerifier, login.Nonce)
+		require.NoError(t, err)
+		_, err = provider.Exchange(context.Background(), code, login.CodeVerifier, login.Nonce)
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("rejects a wrong code verifier", func(t *testing.T) {
+		login, state, _ := domain.NewOIDCLogin("mock", time.Minute)
+		code, _ := idp.login(t, provider, login, state)
+
+		_, err := provider.Exchange(context.Background(), code, "not-the-verifier", login.Nonce)
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("rejects an ID token for another login", func(t *testing.T) {
+		login, state, _ := domain.NewOIDCLogin("mock", time.Minute)
+		code, _ := idp.login(t, provider, login, state)
+
+		_, err := provider.Exchange(context.Background(), code, login.CodeVerifier, "other-nonce")
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("rejects an ID token for another client", func(t *testing.T) {
+		idp.mu.Lock()
+		idp.audience = "someone-else"
+		idp.mu.Unlock()
+		defer func() { idp.mu.Lock(); idp.audience = ""; idp.mu.Unlock() }()
+
+		login, state, _ := domain.NewOIDCLogin("mock", time.Minute)
+		code, _ := idp.login(t, provider, login, state)
+
+		_, err := provider.Exchange(context.Background(), code, login.CodeVerifier, login.Nonce)
+		assert.ErrorContains(t, err, "invalid ID token")
+	})
+
+	t.Run("rejects an ID token signed with another key", func(t *testing.T) {
+		rogueKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		idp.mu.Lock()
+		idp.rogueKey = rogueKey
+		idp.mu.Unlock()
+		defer func() { idp.mu.Lock(); idp.rogueKey = nil; idp.mu.Unlock() }()
+
+		login, state, _ := domain.NewOIDCLogin("mock", time.Minute)
+		code, _ := idp.login(t, provider, login, state)
+
+		_, err = provider.Exchange(context.Background(), code, login.CodeVerifier, login.Nonce)
+		assert.ErrorContains(t, err, "invalid ID token")
+	})
+}
+
+// TestOIDCProvider_IssuerMismatch verifies discovery documents naming another issuer are refused.
+func TestOIDCProvider_IssuerMismatch(t *testing.T) {
+	idp := newMockIdP(t, nil)
+	provider := authinfra.NewOIDCProvider(authinfra.OIDCProviderConfig{
+		Issuer:   idp.server.URL + "/tenant",
+		ClientID: idp.clientID,
+	}, nil)
+
+	_, err := provider.AuthorizationURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}